@@ -150,9 +150,15 @@ const (
 	ResourceKindNetworkPolicy            = "networkpolicy"
 	ResourceKindIngressClass             = "ingressclass"
 	ResourceKindHttpRouteGroup           = "httproutegroup"
+	ResourceKindTCPRoute                 = "tcproute"
 	ResourceKindTrafficSplit             = "trafficsplit"
 	ResourceKindTrafficTarget            = "traffictarget"
 	ResourceKindMeshConfig               = "meshconfig"
+	ResourceKindMultiClusterService      = "multiclusterservice"
+	ResourceKindEgress                   = "egress"
+	ResourceKindIngressBackend           = "ingressbackend"
+	ResourceKindUpstreamTrafficSetting   = "upstreamtrafficsetting"
+	ResourceKindRetry                    = "retry"
 )
 
 // Scalable method return whether ResourceKind is scalable.
@@ -209,6 +215,7 @@ const (
 	ClientTypeSmiSplitClient      = "smisplitclient"
 	ClientTypeSmiAccessClient     = "smiaccessclient"
 	ClientTypeOsmConfigClient     = "osmconfigclient"
+	ClientTypeOsmPolicyClient     = "osmpolicyclient"
 )
 
 // APIMapping is the mapping from resource kind to ClientType and Namespaced.
@@ -258,9 +265,15 @@ var KindToAPIMapping = map[string]APIMapping{
 	ResourceKindRoleBinding:              {"rolebindings", ClientTypeRbacClient, true},
 	ResourceKindPlugin:                   {"plugins", ClientTypePluginsClient, true},
 	ResourceKindHttpRouteGroup:           {"httproutegroups", ClientTypeSmiSpecsClient, true},
-	ResourceKindTrafficSplit:             {"trafficsplit", ClientTypeSmiSplitClient, true},
+	ResourceKindTCPRoute:                 {"tcproutes", ClientTypeSmiSpecsClient, true},
+	ResourceKindTrafficSplit:             {"trafficsplits", ClientTypeSmiSplitClient, true},
 	ResourceKindTrafficTarget:            {"traffictargets", ClientTypeSmiAccessClient, true},
 	ResourceKindMeshConfig:               {"meshconfigs", ClientTypeOsmConfigClient, true},
+	ResourceKindMultiClusterService:      {"multiclusterservices", ClientTypeOsmConfigClient, true},
+	ResourceKindEgress:                   {"egresses", ClientTypeOsmPolicyClient, true},
+	ResourceKindIngressBackend:           {"ingressbackends", ClientTypeOsmPolicyClient, true},
+	ResourceKindUpstreamTrafficSetting:   {"upstreamtrafficsettings", ClientTypeOsmPolicyClient, true},
+	ResourceKindRetry:                    {"retries", ClientTypeOsmPolicyClient, true},
 }
 
 // IsSelectorMatching returns true when an object with the given selector targets the same
@@ -102,6 +102,30 @@ func (self *holderBuilder) SetSidecarHost(sidecarHost string) *holderBuilder {
 	return self
 }
 
+// SetPrometheusHost 'prometheus-host' argument of Dashboard binary.
+func (self *holderBuilder) SetPrometheusHost(prometheusHost string) *holderBuilder {
+	self.holder.prometheusHost = prometheusHost
+	return self
+}
+
+// SetPrometheusService 'prometheus-service' argument of Dashboard binary.
+func (self *holderBuilder) SetPrometheusService(prometheusService string) *holderBuilder {
+	self.holder.prometheusService = prometheusService
+	return self
+}
+
+// SetPrometheusQueries 'prometheus-queries' argument of Dashboard binary.
+func (self *holderBuilder) SetPrometheusQueries(prometheusQueries string) *holderBuilder {
+	self.holder.prometheusQueries = prometheusQueries
+	return self
+}
+
+// SetCAExpiryWarningDays 'ca-expiry-warning-days' argument of Dashboard binary.
+func (self *holderBuilder) SetCAExpiryWarningDays(days int) *holderBuilder {
+	self.holder.caExpiryWarningDays = days
+	return self
+}
+
 // SetKubeConfigFile 'kubeconfig' argument of Dashboard binary.
 func (self *holderBuilder) SetKubeConfigFile(kubeConfigFile string) *holderBuilder {
 	self.holder.kubeConfigFile = kubeConfigFile
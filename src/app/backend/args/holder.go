@@ -40,6 +40,10 @@ type holder struct {
 	metricsProvider      string
 	heapsterHost         string
 	sidecarHost          string
+	prometheusHost       string
+	prometheusService    string
+	prometheusQueries    string
+	caExpiryWarningDays  int
 	kubeConfigFile       string
 	systemBanner         string
 	systemBannerSeverity string
@@ -130,6 +134,26 @@ func (self *holder) GetSidecarHost() string {
 	return self.sidecarHost
 }
 
+// GetPrometheusHost 'prometheus-host' argument of Dashboard binary.
+func (self *holder) GetPrometheusHost() string {
+	return self.prometheusHost
+}
+
+// GetPrometheusService 'prometheus-service' argument of Dashboard binary.
+func (self *holder) GetPrometheusService() string {
+	return self.prometheusService
+}
+
+// GetPrometheusQueries 'prometheus-queries' argument of Dashboard binary.
+func (self *holder) GetPrometheusQueries() string {
+	return self.prometheusQueries
+}
+
+// GetCAExpiryWarningDays 'ca-expiry-warning-days' argument of Dashboard binary.
+func (self *holder) GetCAExpiryWarningDays() int {
+	return self.caExpiryWarningDays
+}
+
 // GetKubeConfigFile 'kubeconfig' argument of Dashboard binary.
 func (self *holder) GetKubeConfigFile() string {
 	return self.kubeConfigFile
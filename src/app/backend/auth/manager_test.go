@@ -28,7 +28,10 @@ import (
 
 	pluginclientset "github.com/kubernetes/dashboard/src/app/backend/plugin/client/clientset/versioned"
 	osmconfigclientset "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned"
+	osmpolicyclientset "github.com/openservicemesh/osm/pkg/gen/client/policy/clientset/versioned"
 	smiaccessclientset "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/access/clientset/versioned"
+	smispecsclientset "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/specs/clientset/versioned"
+	smisplitclientset "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/split/clientset/versioned"
 	v1 "k8s.io/api/authorization/v1"
 	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	"k8s.io/client-go/kubernetes"
@@ -58,12 +61,23 @@ func (self *fakeClientManager) PluginClient(req *restful.Request) (pluginclients
 	return nil, nil
 }
 
+func (self *fakeClientManager) SmiSpecsClient(req *restful.Request) (smispecsclientset.Interface, error) {
+	return nil, nil
+}
+
+func (self *fakeClientManager) SmiSplitClient(req *restful.Request) (smisplitclientset.Interface, error) {
+	return nil, nil
+}
+
 func (self *fakeClientManager) SmiAccessClient(req *restful.Request) (smiaccessclientset.Interface, error) {
 	return nil, nil
 }
 func (self *fakeClientManager) OsmConfigClient(req *restful.Request) (osmconfigclientset.Interface, error) {
 	return nil, nil
 }
+func (self *fakeClientManager) OsmPolicyClient(req *restful.Request) (osmpolicyclientset.Interface, error) {
+	return nil, nil
+}
 
 func (self *fakeClientManager) InsecureClient() kubernetes.Interface {
 	return nil
@@ -77,12 +91,23 @@ func (self *fakeClientManager) InsecurePluginClient() pluginclientset.Interface
 	return nil
 }
 
+func (self *fakeClientManager) InsecureSmiSpecsClient() smispecsclientset.Interface {
+	return nil
+}
+
+func (self *fakeClientManager) InsecureSmiSplitClient() smisplitclientset.Interface {
+	return nil
+}
+
 func (slef *fakeClientManager) InsecureSmiAccessClient() smiaccessclientset.Interface {
 	return nil
 }
 func (slef *fakeClientManager) InsecureOsmConfigClient() osmconfigclientset.Interface {
 	return nil
 }
+func (self *fakeClientManager) InsecureOsmPolicyClient() osmpolicyclientset.Interface {
+	return nil
+}
 
 func (self *fakeClientManager) SetTokenManager(manager authApi.TokenManager) {}
 
@@ -102,8 +127,12 @@ func (self *fakeClientManager) HasAccess(authInfo api.AuthInfo) (string, error)
 	return "", self.HasAccessError
 }
 
+func (self *fakeClientManager) Username(req *restful.Request) (string, error) {
+	return "", nil
+}
+
 func (self *fakeClientManager) VerberClient(req *restful.Request, config *rest.Config) (clientapi.ResourceVerber, error) {
-	return client.NewResourceVerber(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil), nil
+	return client.NewResourceVerber(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil), nil
 }
 
 func (self *fakeClientManager) CanI(req *restful.Request, ssar *v1.SelfSubjectAccessReview) bool {
@@ -29,6 +29,7 @@ import (
 	smisplitclientset "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/split/clientset/versioned"
 
 	osmconfigclientset "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned"
+	osmpolicyclientset "github.com/openservicemesh/osm/pkg/gen/client/policy/clientset/versioned"
 
 	authApi "github.com/kubernetes/dashboard/src/app/backend/auth/api"
 	pluginclientset "github.com/kubernetes/dashboard/src/app/backend/plugin/client/clientset/versioned"
@@ -53,17 +54,20 @@ type ClientManager interface {
 	SmiSplitClient(req *restful.Request) (smisplitclientset.Interface, error)
 	SmiAccessClient(req *restful.Request) (smiaccessclientset.Interface, error)
 	OsmConfigClient(req *restful.Request) (osmconfigclientset.Interface, error)
+	OsmPolicyClient(req *restful.Request) (osmpolicyclientset.Interface, error)
 	InsecureAPIExtensionsClient() apiextensionsclientset.Interface
 	InsecurePluginClient() pluginclientset.Interface
 	InsecureSmiSpecsClient() smispecsclientset.Interface
 	InsecureSmiSplitClient() smisplitclientset.Interface
 	InsecureSmiAccessClient() smiaccessclientset.Interface
 	InsecureOsmConfigClient() osmconfigclientset.Interface
+	InsecureOsmPolicyClient() osmpolicyclientset.Interface
 	CanI(req *restful.Request, ssar *v1.SelfSubjectAccessReview) bool
 	Config(req *restful.Request) (*rest.Config, error)
 	ClientCmdConfig(req *restful.Request) (clientcmd.ClientConfig, error)
 	CSRFKey() string
 	HasAccess(authInfo api.AuthInfo) (string, error)
+	Username(req *restful.Request) (string, error)
 	VerberClient(req *restful.Request, config *rest.Config) (ResourceVerber, error)
 	SetTokenManager(manager authApi.TokenManager)
 }
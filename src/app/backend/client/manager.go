@@ -37,6 +37,7 @@ import (
 	smisplitclientset "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/split/clientset/versioned"
 
 	osmconfigclientset "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned"
+	osmpolicyclientset "github.com/openservicemesh/osm/pkg/gen/client/policy/clientset/versioned"
 
 	pluginclientset "github.com/kubernetes/dashboard/src/app/backend/plugin/client/clientset/versioned"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/customresourcedefinition"
@@ -104,6 +105,9 @@ type clientManager struct {
 	// OSM Config client created without providing auth info. It uses permissions granted to
 	// service account used by dashboard or kubeconfig file if it was passed during dashboard init.
 	insecureOsmConfigClient osmconfigclientset.Interface
+	// OSM Policy client created without providing auth info. It uses permissions granted to
+	// service account used by dashboard or kubeconfig file if it was passed during dashboard init.
+	insecureOsmPolicyClient osmpolicyclientset.Interface
 	// Kubernetes client config created without providing auth info. It uses permissions granted
 	// to service account used by dashboard or kubeconfig file if it was passed during dashboard
 	// init.
@@ -171,6 +175,18 @@ func (self *clientManager) OsmConfigClient(req *restful.Request) (osmconfigclien
 	return self.InsecureOsmConfigClient(), nil
 }
 
+func (self *clientManager) OsmPolicyClient(req *restful.Request) (osmpolicyclientset.Interface, error) {
+	if req == nil {
+		return nil, errors.NewBadRequest("request can not be nil")
+	}
+
+	if self.isSecureModeEnabled(req) {
+		return self.secureOsmPolicyClient(req)
+	}
+
+	return self.InsecureOsmPolicyClient(), nil
+}
+
 // APIExtensionsClient returns an API Extensions client. In case dashboard login is enabled and
 // option to skip login page is disabled only secure client will be returned, otherwise insecure
 // client will be used.
@@ -261,6 +277,13 @@ func (self *clientManager) InsecureOsmConfigClient() osmconfigclientset.Interfac
 	return self.insecureOsmConfigClient
 }
 
+// InsecureOsmPolicyClient returns OSM policy client that was created without providing
+// auth info. It uses permissions granted to service account used by dashboard or kubeconfig file
+// if it was passed during dashboard init.
+func (self *clientManager) InsecureOsmPolicyClient() osmpolicyclientset.Interface {
+	return self.insecureOsmPolicyClient
+}
+
 // InsecureConfig returns kubernetes client config that used privileges of dashboard service account
 // or kubeconfig file if it was passed during dashboard init.
 func (self *clientManager) InsecureConfig() *rest.Config {
@@ -356,6 +379,25 @@ func (self *clientManager) HasAccess(authInfo api.AuthInfo) (string, error) {
 	return self.getUsername(result.Status.User.Username), nil
 }
 
+// Username returns the name of the user authenticated by the request. Empty string is returned
+// when the request does not carry any auth information, i.e. Dashboard acts on its own behalf.
+func (self *clientManager) Username(req *restful.Request) (string, error) {
+	if !self.containsAuthInfo(req) {
+		return "", nil
+	}
+
+	authInfo, err := self.extractAuthInfo(req)
+	if err != nil {
+		return "", err
+	}
+
+	if len(authInfo.Username) > 0 {
+		return authInfo.Username, nil
+	}
+
+	return self.HasAccess(*authInfo)
+}
+
 // VerberClient returns new verber client based on authentication information extracted from request
 func (self *clientManager) VerberClient(req *restful.Request, config *rest.Config) (clientapi.ResourceVerber, error) {
 	k8sClient, err := self.Client(req)
@@ -378,11 +420,26 @@ func (self *clientManager) VerberClient(req *restful.Request, config *rest.Confi
 		return nil, err
 	}
 
+	smispecsclient, err := self.SmiSpecsClient(req)
+	if err != nil {
+		return nil, err
+	}
+
+	smisplitclient, err := self.SmiSplitClient(req)
+	if err != nil {
+		return nil, err
+	}
+
 	osmconfigclient, err := self.OsmConfigClient(req)
 	if err != nil {
 		return nil, err
 	}
 
+	osmpolicyclient, err := self.OsmPolicyClient(req)
+	if err != nil {
+		return nil, err
+	}
+
 	apiextensionsRestClient, err := customresourcedefinition.GetExtensionsAPIRestClient(apiextensionsclient)
 	if err != nil {
 		return nil, err
@@ -400,7 +457,10 @@ func (self *clientManager) VerberClient(req *restful.Request, config *rest.Confi
 		apiextensionsRestClient,
 		pluginsclient.DashboardV1alpha1().RESTClient(),
 		smiaccessclient.AccessV1alpha3().RESTClient(),
+		smispecsclient.SpecsV1alpha4().RESTClient(),
+		smisplitclient.SplitV1alpha2().RESTClient(),
 		osmconfigclient.ConfigV1alpha2().RESTClient(),
+		osmpolicyclient.PolicyV1alpha1().RESTClient(),
 		config), nil
 }
 
@@ -599,6 +659,20 @@ func (self *clientManager) secureOsmConfigClient(req *restful.Request) (osmconfi
 	return client, nil
 }
 
+func (self *clientManager) secureOsmPolicyClient(req *restful.Request) (osmpolicyclientset.Interface, error) {
+	cfg, err := self.secureConfig(req)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := osmpolicyclientset.NewForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
+
 func (self *clientManager) secureAPIExtensionsClient(req *restful.Request) (apiextensionsclientset.Interface, error) {
 	cfg, err := self.secureConfig(req)
 	if err != nil {
@@ -719,6 +793,11 @@ func (self *clientManager) initInsecureClients() {
 		panic(err)
 	}
 
+	osmpolicyclient, err := osmpolicyclientset.NewForConfig(self.insecureConfig)
+	if err != nil {
+		panic(err)
+	}
+
 	self.insecureClient = k8sClient
 	self.insecureAPIExtensionsClient = apiextensionsclient
 	self.insecurePluginClient = pluginclient
@@ -726,6 +805,7 @@ func (self *clientManager) initInsecureClients() {
 	self.insecureSmiSplitClient = smisplitclient
 	self.insecureSmiAccessClient = smiaccessclient
 	self.insecureOsmConfigClient = osmconfigclient
+	self.insecureOsmPolicyClient = osmpolicyclient
 }
 
 func (self *clientManager) initInsecureConfig() {
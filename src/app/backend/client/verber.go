@@ -44,7 +44,10 @@ type resourceVerber struct {
 	apiExtensionsClient RESTClient
 	pluginsClient       RESTClient
 	smiAccessClient     RESTClient
+	smiSpecsClient      RESTClient
+	smiSplitClient      RESTClient
 	osmConfigClient     RESTClient
+	osmPolicyClient     RESTClient
 	config              *restclient.Config
 }
 
@@ -77,8 +80,14 @@ func (verber *resourceVerber) getRESTClientByType(clientType api.ClientType) RES
 		return verber.pluginsClient
 	case api.ClientTypeSmiAccessClient:
 		return verber.smiAccessClient
+	case api.ClientTypeSmiSpecsClient:
+		return verber.smiSpecsClient
+	case api.ClientTypeSmiSplitClient:
+		return verber.smiSplitClient
 	case api.ClientTypeOsmConfigClient:
 		return verber.osmConfigClient
+	case api.ClientTypeOsmPolicyClient:
+		return verber.osmPolicyClient
 	default:
 		return verber.client
 	}
@@ -153,10 +162,10 @@ type RESTClient interface {
 }
 
 // NewResourceVerber creates a new resource verber that uses the given client for performing operations.
-func NewResourceVerber(client, appsClient, batchClient, betaBatchClient, autoscalingClient, storageClient, rbacClient, networkingClient, apiExtensionsClient, pluginsClient RESTClient, smiAccessClient RESTClient, osmConfigClient RESTClient, config *restclient.Config) clientapi.ResourceVerber {
+func NewResourceVerber(client, appsClient, batchClient, betaBatchClient, autoscalingClient, storageClient, rbacClient, networkingClient, apiExtensionsClient, pluginsClient RESTClient, smiAccessClient, smiSpecsClient, smiSplitClient RESTClient, osmConfigClient, osmPolicyClient RESTClient, config *restclient.Config) clientapi.ResourceVerber {
 	return &resourceVerber{client, appsClient,
 		batchClient, betaBatchClient, autoscalingClient, storageClient, rbacClient, networkingClient, apiExtensionsClient, pluginsClient,
-		smiAccessClient, osmConfigClient, config}
+		smiAccessClient, smiSpecsClient, smiSplitClient, osmConfigClient, osmPolicyClient, config}
 }
 
 // Delete deletes the resource of the given kind in the given namespace with the given name.
@@ -103,6 +103,26 @@ func TestDeleteShouldPropagateErrorsAndChooseClient(t *testing.T) {
 	}
 }
 
+func TestDeleteShouldChooseSmiClients(t *testing.T) {
+	verber := resourceVerber{
+		client:         &FakeRESTClient{err: errors.NewInvalid("err")},
+		smiSpecsClient: &FakeRESTClient{err: errors.NewInvalid("err from smi specs")},
+		smiSplitClient: &FakeRESTClient{err: errors.NewInvalid("err from smi split")},
+	}
+
+	err := verber.Delete("tcproute", true, "bar", "baz")
+
+	if !reflect.DeepEqual(normalize(err.Error()), "Delete /api/v1/namespaces/bar/tcproutes/baz: err from smi specs") {
+		t.Fatalf("Expected error on verber delete but got %#v", err.Error())
+	}
+
+	err = verber.Delete("trafficsplit", true, "bar", "baz")
+
+	if !reflect.DeepEqual(normalize(err.Error()), "Delete /api/v1/namespaces/bar/trafficsplits/baz: err from smi split") {
+		t.Fatalf("Expected error on verber delete but got %#v", err.Error())
+	}
+}
+
 func TestGetShouldPropagateErrorsAndChoseClient(t *testing.T) {
 	verber := resourceVerber{
 		client:     &FakeRESTClient{err: errors.NewInvalid("err")},
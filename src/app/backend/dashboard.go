@@ -53,9 +53,13 @@ var (
 	argCertFile                  = pflag.String("tls-cert-file", "", "file containing the default x509 certificate for HTTPS")
 	argKeyFile                   = pflag.String("tls-key-file", "", "file containing the default x509 private key matching --tls-cert-file")
 	argApiserverHost             = pflag.String("apiserver-host", "", "address of the Kubernetes API server to connect to in the format of protocol://address:port, leave it empty if the binary runs inside cluster for local discovery attempt")
-	argMetricsProvider           = pflag.String("metrics-provider", "sidecar", "select provider type for metrics, 'none' will not check metrics")
+	argMetricsProvider           = pflag.String("metrics-provider", "sidecar", "select provider type for metrics, one of 'sidecar', 'heapster' or 'prometheus', 'none' will not check metrics")
 	argHeapsterHost              = pflag.String("heapster-host", "", "address of the Heapster API server to connect to in the format of protocol://address:port, leave it empty if the binary runs inside cluster for service proxy usage")
 	argSidecarHost               = pflag.String("sidecar-host", "", "address of the Sidecar API server to connect to in the format of protocol://address:port, leave it empty if the binary runs inside cluster for service proxy usage")
+	argPrometheusHost            = pflag.String("prometheus-host", "", "address of the Prometheus server of the mesh to connect to in the format of protocol://address:port, leave it empty if the binary runs inside cluster for service proxy usage")
+	argPrometheusService         = pflag.String("prometheus-service", "osm-system/osm-prometheus:7070", "namespace/name:port of the Prometheus service of the mesh, used through service proxy when --prometheus-host is empty")
+	argPrometheusQueries         = pflag.String("prometheus-queries", "", "path to a JSON file that overrides the PromQL templates used by the Prometheus metrics provider")
+	argCAExpiryWarningDays       = pflag.Int("ca-expiry-warning-days", 30, "number of days before the expiry of the mesh root CA from which a warning is shown")
 	argKubeConfigFile            = pflag.String("kubeconfig", "", "path to kubeconfig file with authorization and master location information")
 	argTokenTTL                  = pflag.Int("token-ttl", authApi.DefaultTokenTTL, "expiration time in seconds of JWE tokens generated by dashboard, set to 0 to avoid expiration")
 	argAuthenticationMode        = pflag.StringSlice("authentication-mode", []string{authApi.Token.String()}, "enabled authentication options, supports 'token' and 'basic' that should only be used if Kubernetes API server has --authorization-mode=ABAC and --basic-auth-file flags set")
@@ -120,6 +124,10 @@ func main() {
 	case "heapster":
 		integrationManager.Metric().ConfigureHeapster(args.Holder.GetHeapsterHost()).
 			EnableWithRetry(integrationapi.HeapsterIntegrationID, time.Duration(args.Holder.GetMetricClientCheckPeriod()))
+	case "prometheus":
+		integrationManager.Metric().ConfigurePrometheus(args.Holder.GetPrometheusHost(), args.Holder.GetPrometheusService(),
+			args.Holder.GetPrometheusQueries()).
+			EnableWithRetry(integrationapi.PrometheusIntegrationID, time.Duration(args.Holder.GetMetricClientCheckPeriod()))
 	case "none":
 		log.Print("no metrics provider selected, will not check metrics.")
 	default:
@@ -233,6 +241,10 @@ func initArgHolder() {
 	builder.SetMetricsProvider(*argMetricsProvider)
 	builder.SetHeapsterHost(*argHeapsterHost)
 	builder.SetSidecarHost(*argSidecarHost)
+	builder.SetPrometheusHost(*argPrometheusHost)
+	builder.SetPrometheusService(*argPrometheusService)
+	builder.SetPrometheusQueries(*argPrometheusQueries)
+	builder.SetCAExpiryWarningDays(*argCAExpiryWarningDays)
 	builder.SetKubeConfigFile(*argKubeConfigFile)
 	builder.SetSystemBanner(*argSystemBanner)
 	builder.SetSystemBannerSeverity(*argSystemBannerSeverity)
@@ -19,6 +19,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
 
@@ -35,11 +36,13 @@ import (
 	"k8s.io/client-go/tools/remotecommand"
 
 	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/args"
 	"github.com/kubernetes/dashboard/src/app/backend/auth"
 	authApi "github.com/kubernetes/dashboard/src/app/backend/auth/api"
 	clientapi "github.com/kubernetes/dashboard/src/app/backend/client/api"
 	"github.com/kubernetes/dashboard/src/app/backend/errors"
 	"github.com/kubernetes/dashboard/src/app/backend/integration"
+	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
 	"github.com/kubernetes/dashboard/src/app/backend/osmcli"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/clusterrole"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/clusterrolebinding"
@@ -60,7 +63,15 @@ import (
 	"github.com/kubernetes/dashboard/src/app/backend/resource/logs"
 	ns "github.com/kubernetes/dashboard/src/app/backend/resource/namespace"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/node"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/osm/drift"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/osm/egress"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/osm/ingressbackend"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/osm/meshconfig"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/osm/multiclusterservice"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/osm/resilience"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/osm/retry"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/osm/sidecar"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/osm/upstreamtrafficsetting"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/persistentvolume"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/persistentvolumeclaim"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/pod"
@@ -72,15 +83,19 @@ import (
 	resourceService "github.com/kubernetes/dashboard/src/app/backend/resource/service"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/serviceaccount"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/smi/httproutegroup"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/smi/policy"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/smi/tcproute"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/smi/trafficsplit"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/smi/traffictarget"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/statefulset"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/storageclass"
+	"github.com/kubernetes/dashboard/src/app/backend/rollout"
 
 	"github.com/kubernetes/dashboard/src/app/backend/scaling"
 	"github.com/kubernetes/dashboard/src/app/backend/settings"
 	settingsApi "github.com/kubernetes/dashboard/src/app/backend/settings/api"
 	"github.com/kubernetes/dashboard/src/app/backend/systembanner"
+	"github.com/kubernetes/dashboard/src/app/backend/topology"
 	"github.com/kubernetes/dashboard/src/app/backend/validation"
 )
 
@@ -140,6 +155,19 @@ func CreateHTTPAPIHandler(iManager integration.IntegrationManager, cManager clie
 	osmCliHandler := osmcli.NewOsmCliHandler(cManager)
 	osmCliHandler.Install(apiV1Ws)
 
+	var rolloutMetrics rollout.MetricSource
+	var topologyTraffic topology.TrafficSource
+	if iManager != nil && hasMeshMetrics(iManager.Metric()) {
+		rolloutMetrics = meshMetrics{manager: iManager.Metric()}
+		topologyTraffic = meshMetrics{manager: iManager.Metric()}
+	}
+
+	rolloutHandler := rollout.NewRolloutHandler(cManager, rolloutMetrics)
+	rolloutHandler.Install(apiV1Ws)
+
+	topologyHandler := topology.NewTopologyHandler(cManager, topologyTraffic)
+	topologyHandler.Install(apiV1Ws)
+
 	apiV1Ws.Route(
 		apiV1Ws.GET("csrftoken/{action}").
 			To(apiHandler.handleGetCsrfToken).
@@ -258,6 +286,10 @@ func CreateHTTPAPIHandler(iManager integration.IntegrationManager, cManager clie
 		apiV1Ws.GET("/pod/{namespace}/{pod}/persistentvolumeclaim").
 			To(apiHandler.handleGetPodPersistentVolumeClaims).
 			Writes(persistentvolumeclaim.PersistentVolumeClaimList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/pod/{namespace}/{pod}/sidecar/{view}").
+			To(apiHandler.handleGetPodSidecarConfig).
+			Writes(sidecar.ProxyConfig{}))
 
 	apiV1Ws.Route(
 		apiV1Ws.GET("/deployment").
@@ -422,6 +454,32 @@ func CreateHTTPAPIHandler(iManager integration.IntegrationManager, cManager clie
 		apiV1Ws.GET("/namespace/{name}/event").
 			To(apiHandler.handleGetNamespaceEvents).
 			Writes(common.EventList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/namespaceenrollment").
+			To(apiHandler.handleGetNamespaceEnrollmentList).
+			Writes(ns.NamespaceEnrollmentList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/namespace/{name}/enrollment").
+			To(apiHandler.handleGetNamespaceEnrollment).
+			Writes(ns.EnrollmentStatus{}))
+	apiV1Ws.Route(
+		apiV1Ws.PUT("/namespace/{name}/enrollment").
+			To(apiHandler.handleEnrollNamespace).
+			Reads(ns.EnrollmentSpec{}).
+			Writes(ns.EnrollmentStatus{}))
+	apiV1Ws.Route(
+		apiV1Ws.DELETE("/namespace/{name}/enrollment").
+			To(apiHandler.handleUnenrollNamespace).
+			Writes(ns.EnrollmentStatus{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/sidecardrift").
+			To(apiHandler.handleGetSidecarDriftList).
+			Writes(drift.DriftList{}))
+	apiV1Ws.Route(
+		apiV1Ws.POST("/sidecardrift/restart").
+			To(apiHandler.handleRestartSidecarDrift).
+			Reads(drift.RestartSpec{}).
+			Writes(drift.RestartResult{}))
 
 	apiV1Ws.Route(
 		apiV1Ws.GET("/event").
@@ -487,6 +545,10 @@ func CreateHTTPAPIHandler(iManager integration.IntegrationManager, cManager clie
 		apiV1Ws.GET("/service/{namespace}/{service}/ingress").
 			To(apiHandler.handleGetServiceIngressList).
 			Writes(ingress.IngressList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/service/{namespace}/{service}/resilience").
+			To(apiHandler.handleGetServiceResilience).
+			Writes(resilience.ServiceResilience{}))
 
 	apiV1Ws.Route(
 		apiV1Ws.GET("/serviceaccount").
@@ -664,16 +726,75 @@ func CreateHTTPAPIHandler(iManager integration.IntegrationManager, cManager clie
 		apiV1Ws.GET("/httproutgroup").
 			To(apiHandler.handleHttpRouteGroupList).
 			Writes(httproutegroup.HttpRouteGroupList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/httproutegroup").
+			To(apiHandler.handleHttpRouteGroupList).
+			Writes(httproutegroup.HttpRouteGroupList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/httproutegroup/{namespace}").
+			To(apiHandler.handleHttpRouteGroupList).
+			Writes(httproutegroup.HttpRouteGroupList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/httproutegroup/{namespace}/{name}").
+			To(apiHandler.handleGetHttpRouteGroupDetail).
+			Writes(httproutegroup.HttpRouteGroupDetail{}))
+	apiV1Ws.Route(
+		apiV1Ws.POST("/httproutegroup/{namespace}/{name}/test").
+			To(apiHandler.handleTestHttpRouteGroup).
+			Reads(httproutegroup.HttpRouteGroupTestRequest{}).
+			Writes(httproutegroup.HttpRouteGroupTestResult{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/tcproute").
+			To(apiHandler.handleGetTCPRouteList).
+			Writes(tcproute.TCPRouteList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/tcproute/{namespace}").
+			To(apiHandler.handleGetTCPRouteList).
+			Writes(tcproute.TCPRouteList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/tcproute/{namespace}/{name}").
+			To(apiHandler.handleGetTCPRouteDetail).
+			Writes(tcproute.TCPRouteDetail{}))
 	apiV1Ws.Route(
 		apiV1Ws.GET("/trafficsplit").
 			To(apiHandler.handleGetTrafficSplitList).
 			Writes(trafficsplit.TrafficSplitList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/trafficsplit/{namespace}/{name}").
+			To(apiHandler.handleGetTrafficSplitDetail).
+			Writes(trafficsplit.TrafficSplitDetail{}))
+	apiV1Ws.Route(
+		apiV1Ws.PUT("/trafficsplit/{namespace}/{name}/weights").
+			To(apiHandler.handleUpdateTrafficSplitWeights).
+			Reads(trafficsplit.TrafficSplitWeights{}).
+			Writes(trafficsplit.TrafficSplitDetail{}))
 	apiV1Ws.Route(
 		apiV1Ws.GET("/traffictarget").
 			To(apiHandler.handleGetTrafficTargetList).
 			Writes(traffictarget.TrafficTargetList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/traffictarget/{namespace}/{name}").
+			To(apiHandler.handleGetTrafficTargetDetail).
+			Writes(traffictarget.TrafficTargetDetail{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/accessmatrix").
+			To(apiHandler.handleGetAccessMatrix).
+			Writes(policy.AccessMatrix{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/accessmatrix/{namespace}").
+			To(apiHandler.handleGetAccessMatrix).
+			Writes(policy.AccessMatrix{}))
+	apiV1Ws.Route(
+		apiV1Ws.POST("/policy/simulate").
+			To(apiHandler.handleSimulatePolicy).
+			Reads(policy.SimulationSpec{}).
+			Writes(policy.SimulationResult{}))
 
 	// OSM
+	apiV1Ws.Route(
+		apiV1Ws.GET("/goldensignals/{kind}/{namespace}/{name}").
+			To(apiHandler.handleGetGoldenSignals).
+			Writes(metricapi.GoldenSignals{}))
 	apiV1Ws.Route(
 		apiV1Ws.GET("/meshconfig").
 			To(apiHandler.handleGetMeshConfigList).
@@ -686,10 +807,132 @@ func CreateHTTPAPIHandler(iManager integration.IntegrationManager, cManager clie
 		apiV1Ws.GET("/meshconfig/{namespace}/{name}").
 			To(apiHandler.handleGetMeshConfigDetail).
 			Writes(meshconfig.MeshConfigDetail{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/meshconfig/{namespace}/{name}/certificate").
+			To(apiHandler.handleGetMeshCertificate).
+			Writes(meshconfig.MeshCertificate{}))
+	apiV1Ws.Route(
+		apiV1Ws.PUT("/meshconfig/{namespace}/{name}").
+			To(apiHandler.handleUpdateMeshConfig).
+			Reads(meshconfig.MeshConfigUpdate{}).
+			Writes(meshconfig.MeshConfigUpdateResult{}))
+	apiV1Ws.Route(
+		apiV1Ws.POST("/meshconfig/{namespace}/{name}/diff").
+			To(apiHandler.handlePreviewMeshConfigUpdate).
+			Reads(meshconfig.MeshConfigUpdate{}).
+			Writes(meshconfig.MeshConfigUpdateResult{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/meshconfig/{namespace}/{name}/revision").
+			To(apiHandler.handleGetMeshConfigRevisionList).
+			Writes(meshconfig.MeshConfigRevisionList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/meshconfig/{namespace}/{name}/revision/{revision}").
+			To(apiHandler.handleGetMeshConfigRevision).
+			Writes(meshconfig.MeshConfigRevision{}))
+	apiV1Ws.Route(
+		apiV1Ws.POST("/meshconfig/{namespace}/{name}/revision/{revision}/rollback").
+			To(apiHandler.handleRollbackMeshConfig).
+			Reads(meshconfig.MeshConfigRollback{}).
+			Writes(meshconfig.MeshConfigUpdateResult{}))
 	apiV1Ws.Route(
 		apiV1Ws.GET("/meshconfig/{namespace}/{meshconfig}/event").
 			To(apiHandler.handleGetMeshConfigControllerEvents).
 			Writes(common.EventList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/multiclusterservice").
+			To(apiHandler.handleGetMultiClusterServiceList).
+			Writes(multiclusterservice.MultiClusterServiceList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/multiclusterservice/{namespace}").
+			To(apiHandler.handleGetMultiClusterServiceList).
+			Writes(multiclusterservice.MultiClusterServiceList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/multiclusterservice/{namespace}/{name}").
+			To(apiHandler.handleGetMultiClusterServiceDetail).
+			Writes(multiclusterservice.MultiClusterServiceDetail{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/egress").
+			To(apiHandler.handleGetEgressList).
+			Writes(egress.EgressList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/egress/{namespace}").
+			To(apiHandler.handleGetEgressList).
+			Writes(egress.EgressList{}))
+	apiV1Ws.Route(
+		apiV1Ws.POST("/egress/{namespace}").
+			To(apiHandler.handleCreateEgress).
+			Reads(egress.EgressSpec{}).
+			Writes(egress.EgressDetail{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/egress/{namespace}/{name}").
+			To(apiHandler.handleGetEgressDetail).
+			Writes(egress.EgressDetail{}))
+	apiV1Ws.Route(
+		apiV1Ws.PUT("/egress/{namespace}/{name}").
+			To(apiHandler.handleUpdateEgress).
+			Reads(egress.EgressUpdate{}).
+			Writes(egress.EgressDetail{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/ingressbackend").
+			To(apiHandler.handleGetIngressBackendList).
+			Writes(ingressbackend.IngressBackendList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/ingressbackend/{namespace}").
+			To(apiHandler.handleGetIngressBackendList).
+			Writes(ingressbackend.IngressBackendList{}))
+	apiV1Ws.Route(
+		apiV1Ws.POST("/ingressbackend/{namespace}").
+			To(apiHandler.handleCreateIngressBackend).
+			Reads(ingressbackend.IngressBackendSpec{}).
+			Writes(ingressbackend.IngressBackendDetail{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/ingressbackend/{namespace}/{name}").
+			To(apiHandler.handleGetIngressBackendDetail).
+			Writes(ingressbackend.IngressBackendDetail{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/upstreamtrafficsetting").
+			To(apiHandler.handleGetUpstreamTrafficSettingList).
+			Writes(upstreamtrafficsetting.UpstreamTrafficSettingList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/upstreamtrafficsetting/{namespace}").
+			To(apiHandler.handleGetUpstreamTrafficSettingList).
+			Writes(upstreamtrafficsetting.UpstreamTrafficSettingList{}))
+	apiV1Ws.Route(
+		apiV1Ws.POST("/upstreamtrafficsetting/{namespace}").
+			To(apiHandler.handleCreateUpstreamTrafficSetting).
+			Reads(upstreamtrafficsetting.UpstreamTrafficSettingSpec{}).
+			Writes(upstreamtrafficsetting.UpstreamTrafficSettingDetail{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/upstreamtrafficsetting/{namespace}/{name}").
+			To(apiHandler.handleGetUpstreamTrafficSettingDetail).
+			Writes(upstreamtrafficsetting.UpstreamTrafficSettingDetail{}))
+	apiV1Ws.Route(
+		apiV1Ws.PUT("/upstreamtrafficsetting/{namespace}/{name}").
+			To(apiHandler.handleUpdateUpstreamTrafficSetting).
+			Reads(upstreamtrafficsetting.UpstreamTrafficSettingUpdate{}).
+			Writes(upstreamtrafficsetting.UpstreamTrafficSettingDetail{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/retry").
+			To(apiHandler.handleGetRetryList).
+			Writes(retry.RetryList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/retry/{namespace}").
+			To(apiHandler.handleGetRetryList).
+			Writes(retry.RetryList{}))
+	apiV1Ws.Route(
+		apiV1Ws.POST("/retry/{namespace}").
+			To(apiHandler.handleCreateRetry).
+			Reads(retry.RetrySpec{}).
+			Writes(retry.RetryDetail{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/retry/{namespace}/{name}").
+			To(apiHandler.handleGetRetryDetail).
+			Writes(retry.RetryDetail{}))
+	apiV1Ws.Route(
+		apiV1Ws.PUT("/retry/{namespace}/{name}").
+			To(apiHandler.handleUpdateRetry).
+			Reads(retry.RetryUpdate{}).
+			Writes(retry.RetryDetail{}))
 	apiV1Ws.Route(
 		apiV1Ws.POST("/mesh/validate/name").
 			To(apiHandler.handleMeshValidity).
@@ -904,6 +1147,12 @@ func (apiHandler *APIHandler) handleGetCsrfToken(request *restful.Request, respo
 }
 
 func (apiHandler *APIHandler) handleGetStatefulSetList(request *restful.Request, response *restful.Response) {
+	osmConfigClient, err := apiHandler.cManager.OsmConfigClient(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
 		errors.HandleInternalError(response, err)
@@ -913,7 +1162,7 @@ func (apiHandler *APIHandler) handleGetStatefulSetList(request *restful.Request,
 	namespace := parseNamespacePathParameter(request)
 	dataSelect := parser.ParseDataSelectPathParameter(request)
 	dataSelect.MetricQuery = dataselect.StandardMetrics
-	result, err := statefulset.GetStatefulSetList(k8sClient, namespace, dataSelect,
+	result, err := statefulset.GetStatefulSetList(osmConfigClient, k8sClient, namespace, dataSelect,
 		apiHandler.iManager.Metric().Client())
 	if err != nil {
 		errors.HandleInternalError(response, err)
@@ -923,6 +1172,12 @@ func (apiHandler *APIHandler) handleGetStatefulSetList(request *restful.Request,
 }
 
 func (apiHandler *APIHandler) handleGetStatefulSetDetail(request *restful.Request, response *restful.Response) {
+	osmConfigClient, err := apiHandler.cManager.OsmConfigClient(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
 		errors.HandleInternalError(response, err)
@@ -931,7 +1186,7 @@ func (apiHandler *APIHandler) handleGetStatefulSetDetail(request *restful.Reques
 
 	namespace := request.PathParameter("namespace")
 	name := request.PathParameter("statefulset")
-	result, err := statefulset.GetStatefulSetDetail(k8sClient, apiHandler.iManager.Metric().Client(), namespace, name)
+	result, err := statefulset.GetStatefulSetDetail(osmConfigClient, k8sClient, apiHandler.iManager.Metric().Client(), namespace, name)
 
 	if err != nil {
 		errors.HandleInternalError(response, err)
@@ -1001,9 +1256,836 @@ func (apiHandler *APIHandler) handleGetServiceDetail(request *restful.Request, r
 		return
 	}
 
-	namespace := request.PathParameter("namespace")
-	name := request.PathParameter("service")
-	result, err := resourceService.GetServiceDetail(k8sClient, namespace, name)
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("service")
+	result, err := resourceService.GetServiceDetail(k8sClient, namespace, name)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetServiceEvent(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("service")
+	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect.MetricQuery = dataselect.StandardMetrics
+	result, err := resourceService.GetServiceEvents(k8sClient, dataSelect, namespace, name)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleHttpRouteGroupList(request *restful.Request, response *restful.Response) {
+	smiSpecsClient, err := apiHandler.cManager.SmiSpecsClient(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	namespace := parseNamespacePathParameter(request)
+	dataSelect := parser.ParseDataSelectPathParameter(request)
+	result, err := httproutegroup.GetHttpRouteGroupList(smiSpecsClient, namespace, dataSelect)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetHttpRouteGroupDetail(request *restful.Request, response *restful.Response) {
+	smiSpecsClient, err := apiHandler.cManager.SmiSpecsClient(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+	result, err := httproutegroup.GetHttpRouteGroupDetail(smiSpecsClient, namespace, name)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleTestHttpRouteGroup(request *restful.Request, response *restful.Response) {
+	smiSpecsClient, err := apiHandler.cManager.SmiSpecsClient(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	testRequest := new(httproutegroup.HttpRouteGroupTestRequest)
+	if err := request.ReadEntity(testRequest); err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+	result, err := httproutegroup.TestHttpRouteGroup(smiSpecsClient, namespace, name, testRequest)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetTCPRouteList(request *restful.Request, response *restful.Response) {
+	smiSpecsClient, err := apiHandler.cManager.SmiSpecsClient(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	smiAccessClient, err := apiHandler.cManager.SmiAccessClient(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace := parseNamespacePathParameter(request)
+	dataSelect := parser.ParseDataSelectPathParameter(request)
+	result, err := tcproute.GetTCPRouteList(smiSpecsClient, smiAccessClient, namespace, dataSelect)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetTCPRouteDetail(request *restful.Request, response *restful.Response) {
+	smiSpecsClient, err := apiHandler.cManager.SmiSpecsClient(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	smiAccessClient, err := apiHandler.cManager.SmiAccessClient(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+	result, err := tcproute.GetTCPRouteDetail(smiSpecsClient, smiAccessClient, namespace, name)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetTrafficSplitList(request *restful.Request, response *restful.Response) {
+	smiSplitClient, err := apiHandler.cManager.SmiSplitClient(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	namespace := parseNamespacePathParameter(request)
+	dataSelect := parser.ParseDataSelectPathParameter(request)
+	result, err := trafficsplit.GetTrafficSplitList(smiSplitClient, namespace, dataSelect)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetTrafficSplitDetail(request *restful.Request, response *restful.Response) {
+	smiSplitClient, err := apiHandler.cManager.SmiSplitClient(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+	result, err := trafficsplit.GetTrafficSplitDetail(smiSplitClient, k8sClient, namespace, name)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleUpdateTrafficSplitWeights(request *restful.Request, response *restful.Response) {
+	smiSplitClient, err := apiHandler.cManager.SmiSplitClient(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+	weights := new(trafficsplit.TrafficSplitWeights)
+	if err := request.ReadEntity(weights); err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	result, err := trafficsplit.UpdateTrafficSplitWeights(smiSplitClient, k8sClient, namespace, name, weights)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetTrafficTargetList(request *restful.Request, response *restful.Response) {
+	smiAccessClient, err := apiHandler.cManager.SmiAccessClient(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace := parseNamespacePathParameter(request)
+	dataSelect := parser.ParseDataSelectPathParameter(request)
+	result, err := traffictarget.GetTrafficTargetList(smiAccessClient, namespace, dataSelect)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetTrafficTargetDetail(request *restful.Request, response *restful.Response) {
+	smiAccessClient, err := apiHandler.cManager.SmiAccessClient(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	smiSpecsClient, err := apiHandler.cManager.SmiSpecsClient(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+	result, err := traffictarget.GetTrafficTargetDetail(smiAccessClient, smiSpecsClient, k8sClient, namespace, name)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetAccessMatrix(request *restful.Request, response *restful.Response) {
+	smiAccessClient, err := apiHandler.cManager.SmiAccessClient(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	smiSpecsClient, err := apiHandler.cManager.SmiSpecsClient(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	osmConfigClient, err := apiHandler.cManager.OsmConfigClient(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace := parseNamespacePathParameter(request)
+	meshConfigNamespace := request.QueryParameter("meshConfigNamespace")
+	meshConfigName := request.QueryParameter("meshConfigName")
+	result, err := policy.GetAccessMatrix(smiAccessClient, smiSpecsClient, osmConfigClient, k8sClient, namespace,
+		meshConfigNamespace, meshConfigName)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleSimulatePolicy(request *restful.Request, response *restful.Response) {
+	smiAccessClient, err := apiHandler.cManager.SmiAccessClient(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	smiSpecsClient, err := apiHandler.cManager.SmiSpecsClient(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	osmConfigClient, err := apiHandler.cManager.OsmConfigClient(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	spec := new(policy.SimulationSpec)
+	if err := request.ReadEntity(spec); err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	result, err := policy.SimulateAccess(smiAccessClient, smiSpecsClient, osmConfigClient, k8sClient, spec)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetMultiClusterServiceList(request *restful.Request, response *restful.Response) {
+	osmConfigClient, err := apiHandler.cManager.OsmConfigClient(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace := parseNamespacePathParameter(request)
+	dataSelect := parser.ParseDataSelectPathParameter(request)
+	result, err := multiclusterservice.GetMultiClusterServiceList(osmConfigClient, k8sClient, namespace, dataSelect)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetMultiClusterServiceDetail(request *restful.Request, response *restful.Response) {
+	osmConfigClient, err := apiHandler.cManager.OsmConfigClient(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+	result, err := multiclusterservice.GetMultiClusterServiceDetail(osmConfigClient, k8sClient, namespace, name)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetEgressList(request *restful.Request, response *restful.Response) {
+	osmPolicyClient, err := apiHandler.cManager.OsmPolicyClient(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace := parseNamespacePathParameter(request)
+	dataSelect := parser.ParseDataSelectPathParameter(request)
+	result, err := egress.GetEgressList(osmPolicyClient, namespace, dataSelect)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetEgressDetail(request *restful.Request, response *restful.Response) {
+	osmPolicyClient, err := apiHandler.cManager.OsmPolicyClient(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+	result, err := egress.GetEgressDetail(osmPolicyClient, k8sClient, namespace, name)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleCreateEgress(request *restful.Request, response *restful.Response) {
+	osmPolicyClient, err := apiHandler.cManager.OsmPolicyClient(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	spec := new(egress.EgressSpec)
+	if err := request.ReadEntity(spec); err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	result, err := egress.CreateEgress(osmPolicyClient, k8sClient, namespace, spec)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusCreated, result)
+}
+
+func (apiHandler *APIHandler) handleUpdateEgress(request *restful.Request, response *restful.Response) {
+	osmPolicyClient, err := apiHandler.cManager.OsmPolicyClient(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	update := new(egress.EgressUpdate)
+	if err := request.ReadEntity(update); err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+	result, err := egress.UpdateEgress(osmPolicyClient, k8sClient, namespace, name, update)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetIngressBackendList(request *restful.Request, response *restful.Response) {
+	osmPolicyClient, err := apiHandler.cManager.OsmPolicyClient(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace := parseNamespacePathParameter(request)
+	dataSelect := parser.ParseDataSelectPathParameter(request)
+	result, err := ingressbackend.GetIngressBackendList(osmPolicyClient, namespace, dataSelect)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetIngressBackendDetail(request *restful.Request, response *restful.Response) {
+	osmPolicyClient, err := apiHandler.cManager.OsmPolicyClient(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+	result, err := ingressbackend.GetIngressBackendDetail(osmPolicyClient, k8sClient, namespace, name)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleCreateIngressBackend(request *restful.Request, response *restful.Response) {
+	osmPolicyClient, err := apiHandler.cManager.OsmPolicyClient(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	spec := new(ingressbackend.IngressBackendSpec)
+	if err := request.ReadEntity(spec); err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	result, err := ingressbackend.CreateIngressBackend(osmPolicyClient, k8sClient, namespace, spec)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusCreated, result)
+}
+
+func (apiHandler *APIHandler) handleGetUpstreamTrafficSettingList(request *restful.Request, response *restful.Response) {
+	osmPolicyClient, err := apiHandler.cManager.OsmPolicyClient(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace := parseNamespacePathParameter(request)
+	dataSelect := parser.ParseDataSelectPathParameter(request)
+	result, err := upstreamtrafficsetting.GetUpstreamTrafficSettingList(osmPolicyClient, namespace, dataSelect)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetUpstreamTrafficSettingDetail(request *restful.Request, response *restful.Response) {
+	osmPolicyClient, err := apiHandler.cManager.OsmPolicyClient(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+	result, err := upstreamtrafficsetting.GetUpstreamTrafficSettingDetail(osmPolicyClient, k8sClient, namespace, name)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleCreateUpstreamTrafficSetting(request *restful.Request, response *restful.Response) {
+	osmPolicyClient, err := apiHandler.cManager.OsmPolicyClient(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	spec := new(upstreamtrafficsetting.UpstreamTrafficSettingSpec)
+	if err := request.ReadEntity(spec); err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	result, err := upstreamtrafficsetting.CreateUpstreamTrafficSetting(osmPolicyClient, k8sClient, namespace, spec)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusCreated, result)
+}
+
+func (apiHandler *APIHandler) handleUpdateUpstreamTrafficSetting(request *restful.Request, response *restful.Response) {
+	osmPolicyClient, err := apiHandler.cManager.OsmPolicyClient(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	update := new(upstreamtrafficsetting.UpstreamTrafficSettingUpdate)
+	if err := request.ReadEntity(update); err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+	result, err := upstreamtrafficsetting.UpdateUpstreamTrafficSetting(osmPolicyClient, k8sClient, namespace, name, update)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetRetryList(request *restful.Request, response *restful.Response) {
+	osmPolicyClient, err := apiHandler.cManager.OsmPolicyClient(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace := parseNamespacePathParameter(request)
+	dataSelect := parser.ParseDataSelectPathParameter(request)
+	result, err := retry.GetRetryList(osmPolicyClient, namespace, dataSelect)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetRetryDetail(request *restful.Request, response *restful.Response) {
+	osmPolicyClient, err := apiHandler.cManager.OsmPolicyClient(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+	result, err := retry.GetRetryDetail(osmPolicyClient, k8sClient, namespace, name)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleCreateRetry(request *restful.Request, response *restful.Response) {
+	osmPolicyClient, err := apiHandler.cManager.OsmPolicyClient(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	spec := new(retry.RetrySpec)
+	if err := request.ReadEntity(spec); err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	result, err := retry.CreateRetry(osmPolicyClient, k8sClient, namespace, spec)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusCreated, result)
+}
+
+func (apiHandler *APIHandler) handleUpdateRetry(request *restful.Request, response *restful.Response) {
+	osmPolicyClient, err := apiHandler.cManager.OsmPolicyClient(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	update := new(retry.RetryUpdate)
+	if err := request.ReadEntity(update); err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+	result, err := retry.UpdateRetry(osmPolicyClient, k8sClient, namespace, name, update)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetServiceResilience(request *restful.Request, response *restful.Response) {
+	osmPolicyClient, err := apiHandler.cManager.OsmPolicyClient(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("service")
+	result, err := resilience.GetServiceResilience(osmPolicyClient, k8sClient, namespace, name)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetMeshConfigList(request *restful.Request, response *restful.Response) {
+	osmConfigClient, err := apiHandler.cManager.OsmConfigClient(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace := parseNamespacePathParameter(request)
+	dataSelect := parser.ParseDataSelectPathParameter(request)
+	result, err := meshconfig.GetMeshConfigList(osmConfigClient, namespace, dataSelect)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetMeshConfigDetail(request *restful.Request, response *restful.Response) {
+	osmConfigClient, err := apiHandler.cManager.OsmConfigClient(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+	result, err := meshconfig.GetMeshConfigDetail(osmConfigClient, namespace, name)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetMeshCertificate(request *restful.Request, response *restful.Response) {
+	osmConfigClient, err := apiHandler.cManager.OsmConfigClient(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+	result, err := meshconfig.GetMeshCertificate(osmConfigClient, k8sClient, namespace, name,
+		args.Holder.GetCAExpiryWarningDays())
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleUpdateMeshConfig(request *restful.Request, response *restful.Response) {
+	osmConfigClient, err := apiHandler.cManager.OsmConfigClient(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+	update := new(meshconfig.MeshConfigUpdate)
+	if err := request.ReadEntity(update); err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	author, err := apiHandler.cManager.Username(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	history := meshconfig.NewMeshConfigHistory(apiHandler.cManager.InsecureClient(), args.Holder.GetNamespace())
+	result, err := meshconfig.UpdateMeshConfig(osmConfigClient, history, author, namespace, name, update)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
@@ -1011,35 +2093,22 @@ func (apiHandler *APIHandler) handleGetServiceDetail(request *restful.Request, r
 	response.WriteHeaderAndEntity(http.StatusOK, result)
 }
 
-func (apiHandler *APIHandler) handleGetServiceEvent(request *restful.Request, response *restful.Response) {
-	k8sClient, err := apiHandler.cManager.Client(request)
+func (apiHandler *APIHandler) handlePreviewMeshConfigUpdate(request *restful.Request, response *restful.Response) {
+	osmConfigClient, err := apiHandler.cManager.OsmConfigClient(request)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
 
 	namespace := request.PathParameter("namespace")
-	name := request.PathParameter("service")
-	dataSelect := parser.ParseDataSelectPathParameter(request)
-	dataSelect.MetricQuery = dataselect.StandardMetrics
-	result, err := resourceService.GetServiceEvents(k8sClient, dataSelect, namespace, name)
-	if err != nil {
+	name := request.PathParameter("name")
+	update := new(meshconfig.MeshConfigUpdate)
+	if err := request.ReadEntity(update); err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
-	response.WriteHeaderAndEntity(http.StatusOK, result)
-}
 
-func (apiHandler *APIHandler) handleHttpRouteGroupList(request *restful.Request, response *restful.Response) {
-	println("=== === === >>>>>> handleHttpRouteGroupList")
-	smiSpecsClient, err := apiHandler.cManager.SmiSpecsClient(request)
-	if err != nil {
-		errors.HandleInternalError(response, err)
-		return
-	}
-	namespace := parseNamespacePathParameter(request)
-	dataSelect := parser.ParseDataSelectPathParameter(request)
-	result, err := httproutegroup.GetHttpRouteGroupList(smiSpecsClient, namespace, dataSelect)
+	result, err := meshconfig.PreviewMeshConfigUpdate(osmConfigClient, namespace, name, update)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
@@ -1047,15 +2116,17 @@ func (apiHandler *APIHandler) handleHttpRouteGroupList(request *restful.Request,
 	response.WriteHeaderAndEntity(http.StatusOK, result)
 }
 
-func (apiHandler *APIHandler) handleGetTrafficSplitList(request *restful.Request, response *restful.Response) {
-	smiSplitClient, err := apiHandler.cManager.SmiSplitClient(request)
+func (apiHandler *APIHandler) handleGetMeshConfigRevisionList(request *restful.Request, response *restful.Response) {
+	osmConfigClient, err := apiHandler.cManager.OsmConfigClient(request)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
-	namespace := parseNamespacePathParameter(request)
-	dataSelect := parser.ParseDataSelectPathParameter(request)
-	result, err := trafficsplit.GetTrafficSplitList(smiSplitClient, namespace, dataSelect)
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+	history := meshconfig.NewMeshConfigHistory(apiHandler.cManager.InsecureClient(), args.Holder.GetNamespace())
+	result, err := meshconfig.GetMeshConfigRevisionList(osmConfigClient, history, namespace, name)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
@@ -1063,16 +2134,23 @@ func (apiHandler *APIHandler) handleGetTrafficSplitList(request *restful.Request
 	response.WriteHeaderAndEntity(http.StatusOK, result)
 }
 
-func (apiHandler *APIHandler) handleGetTrafficTargetList(request *restful.Request, response *restful.Response) {
-	smiAccessClient, err := apiHandler.cManager.SmiAccessClient(request)
+func (apiHandler *APIHandler) handleGetMeshConfigRevision(request *restful.Request, response *restful.Response) {
+	osmConfigClient, err := apiHandler.cManager.OsmConfigClient(request)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
 
-	namespace := parseNamespacePathParameter(request)
-	dataSelect := parser.ParseDataSelectPathParameter(request)
-	result, err := traffictarget.GetTrafficTargetList(smiAccessClient, namespace, dataSelect)
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+	revision, err := strconv.Atoi(request.PathParameter("revision"))
+	if err != nil {
+		errors.HandleInternalError(response, errors.NewBadRequest("revision must be a number"))
+		return
+	}
+
+	history := meshconfig.NewMeshConfigHistory(apiHandler.cManager.InsecureClient(), args.Holder.GetNamespace())
+	result, err := meshconfig.GetMeshConfigRevision(osmConfigClient, history, namespace, name, revision)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
@@ -1080,33 +2158,35 @@ func (apiHandler *APIHandler) handleGetTrafficTargetList(request *restful.Reques
 	response.WriteHeaderAndEntity(http.StatusOK, result)
 }
 
-func (apiHandler *APIHandler) handleGetMeshConfigList(request *restful.Request, response *restful.Response) {
+func (apiHandler *APIHandler) handleRollbackMeshConfig(request *restful.Request, response *restful.Response) {
 	osmConfigClient, err := apiHandler.cManager.OsmConfigClient(request)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
 
-	namespace := parseNamespacePathParameter(request)
-	dataSelect := parser.ParseDataSelectPathParameter(request)
-	result, err := meshconfig.GetMeshConfigList(osmConfigClient, namespace, dataSelect)
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+	revision, err := strconv.Atoi(request.PathParameter("revision"))
 	if err != nil {
+		errors.HandleInternalError(response, errors.NewBadRequest("revision must be a number"))
+		return
+	}
+
+	rollback := new(meshconfig.MeshConfigRollback)
+	if err := request.ReadEntity(rollback); err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
-	response.WriteHeaderAndEntity(http.StatusOK, result)
-}
 
-func (apiHandler *APIHandler) handleGetMeshConfigDetail(request *restful.Request, response *restful.Response) {
-	osmConfigClient, err := apiHandler.cManager.OsmConfigClient(request)
+	author, err := apiHandler.cManager.Username(request)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
 
-	namespace := request.PathParameter("namespace")
-	name := request.PathParameter("name")
-	result, err := meshconfig.GetMeshConfigDetail(osmConfigClient, namespace, name)
+	history := meshconfig.NewMeshConfigHistory(apiHandler.cManager.InsecureClient(), args.Holder.GetNamespace())
+	result, err := meshconfig.RollbackMeshConfig(osmConfigClient, history, author, namespace, name, revision, rollback)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
@@ -1765,6 +2845,31 @@ func (apiHandler *APIHandler) handleGetPodEvents(request *restful.Request, respo
 	response.WriteHeaderAndEntity(http.StatusOK, result)
 }
 
+// Handles get pod sidecar proxy config API call
+func (apiHandler *APIHandler) handleGetPodSidecarConfig(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("pod")
+	view := request.PathParameter("view")
+	result, err := sidecar.GetProxyConfig(k8sClient, cfg, namespace, name, view)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
 // Handles execute shell API call
 func (apiHandler *APIHandler) handleExecShell(request *restful.Request, response *restful.Response) {
 	sessionID, err := genTerminalSessionId()
@@ -1795,6 +2900,12 @@ func (apiHandler *APIHandler) handleExecShell(request *restful.Request, response
 }
 
 func (apiHandler *APIHandler) handleGetDeployments(request *restful.Request, response *restful.Response) {
+	osmConfigClient, err := apiHandler.cManager.OsmConfigClient(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
 		errors.HandleInternalError(response, err)
@@ -1804,7 +2915,7 @@ func (apiHandler *APIHandler) handleGetDeployments(request *restful.Request, res
 	namespace := parseNamespacePathParameter(request)
 	dataSelect := parser.ParseDataSelectPathParameter(request)
 	dataSelect.MetricQuery = dataselect.StandardMetrics
-	result, err := deployment.GetDeploymentList(k8sClient, namespace, dataSelect, apiHandler.iManager.Metric().Client())
+	result, err := deployment.GetDeploymentList(osmConfigClient, k8sClient, namespace, dataSelect, apiHandler.iManager.Metric().Client())
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
@@ -1813,6 +2924,12 @@ func (apiHandler *APIHandler) handleGetDeployments(request *restful.Request, res
 }
 
 func (apiHandler *APIHandler) handleGetDeploymentDetail(request *restful.Request, response *restful.Response) {
+	osmConfigClient, err := apiHandler.cManager.OsmConfigClient(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
 		errors.HandleInternalError(response, err)
@@ -1821,7 +2938,7 @@ func (apiHandler *APIHandler) handleGetDeploymentDetail(request *restful.Request
 
 	namespace := request.PathParameter("namespace")
 	name := request.PathParameter("deployment")
-	result, err := deployment.GetDeploymentDetail(k8sClient, namespace, name)
+	result, err := deployment.GetDeploymentDetail(osmConfigClient, k8sClient, namespace, name)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
@@ -1887,6 +3004,12 @@ func (apiHandler *APIHandler) handleGetDeploymentNewReplicaSet(request *restful.
 }
 
 func (apiHandler *APIHandler) handleGetPods(request *restful.Request, response *restful.Response) {
+	osmConfigClient, err := apiHandler.cManager.OsmConfigClient(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
 		errors.HandleInternalError(response, err)
@@ -1896,7 +3019,7 @@ func (apiHandler *APIHandler) handleGetPods(request *restful.Request, response *
 	namespace := parseNamespacePathParameter(request)
 	dataSelect := parser.ParseDataSelectPathParameter(request)
 	dataSelect.MetricQuery = dataselect.StandardMetrics // download standard metrics - cpu, and memory - by default
-	result, err := pod.GetPodList(k8sClient, apiHandler.iManager.Metric().Client(), namespace, dataSelect)
+	result, err := pod.GetPodList(osmConfigClient, k8sClient, apiHandler.iManager.Metric().Client(), namespace, dataSelect)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
@@ -1905,6 +3028,12 @@ func (apiHandler *APIHandler) handleGetPods(request *restful.Request, response *
 }
 
 func (apiHandler *APIHandler) handleGetPodDetail(request *restful.Request, response *restful.Response) {
+	osmConfigClient, err := apiHandler.cManager.OsmConfigClient(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
 		errors.HandleInternalError(response, err)
@@ -1913,7 +3042,7 @@ func (apiHandler *APIHandler) handleGetPodDetail(request *restful.Request, respo
 
 	namespace := request.PathParameter("namespace")
 	name := request.PathParameter("pod")
-	result, err := pod.GetPodDetail(k8sClient, apiHandler.iManager.Metric().Client(), namespace, name)
+	result, err := pod.GetPodDetail(osmConfigClient, k8sClient, apiHandler.iManager.Metric().Client(), namespace, name)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
@@ -1986,6 +3115,53 @@ func (apiHandler *APIHandler) handleGetResource(request *restful.Request, respon
 	response.WriteHeaderAndEntity(http.StatusOK, result)
 }
 
+func (apiHandler *APIHandler) handleGetGoldenSignals(request *restful.Request, response *restful.Response) {
+	config, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	verber, err := apiHandler.cManager.VerberClient(request, config)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	window := 5 * time.Minute
+	if param := request.QueryParameter("window"); len(param) > 0 {
+		window, err = time.ParseDuration(param)
+		if err != nil || window <= 0 {
+			errors.HandleInternalError(response, errors.NewBadRequest("invalid window "+param))
+			return
+		}
+	}
+
+	kind := request.PathParameter("kind")
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+
+	// Metrics are read with the identity of the dashboard, so make sure the user can get the resource.
+	if _, err := verber.Get(kind, true, namespace, name); err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	metricClient, ok := apiHandler.iManager.Metric().Client().(metricapi.MeshMetricClient)
+	if !ok {
+		errors.HandleInternalError(response, errors.NewGenericResponse(http.StatusServiceUnavailable,
+			errMeshMetricsUnavailable.Error()))
+		return
+	}
+
+	result, err := metricClient.GoldenSignals(api.ResourceKind(kind), namespace, name, window)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
 func (apiHandler *APIHandler) handlePutResource(
 	request *restful.Request, response *restful.Response) {
 	config, err := apiHandler.cManager.Config(request)
@@ -2131,6 +3307,127 @@ func (apiHandler *APIHandler) handleGetNamespaceDetail(request *restful.Request,
 	response.WriteHeaderAndEntity(http.StatusOK, result)
 }
 
+func (apiHandler *APIHandler) handleGetNamespaceEnrollmentList(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	dataSelect := parser.ParseDataSelectPathParameter(request)
+	result, err := ns.GetNamespaceEnrollmentList(k8sClient, dataSelect)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetNamespaceEnrollment(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	name := request.PathParameter("name")
+	result, err := ns.GetEnrollmentStatus(k8sClient, name)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleEnrollNamespace(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	spec := new(ns.EnrollmentSpec)
+	if err := request.ReadEntity(spec); err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	name := request.PathParameter("name")
+	result, err := ns.EnrollNamespace(k8sClient, name, spec)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleUnenrollNamespace(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	name := request.PathParameter("name")
+	mesh := request.QueryParameter("mesh")
+	restart := request.QueryParameter("restart") == "true"
+	result, err := ns.UnenrollNamespace(k8sClient, name, mesh, restart)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetSidecarDriftList(request *restful.Request, response *restful.Response) {
+	osmConfigClient, err := apiHandler.cManager.OsmConfigClient(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	dataSelect := parser.ParseDataSelectPathParameter(request)
+	result, err := drift.GetDriftList(osmConfigClient, k8sClient, dataSelect)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleRestartSidecarDrift(request *restful.Request, response *restful.Response) {
+	osmConfigClient, err := apiHandler.cManager.OsmConfigClient(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	spec := new(drift.RestartSpec)
+	if err := request.ReadEntity(spec); err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	result, err := drift.RestartDriftedWorkloads(osmConfigClient, k8sClient, spec)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
 func (apiHandler *APIHandler) handleGetNamespaceEvents(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
@@ -2374,6 +3671,12 @@ func (apiHandler *APIHandler) handleGetReplicationControllerServices(request *re
 }
 
 func (apiHandler *APIHandler) handleGetDaemonSetList(request *restful.Request, response *restful.Response) {
+	osmConfigClient, err := apiHandler.cManager.OsmConfigClient(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
 		errors.HandleInternalError(response, err)
@@ -2383,7 +3686,7 @@ func (apiHandler *APIHandler) handleGetDaemonSetList(request *restful.Request, r
 	namespace := parseNamespacePathParameter(request)
 	dataSelect := parser.ParseDataSelectPathParameter(request)
 	dataSelect.MetricQuery = dataselect.StandardMetrics
-	result, err := daemonset.GetDaemonSetList(k8sClient, namespace, dataSelect, apiHandler.iManager.Metric().Client())
+	result, err := daemonset.GetDaemonSetList(osmConfigClient, k8sClient, namespace, dataSelect, apiHandler.iManager.Metric().Client())
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
@@ -2393,6 +3696,12 @@ func (apiHandler *APIHandler) handleGetDaemonSetList(request *restful.Request, r
 
 func (apiHandler *APIHandler) handleGetDaemonSetDetail(
 	request *restful.Request, response *restful.Response) {
+	osmConfigClient, err := apiHandler.cManager.OsmConfigClient(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
 		errors.HandleInternalError(response, err)
@@ -2401,7 +3710,7 @@ func (apiHandler *APIHandler) handleGetDaemonSetDetail(
 
 	namespace := request.PathParameter("namespace")
 	name := request.PathParameter("daemonSet")
-	result, err := daemonset.GetDaemonSetDetail(k8sClient, apiHandler.iManager.Metric().Client(), namespace, name)
+	result, err := daemonset.GetDaemonSetDetail(osmConfigClient, k8sClient, apiHandler.iManager.Metric().Client(), namespace, name)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	integrationapi "github.com/kubernetes/dashboard/src/app/backend/integration/api"
+	"github.com/kubernetes/dashboard/src/app/backend/integration/metric"
+	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
+	"github.com/kubernetes/dashboard/src/app/backend/rollout"
+	"github.com/kubernetes/dashboard/src/app/backend/topology"
+)
+
+// errMeshMetricsUnavailable is returned when the active metric client does not provide mesh metrics.
+var errMeshMetricsUnavailable = errors.New("mesh metrics are not available, Prometheus metrics provider is not active")
+
+// meshMetrics provides the mesh metrics of the active metric client to rollouts and the topology
+// graph. The client is resolved on every call, as it is enabled and disabled by health checks.
+type meshMetrics struct {
+	manager metric.MetricManager
+}
+
+// client returns the active metric client if it provides mesh metrics.
+func (self meshMetrics) client() (metricapi.MeshMetricClient, error) {
+	client, ok := self.manager.Client().(metricapi.MeshMetricClient)
+	if !ok {
+		return nil, errMeshMetricsUnavailable
+	}
+	return client, nil
+}
+
+// BackendMetrics implements rollout.MetricSource with the golden signals of a service.
+func (self meshMetrics) BackendMetrics(namespace, service string, window time.Duration) (*rollout.BackendMetrics, error) {
+	client, err := self.client()
+	if err != nil {
+		return nil, err
+	}
+
+	signals, err := client.GoldenSignals(api.ResourceKindService, namespace, service, window)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rollout.BackendMetrics{
+		Requests:    int64(math.Round(signals.RequestRate * window.Seconds())),
+		SuccessRate: signals.SuccessRate,
+		Latency:     time.Duration(signals.LatencyP99 * float64(time.Millisecond)),
+	}, nil
+}
+
+// ObservedTraffic implements topology.TrafficSource with the traffic between workloads and services.
+func (self meshMetrics) ObservedTraffic(namespaces []string, window time.Duration) ([]topology.ObservedTraffic, error) {
+	client, err := self.client()
+	if err != nil {
+		return nil, err
+	}
+
+	edges, err := client.EdgeTraffic(namespaces, window)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]topology.ObservedTraffic, 0, len(edges))
+	for _, edge := range edges {
+		result = append(result, topology.ObservedTraffic{
+			Source: topology.NodeRef{Kind: edge.SourceKind, Namespace: edge.SourceNamespace, Name: edge.SourceName},
+			Destination: topology.NodeRef{
+				Kind:      api.ResourceKindService,
+				Namespace: edge.DestinationNamespace,
+				Name:      edge.DestinationService,
+			},
+			Traffic: topology.Traffic{
+				RequestRate: edge.RequestRate,
+				ErrorRate:   edge.ErrorRate,
+				LatencyP99:  edge.LatencyP99,
+			},
+		})
+	}
+	return result, nil
+}
+
+// hasMeshMetrics tells whether a metric client providing mesh metrics is configured.
+func hasMeshMetrics(manager metric.MetricManager) bool {
+	for _, integration := range manager.List() {
+		if integration.ID() == integrationapi.PrometheusIntegrationID {
+			return true
+		}
+	}
+	return false
+}
@@ -22,8 +22,9 @@ type IntegrationID string
 
 // Integration app IDs should be registered in this block.
 const (
-	HeapsterIntegrationID IntegrationID = "heapster"
-	SidecarIntegrationID  IntegrationID = "sidecar"
+	HeapsterIntegrationID   IntegrationID = "heapster"
+	SidecarIntegrationID    IntegrationID = "sidecar"
+	PrometheusIntegrationID IntegrationID = "prometheus"
 )
 
 // Integration represents application integrated into the dashboard. Every application
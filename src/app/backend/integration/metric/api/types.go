@@ -45,6 +45,49 @@ type MetricClient interface {
 	integrationapi.Integration
 }
 
+// MeshMetricClient is implemented by metric clients that can read the request metrics of the mesh
+// sidecars.
+type MeshMetricClient interface {
+	// GoldenSignals returns the request metrics of a pod, workload or service over the window ending
+	// now.
+	GoldenSignals(kind api.ResourceKind, namespace, name string, window time.Duration) (*GoldenSignals, error)
+	// EdgeTraffic returns the traffic from the workloads of the given namespaces to services over the
+	// window ending now. All namespaces are included when none are given.
+	EdgeTraffic(namespaces []string, window time.Duration) ([]EdgeTraffic, error)
+}
+
+// GoldenSignals are the request metrics of a pod, workload or service.
+type GoldenSignals struct {
+	// Window is the time window the signals are computed over.
+	Window string `json:"window"`
+	// RequestRate is the number of requests per second.
+	RequestRate float64 `json:"requestRate"`
+	// SuccessRate is the ratio of requests that did not fail with a server error, between 0 and 1.
+	// It is 1 when there were no requests.
+	SuccessRate float64 `json:"successRate"`
+	// LatencyP50, LatencyP90 and LatencyP99 are request latency percentiles in milliseconds.
+	LatencyP50 float64 `json:"latencyP50"`
+	LatencyP90 float64 `json:"latencyP90"`
+	LatencyP99 float64 `json:"latencyP99"`
+}
+
+// EdgeTraffic is the traffic the sidecars observed from a workload to a service.
+type EdgeTraffic struct {
+	SourceKind      api.ResourceKind
+	SourceNamespace string
+	SourceName      string
+
+	DestinationNamespace string
+	DestinationService   string
+
+	// RequestRate is the number of requests per second.
+	RequestRate float64
+	// ErrorRate is the ratio of requests that failed with a server error, between 0 and 1.
+	ErrorRate float64
+	// LatencyP99 is the 99th percentile request latency in milliseconds.
+	LatencyP99 float64
+}
+
 // CachedResources contains all resources that may be required by DataSelect functions for metric
 // gathering. Depending on the need you may have to provide DataSelect with resources it
 // requires, for example resource like deployment will need Pods in order to calculate its metrics.
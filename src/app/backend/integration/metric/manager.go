@@ -23,6 +23,7 @@ import (
 	integrationapi "github.com/kubernetes/dashboard/src/app/backend/integration/api"
 	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
 	"github.com/kubernetes/dashboard/src/app/backend/integration/metric/heapster"
+	"github.com/kubernetes/dashboard/src/app/backend/integration/metric/prometheus"
 	"github.com/kubernetes/dashboard/src/app/backend/integration/metric/sidecar"
 	"k8s.io/apimachinery/pkg/util/wait"
 )
@@ -45,6 +46,8 @@ type MetricManager interface {
 	ConfigureSidecar(host string) MetricManager
 	// ConfigureHeapster configures and adds sidecar to clients list.
 	ConfigureHeapster(host string) MetricManager
+	// ConfigurePrometheus configures and adds prometheus to clients list.
+	ConfigurePrometheus(host, service, queries string) MetricManager
 }
 
 // Implements MetricManager interface.
@@ -143,6 +146,19 @@ func (self *metricManager) ConfigureHeapster(host string) MetricManager {
 	return self
 }
 
+// ConfigurePrometheus implements metric manager interface. See MetricManager for more information.
+func (self *metricManager) ConfigurePrometheus(host, service, queries string) MetricManager {
+	kubeClient := self.manager.InsecureClient()
+	metricClient, err := prometheus.CreatePrometheusClient(host, service, queries, kubeClient)
+	if err != nil {
+		log.Printf("There was an error during prometheus client creation: %s", err.Error())
+		return self
+	}
+
+	self.clients[metricClient.ID()] = metricClient
+	return self
+}
+
 // NewMetricManager creates metric manager.
 func NewMetricManager(manager clientapi.ClientManager) MetricManager {
 	return &metricManager{
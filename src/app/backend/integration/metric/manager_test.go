@@ -150,3 +150,23 @@ func TestMetricManager_ConfigureHeapster(t *testing.T) {
 		}
 	}
 }
+
+func TestMetricManager_ConfigurePrometheus(t *testing.T) {
+	cases := []struct {
+		manager         MetricManager
+		service         string
+		expectedClients int
+	}{
+		{NewMetricManager(client.NewClientManager("", "http://localhost:8080")), "osm-system/osm-prometheus:7070", 1},
+		{NewMetricManager(client.NewClientManager("", "http://localhost:8080")), "osm-prometheus", 0},
+	}
+
+	for _, c := range cases {
+		c.manager.ConfigurePrometheus("", c.service, "")
+
+		if len(c.manager.List()) != c.expectedClients {
+			t.Errorf("Failed to configure prometheus. Expected number of clients to be "+
+				"%d, but got %d.", c.expectedClients, len(c.manager.List()))
+		}
+	}
+}
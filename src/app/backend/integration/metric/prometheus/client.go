@@ -0,0 +1,404 @@
+package prometheus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/client"
+	backenderrors "github.com/kubernetes/dashboard/src/app/backend/errors"
+	integrationapi "github.com/kubernetes/dashboard/src/app/backend/integration/api"
+	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
+	"github.com/kubernetes/dashboard/src/app/backend/integration/metric/common"
+)
+
+const (
+	// metricWindow and metricStep are the time range and resolution of CPU and memory graphs.
+	metricWindow = 15 * time.Minute
+	metricStep   = time.Minute
+)
+
+// workloadKinds maps the workloads golden signals are supported for to their Kubernetes kind, as
+// used in the labels of the sidecar metrics.
+var workloadKinds = map[api.ResourceKind]string{
+	api.ResourceKindDeployment:            "Deployment",
+	api.ResourceKindStatefulSet:           "StatefulSet",
+	api.ResourceKindDaemonSet:             "DaemonSet",
+	api.ResourceKindReplicaSet:            "ReplicaSet",
+	api.ResourceKindReplicationController: "ReplicationController",
+	api.ResourceKindJob:                   "Job",
+}
+
+// Prometheus client implements MetricClient, MeshMetricClient and Integration interfaces.
+type prometheusClient struct {
+	client  PrometheusRESTClient
+	queries *Queries
+	now     func() time.Time
+}
+
+// Implement Integration interface.
+
+// HealthCheck implements integration app interface. See Integration interface for more information.
+func (self prometheusClient) HealthCheck() error {
+	if self.client == nil {
+		return errors.New("Prometheus not configured")
+	}
+
+	return self.client.HealthCheck()
+}
+
+// ID implements integration app interface. See Integration interface for more information.
+func (self prometheusClient) ID() integrationapi.IntegrationID {
+	return integrationapi.PrometheusIntegrationID
+}
+
+// Implement MetricClient interface
+
+// DownloadMetrics implements metric client interface. See MetricClient for more information.
+func (self prometheusClient) DownloadMetrics(selectors []metricapi.ResourceSelector,
+	metricNames []string, cachedResources *metricapi.CachedResources) metricapi.MetricPromises {
+	result := metricapi.MetricPromises{}
+	for _, metricName := range metricNames {
+		result = append(result, self.DownloadMetric(selectors, metricName, cachedResources)...)
+	}
+	return result
+}
+
+// DownloadMetric implements metric client interface. See MetricClient for more information.
+func (self prometheusClient) DownloadMetric(selectors []metricapi.ResourceSelector,
+	metricName string, cachedResources *metricapi.CachedResources) metricapi.MetricPromises {
+	result := metricapi.NewMetricPromises(len(selectors))
+
+	var query string
+	switch metricName {
+	case metricapi.CpuUsage:
+		query = self.queries.CPUUsage
+	case metricapi.MemoryUsage:
+		query = self.queries.MemoryUsage
+	default:
+		result.PutMetrics(nil, fmt.Errorf("metric %s is not supported by Prometheus", metricName))
+		return result
+	}
+
+	for i, selector := range selectors {
+		go self.downloadPodMetric(result[i], selector, cachedResources, metricName, query)
+	}
+	return result
+}
+
+// AggregateMetrics implements metric client interface. See MetricClient for more information.
+func (self prometheusClient) AggregateMetrics(metrics metricapi.MetricPromises, metricName string,
+	aggregations metricapi.AggregationModes) metricapi.MetricPromises {
+	return common.AggregateMetricPromises(metrics, metricName, aggregations, nil)
+}
+
+// downloadPodMetric sums the metric of the pods of a resource over the metric window.
+func (self prometheusClient) downloadPodMetric(promise metricapi.MetricPromise, selector metricapi.ResourceSelector,
+	cachedResources *metricapi.CachedResources, metricName, query string) {
+	names, uids, err := getPods(selector, cachedResources)
+	if err != nil {
+		promise.Metric <- nil
+		promise.Error <- err
+		return
+	}
+
+	metric := &metricapi.Metric{
+		DataPoints:   metricapi.DataPoints{},
+		MetricPoints: []metricapi.MetricPoint{},
+		MetricName:   metricName,
+		Label:        metricapi.Label{api.ResourceKindPod: uids},
+	}
+	if len(names) > 0 {
+		series, err := self.queryRange(query, queryParams{Namespace: selector.Namespace, Pods: matchAny(names)})
+		if err != nil {
+			promise.Metric <- nil
+			promise.Error <- err
+			return
+		}
+		metric.DataPoints, metric.MetricPoints = sumSeries(series)
+	}
+
+	promise.Metric <- metric
+	promise.Error <- nil
+}
+
+// Implement MeshMetricClient interface
+
+// GoldenSignals implements mesh metric client interface. See MeshMetricClient for more information.
+func (self prometheusClient) GoldenSignals(kind api.ResourceKind, namespace, name string,
+	window time.Duration) (*metricapi.GoldenSignals, error) {
+	params := queryParams{Namespace: namespace, Name: name, Window: formatWindow(window)}
+
+	var queries SignalQueries
+	switch kind {
+	case api.ResourceKindPod:
+		queries = self.queries.Pod
+	case api.ResourceKindService:
+		queries = self.queries.Service
+	default:
+		workloadKind, ok := workloadKinds[kind]
+		if !ok {
+			return nil, backenderrors.NewBadRequest(fmt.Sprintf("golden signals are not supported for %s", kind))
+		}
+		queries = self.queries.Workload
+		params.Kind = workloadKind
+	}
+
+	result := &metricapi.GoldenSignals{Window: window.String(), SuccessRate: 1}
+
+	requestRate, err := self.queryValue(queries.RequestRate, params)
+	if err != nil {
+		return nil, err
+	}
+	if requestRate != nil {
+		result.RequestRate = *requestRate
+	}
+
+	successRate, err := self.queryValue(queries.SuccessRate, params)
+	if err != nil {
+		return nil, err
+	}
+	if successRate != nil {
+		result.SuccessRate = *successRate
+	}
+
+	for quantile, latency := range map[float64]*float64{0.5: &result.LatencyP50, 0.9: &result.LatencyP90, 0.99: &result.LatencyP99} {
+		params.Quantile = formatQuantile(quantile)
+		value, err := self.queryValue(queries.Latency, params)
+		if err != nil {
+			return nil, err
+		}
+		if value != nil {
+			*latency = *value
+		}
+	}
+
+	return result, nil
+}
+
+// edgeKey identifies the traffic from a workload to a service.
+type edgeKey struct {
+	sourceKind, sourceNamespace, sourceName  string
+	destinationNamespace, destinationService string
+}
+
+// EdgeTraffic implements mesh metric client interface. See MeshMetricClient for more information.
+func (self prometheusClient) EdgeTraffic(namespaces []string, window time.Duration) ([]metricapi.EdgeTraffic, error) {
+	params := queryParams{Namespaces: matchAny(namespaces), Window: formatWindow(window), Quantile: formatQuantile(0.99)}
+
+	requests, err := self.queryEdges(self.queries.EdgeRequestRate, params, false)
+	if err != nil {
+		return nil, err
+	}
+	failures, err := self.queryEdges(self.queries.EdgeErrorRate, params, false)
+	if err != nil {
+		return nil, err
+	}
+	latencies, err := self.queryEdges(self.queries.EdgeLatency, params, true)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]metricapi.EdgeTraffic, 0, len(requests))
+	for key, requestRate := range requests {
+		edge := metricapi.EdgeTraffic{
+			SourceKind:           api.ResourceKind(strings.ToLower(key.sourceKind)),
+			SourceNamespace:      key.sourceNamespace,
+			SourceName:           key.sourceName,
+			DestinationNamespace: key.destinationNamespace,
+			DestinationService:   key.destinationService,
+			RequestRate:          requestRate,
+			LatencyP99:           latencies[key],
+		}
+		if requestRate > 0 {
+			edge.ErrorRate = failures[key] / requestRate
+		}
+		result = append(result, edge)
+	}
+
+	return result, nil
+}
+
+// queryEdges evaluates an edge query. Series of the same workload and service, e.g. for several
+// ports of the service, are added up, or the highest is kept when max is set, e.g. for latencies.
+func (self prometheusClient) queryEdges(query string, params queryParams, max bool) (map[edgeKey]float64, error) {
+	result, err := self.query(query, params)
+	if err != nil {
+		return nil, err
+	}
+
+	edges := make(map[edgeKey]float64)
+	for _, item := range result {
+		if !item.Value.valid() {
+			continue
+		}
+
+		// Cluster names are namespace/service, optionally followed by |port.
+		cluster := strings.SplitN(item.Metric["envoy_cluster_name"], "|", 2)[0]
+		destination := strings.SplitN(cluster, "/", 2)
+		if len(destination) != 2 || len(item.Metric["source_workload_name"]) == 0 {
+			continue
+		}
+
+		key := edgeKey{
+			sourceKind:           item.Metric["source_workload_kind"],
+			sourceNamespace:      item.Metric["source_namespace"],
+			sourceName:           item.Metric["source_workload_name"],
+			destinationNamespace: destination[0],
+			destinationService:   destination[1],
+		}
+		if max {
+			if item.Value.Value > edges[key] {
+				edges[key] = item.Value.Value
+			}
+		} else {
+			edges[key] += item.Value.Value
+		}
+	}
+	return edges, nil
+}
+
+// queryValue evaluates an instant query that returns a single sample. It returns nil when the query
+// returns no valid sample, e.g. when there was no traffic.
+func (self prometheusClient) queryValue(query string, params queryParams) (*float64, error) {
+	result, err := self.query(query, params)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range result {
+		if item.Value.valid() {
+			value := item.Value.Value
+			return &value, nil
+		}
+	}
+	return nil, nil
+}
+
+// query evaluates an instant query at the current time.
+func (self prometheusClient) query(query string, params queryParams) ([]series, error) {
+	rendered, err := render(query, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return self.unmarshalResult(self.client.Get("/api/v1/query").
+		Param("query", rendered).
+		Param("time", formatTime(self.now())))
+}
+
+// queryRange evaluates a range query over the metric window ending now.
+func (self prometheusClient) queryRange(query string, params queryParams) ([]series, error) {
+	rendered, err := render(query, params)
+	if err != nil {
+		return nil, err
+	}
+
+	end := self.now()
+	return self.unmarshalResult(self.client.Get("/api/v1/query_range").
+		Param("query", rendered).
+		Param("start", formatTime(end.Add(-metricWindow))).
+		Param("end", formatTime(end)).
+		Param("step", formatWindow(metricStep)))
+}
+
+// unmarshalResult performs a Prometheus query request and returns the series of its result.
+func (self prometheusClient) unmarshalResult(request *rest.Request) ([]series, error) {
+	rawData, err := request.DoRaw(context.TODO())
+	response := queryResponse{}
+	if len(rawData) > 0 {
+		if unmarshalErr := json.Unmarshal(rawData, &response); unmarshalErr != nil && err == nil {
+			return nil, unmarshalErr
+		}
+	}
+	if response.Status == "error" {
+		return nil, fmt.Errorf("Prometheus query failed with %s: %s", response.ErrorType, response.Error)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Data.Result, nil
+}
+
+func formatTime(t time.Time) string {
+	return strconv.FormatFloat(float64(t.UnixNano())/float64(time.Second), 'f', 3, 64)
+}
+
+// getPods returns the names and UIDs of the pods of a resource.
+func getPods(selector metricapi.ResourceSelector, cachedResources *metricapi.CachedResources) ([]string, []types.UID, error) {
+	if selector.ResourceType == api.ResourceKindPod {
+		return []string{selector.ResourceName}, []types.UID{selector.UID}, nil
+	}
+
+	if metricapi.DerivedResources[selector.ResourceType] != api.ResourceKindPod {
+		return nil, nil, fmt.Errorf(`Resource "%s" is not supported by Prometheus`, selector.ResourceType)
+	}
+	if cachedResources == nil || cachedResources.Pods == nil {
+		return nil, nil, fmt.Errorf(`Pods were not available in cache. Required for resource type: "%s"`, selector.ResourceType)
+	}
+
+	names, uids := make([]string, 0), make([]types.UID, 0)
+	for _, pod := range cachedResources.Pods {
+		if pod.Namespace != selector.Namespace {
+			continue
+		}
+
+		matches := false
+		if selector.ResourceType == api.ResourceKindDeployment {
+			matches = api.IsSelectorMatching(selector.Selector, pod.Labels)
+		} else {
+			for _, ownerRef := range pod.OwnerReferences {
+				matches = matches || (ownerRef.Controller != nil && *ownerRef.Controller && ownerRef.UID == selector.UID)
+			}
+		}
+
+		if matches {
+			names = append(names, pod.Name)
+			uids = append(uids, pod.UID)
+		}
+	}
+	return names, uids, nil
+}
+
+// CreatePrometheusClient creates new Prometheus client. When host param is empty string the function
+// assumes that it is running inside a Kubernetes cluster and connects via service proxy to the
+// service, given as namespace/name:port. Host param is in the format of protocol://address:port,
+// e.g., http://localhost:9090. Queries are read from the JSON file at queriesPath, when given.
+func CreatePrometheusClient(host, service, queriesPath string, k8sClient kubernetes.Interface) (
+	metricapi.MetricClient, error) {
+	queries, err := LoadQueries(queriesPath)
+	if err != nil {
+		return prometheusClient{}, err
+	}
+
+	if host == "" && k8sClient != nil {
+		namespace, name, err := parseService(service)
+		if err != nil {
+			return prometheusClient{}, err
+		}
+
+		log.Printf("Creating in-cluster Prometheus client for %s", service)
+		c := inClusterPrometheusClient{client: k8sClient.CoreV1().RESTClient(), namespace: namespace, service: name}
+		return prometheusClient{client: c, queries: queries, now: time.Now}, nil
+	}
+
+	cfg := &rest.Config{Host: host, QPS: client.DefaultQPS, Burst: client.DefaultBurst}
+	restClient, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return prometheusClient{}, err
+	}
+	log.Printf("Creating remote Prometheus client for %s", host)
+	c := remotePrometheusClient{client: restClient.RESTClient()}
+	return prometheusClient{client: c, queries: queries, now: time.Now}, nil
+}
@@ -0,0 +1,227 @@
+package prometheus
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
+)
+
+// fakePrometheus serves the responses of the first rule whose substrings are all part of the query.
+type fakePrometheus struct {
+	rules   []fakeRule
+	queries []string
+}
+
+type fakeRule struct {
+	contains []string
+	result   string
+}
+
+func (self *fakePrometheus) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/-/healthy":
+		fmt.Fprint(w, "Prometheus is Healthy.")
+		return
+	case "/api/v1/query", "/api/v1/query_range":
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	query := r.URL.Query().Get("query")
+	self.queries = append(self.queries, query)
+
+	resultType := "vector"
+	if r.URL.Path == "/api/v1/query_range" {
+		resultType = "matrix"
+	}
+
+	for _, rule := range self.rules {
+		matches := true
+		for _, substring := range rule.contains {
+			matches = matches && strings.Contains(query, substring)
+		}
+		if matches {
+			fmt.Fprintf(w, `{"status":"success","data":{"resultType":"%s","result":[%s]}}`, resultType, rule.result)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusBadRequest)
+	fmt.Fprintf(w, `{"status":"error","errorType":"bad_data","error":%q}`, "unexpected query "+query)
+}
+
+func newTestClient(t *testing.T, rules ...fakeRule) (prometheusClient, *fakePrometheus) {
+	prometheus := &fakePrometheus{rules: rules}
+	server := httptest.NewServer(prometheus)
+	t.Cleanup(server.Close)
+
+	client, err := CreatePrometheusClient(server.URL, "", "", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return client.(prometheusClient), prometheus
+}
+
+func TestHealthCheck(t *testing.T) {
+	client, _ := newTestClient(t)
+	if err := client.HealthCheck(); err != nil {
+		t.Errorf("expected a healthy Prometheus, but got %s", err)
+	}
+
+	if err := (prometheusClient{}).HealthCheck(); err == nil {
+		t.Error("expected an unconfigured client to be unhealthy")
+	}
+}
+
+func TestGoldenSignals(t *testing.T) {
+	selector := `destination_namespace="bookstore",destination_kind="Deployment",destination_name="bookstore-v1"`
+	client, _ := newTestClient(t,
+		fakeRule{[]string{"histogram_quantile(0.5,", selector}, `{"metric":{},"value":[1656633600,"12.5"]}`},
+		fakeRule{[]string{"histogram_quantile(0.9,", selector}, `{"metric":{},"value":[1656633600,"40"]}`},
+		fakeRule{[]string{"histogram_quantile(0.99,", selector}, `{"metric":{},"value":[1656633600,"NaN"]}`},
+		fakeRule{[]string{`response_code!~"5.."`, selector, "[60s]"}, `{"metric":{},"value":[1656633600,"0.95"]}`},
+		fakeRule{[]string{"osm_request_total", selector, "[60s]"}, `{"metric":{},"value":[1656633600,"20"]}`},
+	)
+
+	signals, err := client.GoldenSignals(api.ResourceKindDeployment, "bookstore", "bookstore-v1", time.Minute)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := &metricapi.GoldenSignals{
+		Window:      "1m0s",
+		RequestRate: 20,
+		SuccessRate: 0.95,
+		LatencyP50:  12.5,
+		LatencyP90:  40,
+	}
+	if !reflect.DeepEqual(signals, expected) {
+		t.Errorf("expected %#v, but got %#v", expected, signals)
+	}
+}
+
+func TestGoldenSignalsWithoutTraffic(t *testing.T) {
+	client, _ := newTestClient(t, fakeRule{[]string{`envoy_cluster_name=~"bookstore/bookstore([|].*)?"`}, ""})
+
+	signals, err := client.GoldenSignals(api.ResourceKindService, "bookstore", "bookstore", 5*time.Minute)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if signals.RequestRate != 0 || signals.SuccessRate != 1 || signals.LatencyP99 != 0 {
+		t.Errorf("expected no requests and no failures, but got %#v", signals)
+	}
+}
+
+func TestGoldenSignalsErrors(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	if _, err := client.GoldenSignals(api.ResourceKindConfigMap, "bookstore", "bookstore", time.Minute); !k8serrors.IsBadRequest(err) {
+		t.Errorf("expected a bad request for a config map, but got %v", err)
+	}
+
+	_, err := client.GoldenSignals(api.ResourceKindPod, "bookstore", "bookstore-v1-0", time.Minute)
+	if err == nil || !strings.Contains(err.Error(), "unexpected query") {
+		t.Errorf("expected the error of Prometheus, but got %v", err)
+	}
+}
+
+func TestEdgeTraffic(t *testing.T) {
+	bookbuyer := `"source_namespace":"bookbuyer","source_workload_kind":"Deployment","source_workload_name":"bookbuyer"`
+	client, prometheus := newTestClient(t,
+		fakeRule{[]string{"histogram_quantile(0.99,"}, `{"metric":{` + bookbuyer + `,"envoy_cluster_name":"bookstore/bookstore|14001"},"value":[1656633600,"30"]},` +
+			`{"metric":{` + bookbuyer + `,"envoy_cluster_name":"bookstore/bookstore|14002"},"value":[1656633600,"80"]}`},
+		fakeRule{[]string{`envoy_response_code_class="5"`}, `{"metric":{` + bookbuyer + `,"envoy_cluster_name":"bookstore/bookstore|14001"},"value":[1656633600,"1"]}`},
+		fakeRule{[]string{"envoy_cluster_upstream_rq_xx"}, `{"metric":{` + bookbuyer + `,"envoy_cluster_name":"bookstore/bookstore|14001"},"value":[1656633600,"6"]},` +
+			`{"metric":{` + bookbuyer + `,"envoy_cluster_name":"bookstore/bookstore|14002"},"value":[1656633600,"4"]},` +
+			`{"metric":{` + bookbuyer + `,"envoy_cluster_name":"passthrough-outbound"},"value":[1656633600,"3"]}`},
+	)
+
+	edges, err := client.EdgeTraffic([]string{"bookbuyer", "bookstore"}, time.Minute)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []metricapi.EdgeTraffic{{
+		SourceKind:           api.ResourceKindDeployment,
+		SourceNamespace:      "bookbuyer",
+		SourceName:           "bookbuyer",
+		DestinationNamespace: "bookstore",
+		DestinationService:   "bookstore",
+		RequestRate:          10,
+		ErrorRate:            0.1,
+		LatencyP99:           80,
+	}}
+	if !reflect.DeepEqual(edges, expected) {
+		t.Errorf("expected %#v, but got %#v", expected, edges)
+	}
+
+	for _, query := range prometheus.queries {
+		if !strings.Contains(query, `source_namespace=~"bookbuyer|bookstore"`) {
+			t.Errorf("expected the query to select the namespaces, but got %s", query)
+		}
+	}
+}
+
+func TestDownloadMetric(t *testing.T) {
+	client, _ := newTestClient(t,
+		fakeRule{[]string{"container_cpu_usage_seconds_total", `namespace="bookstore"`, `pod=~"bookstore-v1-0|bookstore-v1-1"`},
+			`{"metric":{"pod":"bookstore-v1-0"},"values":[[1656633600,"100"],[1656633660,"120"]]},` +
+				`{"metric":{"pod":"bookstore-v1-1"},"values":[[1656633600,"50"],[1656633660,"NaN"]]}`},
+	)
+
+	cachedResources := &metricapi.CachedResources{Pods: []v1.Pod{
+		newPod("bookstore-v1-0", "pod-0", "rs-uid"),
+		newPod("bookstore-v1-1", "pod-1", "rs-uid"),
+		newPod("bookstore-v2-0", "pod-2", "other-uid"),
+	}}
+	selectors := []metricapi.ResourceSelector{{
+		Namespace:    "bookstore",
+		ResourceType: api.ResourceKindReplicaSet,
+		ResourceName: "bookstore-v1",
+		UID:          "rs-uid",
+	}}
+
+	metrics, err := client.DownloadMetric(selectors, metricapi.CpuUsage, cachedResources).GetMetrics()
+	if err != nil || len(metrics) != 1 {
+		t.Fatalf("expected a metric, but got %#v, %v", metrics, err)
+	}
+
+	expectedPoints := metricapi.DataPoints{{X: 1656633600, Y: 150}, {X: 1656633660, Y: 120}}
+	if !reflect.DeepEqual(metrics[0].DataPoints, expectedPoints) {
+		t.Errorf("expected data points %#v, but got %#v", expectedPoints, metrics[0].DataPoints)
+	}
+
+	uids := metrics[0].Label[api.ResourceKindPod]
+	sort.Slice(uids, func(i, j int) bool { return uids[i] < uids[j] })
+	if !reflect.DeepEqual(uids, []types.UID{"pod-0", "pod-1"}) {
+		t.Errorf("expected the metric to be labeled with the pods of the replica set, but got %v", uids)
+	}
+
+	if _, err := client.DownloadMetric(selectors, "network/tx_rate", cachedResources)[0].GetMetric(); err == nil {
+		t.Error("expected an error for an unsupported metric")
+	}
+}
+
+func newPod(name string, uid, owner types.UID) v1.Pod {
+	controller := true
+	return v1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name:            name,
+		Namespace:       "bookstore",
+		UID:             uid,
+		OwnerReferences: []metav1.OwnerReference{{UID: owner, Controller: &controller}},
+	}}
+}
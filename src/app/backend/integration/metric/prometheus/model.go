@@ -0,0 +1,105 @@
+package prometheus
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
+)
+
+// queryResponse is the response of the query and query_range endpoints of the Prometheus HTTP API.
+type queryResponse struct {
+	Status    string    `json:"status"`
+	ErrorType string    `json:"errorType"`
+	Error     string    `json:"error"`
+	Data      queryData `json:"data"`
+}
+
+type queryData struct {
+	// ResultType is vector for instant queries and matrix for range queries.
+	ResultType string   `json:"resultType"`
+	Result     []series `json:"result"`
+}
+
+// series is an element of a vector, with Value set, or of a matrix, with Values set.
+type series struct {
+	Metric map[string]string `json:"metric"`
+	Value  sample            `json:"value"`
+	Values []sample          `json:"values"`
+}
+
+// sample is a [<unix time>, "<value>"] pair.
+type sample struct {
+	Time  time.Time
+	Value float64
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (self *sample) UnmarshalJSON(data []byte) error {
+	var pair []interface{}
+	if err := json.Unmarshal(data, &pair); err != nil {
+		return err
+	}
+	if len(pair) != 2 {
+		return fmt.Errorf("invalid Prometheus sample %s", data)
+	}
+
+	timestamp, ok := pair[0].(float64)
+	if !ok {
+		return fmt.Errorf("invalid Prometheus sample time %v", pair[0])
+	}
+	raw, ok := pair[1].(string)
+	if !ok {
+		return fmt.Errorf("invalid Prometheus sample value %v", pair[1])
+	}
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return err
+	}
+
+	seconds, fraction := math.Modf(timestamp)
+	self.Time = time.Unix(int64(seconds), int64(fraction*float64(time.Second)))
+	self.Value = value
+	return nil
+}
+
+// valid tells whether a sample value can be returned, as NaN and infinite values cannot be encoded
+// to JSON.
+func (self sample) valid() bool {
+	return !math.IsNaN(self.Value) && !math.IsInf(self.Value, 0)
+}
+
+// sumSeries adds up the values of series at the same timestamps, in the format of metric API.
+func sumSeries(result []series) ([]metricapi.DataPoint, []metricapi.MetricPoint) {
+	sums := make(map[int64]float64)
+	timestamps := make([]int64, 0)
+	for _, item := range result {
+		for _, value := range item.Values {
+			if !value.valid() {
+				continue
+			}
+			timestamp := value.Time.Unix()
+			if _, ok := sums[timestamp]; !ok {
+				timestamps = append(timestamps, timestamp)
+			}
+			sums[timestamp] += value.Value
+		}
+	}
+
+	sort.Slice(timestamps, func(i, j int) bool { return timestamps[i] < timestamps[j] })
+	dataPoints := make([]metricapi.DataPoint, 0, len(timestamps))
+	metricPoints := make([]metricapi.MetricPoint, 0, len(timestamps))
+	for _, timestamp := range timestamps {
+		value := sums[timestamp]
+		if value < 0 {
+			value = 0
+		}
+		dataPoints = append(dataPoints, metricapi.DataPoint{X: timestamp, Y: int64(value)})
+		metricPoints = append(metricPoints, metricapi.MetricPoint{Timestamp: time.Unix(timestamp, 0), Value: uint64(value)})
+	}
+	return dataPoints, metricPoints
+}
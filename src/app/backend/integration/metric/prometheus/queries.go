@@ -0,0 +1,190 @@
+package prometheus
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// Queries are the PromQL templates of the Prometheus client. They are Go text/templates and may be
+// overridden by a JSON file with the same structure, see LoadQueries.
+type Queries struct {
+	// CPUUsage and MemoryUsage are evaluated as range queries for a namespace, with .Namespace and
+	// .Pods, a regular expression matching the names of the pods. They return a series per pod,
+	// labeled with pod. CPU usage is in millicores and memory usage in bytes.
+	CPUUsage    string `json:"cpuUsage"`
+	MemoryUsage string `json:"memoryUsage"`
+
+	// Pod, Workload and Service are the golden signals of a single resource.
+	Pod      SignalQueries `json:"pod"`
+	Workload SignalQueries `json:"workload"`
+	Service  SignalQueries `json:"service"`
+
+	// EdgeRequestRate, EdgeErrorRate and EdgeLatency are evaluated with .Namespaces, a regular
+	// expression matching the namespaces of the source workloads, .Window and, for the latency,
+	// .Quantile. They return a series per source workload and destination, labeled with
+	// source_namespace, source_workload_kind, source_workload_name and envoy_cluster_name. The error
+	// rate is the number of failed requests per second.
+	EdgeRequestRate string `json:"edgeRequestRate"`
+	EdgeErrorRate   string `json:"edgeErrorRate"`
+	EdgeLatency     string `json:"edgeLatency"`
+}
+
+// SignalQueries are the golden signal queries of a kind of resource. They are evaluated with
+// .Namespace, .Name, .Kind, the Kubernetes kind of a workload such as Deployment, .Window and, for
+// the latency, .Quantile. Each returns a single sample.
+type SignalQueries struct {
+	// RequestRate is the number of requests per second.
+	RequestRate string `json:"requestRate"`
+	// SuccessRate is the ratio of requests that did not fail with a server error.
+	SuccessRate string `json:"successRate"`
+	// Latency is a request latency percentile in milliseconds.
+	Latency string `json:"latency"`
+}
+
+// queryParams are the values queries are evaluated with.
+type queryParams struct {
+	Namespace  string
+	Name       string
+	Kind       string
+	Pods       string
+	Namespaces string
+	Window     string
+	Quantile   string
+}
+
+const (
+	podSelector      = `destination_namespace="{{.Namespace}}",destination_pod="{{.Name}}"`
+	workloadSelector = `destination_namespace="{{.Namespace}}",destination_kind="{{.Kind}}",destination_name="{{.Name}}"`
+	serviceSelector  = `envoy_cluster_name=~"{{.Namespace}}/{{.Name}}([|].*)?"`
+	edgeLabels       = `source_namespace, source_workload_kind, source_workload_name, envoy_cluster_name`
+)
+
+// DefaultQueries returns the queries for the metrics the sidecars and cAdvisor export to the
+// Prometheus deployed with the mesh.
+func DefaultQueries() *Queries {
+	return &Queries{
+		CPUUsage: `sum by (pod) (rate(container_cpu_usage_seconds_total{namespace="{{.Namespace}}",pod=~"{{.Pods}}",` +
+			`container!="",container!="POD"}[2m])) * 1000`,
+		MemoryUsage: `sum by (pod) (container_memory_working_set_bytes{namespace="{{.Namespace}}",pod=~"{{.Pods}}",` +
+			`container!="",container!="POD"})`,
+
+		Pod:      requestSignalQueries(podSelector),
+		Workload: requestSignalQueries(workloadSelector),
+		Service: SignalQueries{
+			RequestRate: `sum(rate(envoy_cluster_upstream_rq_xx{` + serviceSelector + `}[{{.Window}}]))`,
+			SuccessRate: `sum(rate(envoy_cluster_upstream_rq_xx{` + serviceSelector + `,envoy_response_code_class!="5"}[{{.Window}}]))` +
+				` / sum(rate(envoy_cluster_upstream_rq_xx{` + serviceSelector + `}[{{.Window}}]))`,
+			Latency: `histogram_quantile({{.Quantile}}, sum by (le) (rate(envoy_cluster_upstream_rq_time_bucket{` +
+				serviceSelector + `}[{{.Window}}])))`,
+		},
+
+		EdgeRequestRate: `sum by (` + edgeLabels + `) (rate(envoy_cluster_upstream_rq_xx{` +
+			`source_namespace=~"{{.Namespaces}}"}[{{.Window}}]))`,
+		EdgeErrorRate: `sum by (` + edgeLabels + `) (rate(envoy_cluster_upstream_rq_xx{` +
+			`source_namespace=~"{{.Namespaces}}",envoy_response_code_class="5"}[{{.Window}}]))`,
+		EdgeLatency: `histogram_quantile({{.Quantile}}, sum by (` + edgeLabels + `, le) (rate(envoy_cluster_upstream_rq_time_bucket{` +
+			`source_namespace=~"{{.Namespaces}}"}[{{.Window}}])))`,
+	}
+}
+
+// requestSignalQueries returns the golden signal queries of the requests received by the sidecars
+// matching a label selector.
+func requestSignalQueries(selector string) SignalQueries {
+	return SignalQueries{
+		RequestRate: `sum(rate(osm_request_total{` + selector + `}[{{.Window}}]))`,
+		SuccessRate: `sum(rate(osm_request_total{` + selector + `,response_code!~"5.."}[{{.Window}}]))` +
+			` / sum(rate(osm_request_total{` + selector + `}[{{.Window}}]))`,
+		Latency: `histogram_quantile({{.Quantile}}, sum by (le) (rate(osm_request_duration_ms_bucket{` +
+			selector + `}[{{.Window}}])))`,
+	}
+}
+
+// LoadQueries returns the default queries overridden by the queries of a JSON file. Queries missing
+// from the file keep their default. An empty path returns the default queries.
+func LoadQueries(path string) (*Queries, error) {
+	queries := DefaultQueries()
+	if len(path) == 0 {
+		return queries, nil
+	}
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(content, queries); err != nil {
+		return nil, fmt.Errorf("invalid Prometheus queries in %s: %s", path, err)
+	}
+
+	return queries, queries.validate()
+}
+
+func (self *Queries) validate() error {
+	for name, query := range map[string]string{
+		"cpuUsage":             self.CPUUsage,
+		"memoryUsage":          self.MemoryUsage,
+		"pod.requestRate":      self.Pod.RequestRate,
+		"pod.successRate":      self.Pod.SuccessRate,
+		"pod.latency":          self.Pod.Latency,
+		"workload.requestRate": self.Workload.RequestRate,
+		"workload.successRate": self.Workload.SuccessRate,
+		"workload.latency":     self.Workload.Latency,
+		"service.requestRate":  self.Service.RequestRate,
+		"service.successRate":  self.Service.SuccessRate,
+		"service.latency":      self.Service.Latency,
+		"edgeRequestRate":      self.EdgeRequestRate,
+		"edgeErrorRate":        self.EdgeErrorRate,
+		"edgeLatency":          self.EdgeLatency,
+	} {
+		if _, err := template.New(name).Parse(query); err != nil {
+			return fmt.Errorf("invalid Prometheus query %s: %s", name, err)
+		}
+	}
+	return nil
+}
+
+// render evaluates a query template.
+func render(query string, params queryParams) (string, error) {
+	tmpl, err := template.New("query").Parse(query)
+	if err != nil {
+		return "", err
+	}
+
+	var result bytes.Buffer
+	if err := tmpl.Execute(&result, params); err != nil {
+		return "", err
+	}
+	return result.String(), nil
+}
+
+// matchAny returns a regular expression matching any of the values, escaped for a PromQL string.
+func matchAny(values []string) string {
+	if len(values) == 0 {
+		return ".+"
+	}
+
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = strings.ReplaceAll(regexp.QuoteMeta(value), `\`, `\\`)
+	}
+	return strings.Join(quoted, "|")
+}
+
+// formatWindow returns a window as a PromQL duration.
+func formatWindow(window time.Duration) string {
+	seconds := int64(window.Seconds())
+	if seconds < 1 {
+		seconds = 1
+	}
+	return strconv.FormatInt(seconds, 10) + "s"
+}
+
+func formatQuantile(quantile float64) string {
+	return strconv.FormatFloat(quantile, 'f', -1, 64)
+}
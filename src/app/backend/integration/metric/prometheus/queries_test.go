@@ -0,0 +1,55 @@
+package prometheus
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoadQueries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "queries.json")
+	content := `{"cpuUsage": "sum by (pod) (cpu{pod=~\"{{.Pods}}\"})", "service": {"requestRate": "sum(rate(requests{service=\"{{.Name}}\"}[{{.Window}}]))"}}`
+	if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	queries, err := LoadQueries(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	defaults := DefaultQueries()
+	if queries.MemoryUsage != defaults.MemoryUsage || queries.Service.Latency != defaults.Service.Latency {
+		t.Errorf("expected queries missing from the file to keep their default, but got %#v", queries)
+	}
+
+	rendered, err := render(queries.Service.RequestRate, queryParams{Name: "bookstore", Window: formatWindow(5 * time.Minute)})
+	if err != nil || rendered != `sum(rate(requests{service="bookstore"}[300s]))` {
+		t.Errorf("expected the overridden query, but got %s, %v", rendered, err)
+	}
+
+	if err := ioutil.WriteFile(path, []byte(`{"edgeLatency": "{{.Quantile"}`), 0600); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := LoadQueries(path); err == nil {
+		t.Error("expected an error for an invalid query template")
+	}
+}
+
+func TestMatchAny(t *testing.T) {
+	cases := []struct {
+		values   []string
+		expected string
+	}{
+		{nil, ".+"},
+		{[]string{"bookstore"}, "bookstore"},
+		{[]string{"bookstore-v1.0", "bookbuyer"}, `bookstore-v1\\.0|bookbuyer`},
+	}
+
+	for _, c := range cases {
+		if actual := matchAny(c.values); actual != c.expected {
+			t.Errorf("matchAny(%v) == %s, expected %s", c.values, actual, c.expected)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package prometheus
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/client-go/rest"
+)
+
+// PrometheusRESTClient is used to make raw requests to the Prometheus HTTP API.
+type PrometheusRESTClient interface {
+	// Get creates a new GET request to the path of the Prometheus HTTP API, e.g., /api/v1/query.
+	Get(path string) *rest.Request
+	HealthCheck() error
+}
+
+// inClusterPrometheusClient talks with Prometheus through service proxy.
+type inClusterPrometheusClient struct {
+	client    rest.Interface
+	namespace string
+	// service is the name of the service, optionally followed by a colon and its port.
+	service string
+}
+
+// Get creates request to given path.
+func (self inClusterPrometheusClient) Get(path string) *rest.Request {
+	return self.client.Get().
+		Namespace(self.namespace).
+		Resource("services").
+		Name(self.service).
+		SubResource("proxy").
+		Suffix(path)
+}
+
+// HealthCheck does a health check of the application.
+// Returns nil if connection to application can be established, error object otherwise.
+func (self inClusterPrometheusClient) HealthCheck() error {
+	_, err := self.Get("/-/healthy").DoRaw(context.TODO())
+	return err
+}
+
+// remotePrometheusClient talks with Prometheus at a host through raw RESTClient.
+type remotePrometheusClient struct {
+	client rest.Interface
+}
+
+// Get creates request to given path.
+func (self remotePrometheusClient) Get(path string) *rest.Request {
+	return self.client.Get().AbsPath(path)
+}
+
+// HealthCheck does a health check of the application.
+// Returns nil if connection to application can be established, error object otherwise.
+func (self remotePrometheusClient) HealthCheck() error {
+	_, err := self.Get("/-/healthy").DoRaw(context.TODO())
+	return err
+}
+
+// parseService splits namespace/name:port of a service into its namespace and the name with the
+// port, as used by service proxy.
+func parseService(service string) (string, string, error) {
+	parts := strings.Split(service, "/")
+	if len(parts) != 2 || len(parts[0]) == 0 || len(parts[1]) == 0 {
+		return "", "", fmt.Errorf("invalid Prometheus service %q, expected namespace/name:port", service)
+	}
+	return parts[0], parts[1], nil
+}
@@ -0,0 +1,115 @@
+package osmcli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	cli "github.com/openservicemesh/osm/pkg/cli"
+
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/strvals"
+
+	backenderrors "github.com/kubernetes/dashboard/src/app/backend/errors"
+)
+
+// defaultInstallValues are the values the dashboard applies on top of the chart defaults before
+// the user supplied values document. They enable the observability stack used by the dashboard.
+var defaultInstallValues = map[string]interface{}{
+	"osm": map[string]interface{}{
+		"deployGrafana":                 true,
+		"deployJaeger":                  true,
+		"deployPrometheus":              true,
+		"enablePermissiveTrafficPolicy": true,
+	},
+}
+
+// loadChart loads the embedded osm-edge chart.
+func loadChart() (*chart.Chart, error) {
+	chartRequested, err := loader.LoadArchive(bytes.NewReader(chartTGZSource))
+	if err != nil {
+		return nil, errors.Errorf("Error loading chart for installation: %s", err)
+	}
+
+	cli.EnsureNodeSelector(chartRequested)
+	return chartRequested, nil
+}
+
+// parseValues parses a values document. The document can be given either as a nested JSON object
+// or as a JSON string holding a YAML or JSON document.
+func parseValues(raw json.RawMessage) (map[string]interface{}, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return map[string]interface{}{}, nil
+	}
+
+	document := []byte(raw)
+	if raw[0] == '"' {
+		var text string
+		if err := json.Unmarshal(raw, &text); err != nil {
+			return nil, backenderrors.NewBadRequest(fmt.Sprintf("invalid values document: %s", err))
+		}
+		document = []byte(text)
+	}
+
+	values, err := chartutil.ReadValues(document)
+	if err != nil {
+		return nil, backenderrors.NewBadRequest(fmt.Sprintf("invalid values document: %s", err))
+	}
+
+	return values.AsMap(), nil
+}
+
+// resolveInstallValues builds the values passed to Helm for an install. User supplied values take
+// precedence over the dashboard defaults, while mesh name and single mesh enforcement always follow
+// the install spec.
+func resolveInstallValues(spec OsmInstallSpec) (map[string]interface{}, error) {
+	userValues, err := parseValues(spec.Values)
+	if err != nil {
+		return nil, err
+	}
+
+	finalValues := chartutil.CoalesceTables(userValues, copyValues(defaultInstallValues))
+
+	valuesConfig := []string{
+		fmt.Sprintf("osm.meshName=%s", spec.MeshName),
+		fmt.Sprintf("osm.enforceSingleMesh=%t", spec.EnforceSingleMesh),
+	}
+
+	for _, v := range valuesConfig {
+		if err := strvals.ParseInto(v, finalValues); err != nil {
+			return nil, backenderrors.NewBadRequest(err.Error())
+		}
+	}
+
+	return finalValues, nil
+}
+
+// mergeChartValues returns the given values merged over the defaults of the chart, which is the
+// effective configuration the chart is rendered with.
+func mergeChartValues(chartRequested *chart.Chart, values map[string]interface{}) (map[string]interface{}, error) {
+	merged, err := chartutil.CoalesceValues(chartRequested, copyValues(values))
+	if err != nil {
+		return nil, err
+	}
+
+	return merged.AsMap(), nil
+}
+
+// copyValues returns a deep copy of a values map, so that coalescing never mutates shared state.
+func copyValues(values map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(values))
+	for key, value := range values {
+		if table, ok := value.(map[string]interface{}); ok {
+			result[key] = copyValues(table)
+			continue
+		}
+		result[key] = value
+	}
+
+	return result
+}
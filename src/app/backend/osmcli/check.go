@@ -0,0 +1,502 @@
+package osmcli
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+
+	helm "helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chartutil"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	k8sapierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+
+	backenderrors "github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/osm/sidecar"
+)
+
+// CheckMode selects the set of checks that is run.
+type CheckMode string
+
+const (
+	// CheckModePreflight checks whether a mesh can be installed.
+	CheckModePreflight CheckMode = "preflight"
+
+	// CheckModePostInstall checks whether an installed mesh is healthy.
+	CheckModePostInstall CheckMode = "postinstall"
+)
+
+// CheckStatus is the outcome of a single check.
+type CheckStatus string
+
+const (
+	CheckStatusPass CheckStatus = "pass"
+	CheckStatusWarn CheckStatus = "warn"
+	CheckStatusFail CheckStatus = "fail"
+)
+
+// expectedCRDVersions are the versions of the osm and smi-related crds the mesh control plane
+// relies on.
+var expectedCRDVersions = map[string]string{
+	"egresses.policy.openservicemesh.io":                "v1alpha1",
+	"ingressbackends.policy.openservicemesh.io":         "v1alpha1",
+	"meshconfigs.config.openservicemesh.io":             "v1alpha2",
+	"upstreamtrafficsettings.policy.openservicemesh.io": "v1alpha1",
+	"retries.policy.openservicemesh.io":                 "v1alpha1",
+	"multiclusterservices.config.openservicemesh.io":    "v1alpha2",
+	"httproutegroups.specs.smi-spec.io":                 "v1alpha4",
+	"tcproutes.specs.smi-spec.io":                       "v1alpha4",
+	"trafficsplits.split.smi-spec.io":                   "v1alpha2",
+	"traffictargets.access.smi-spec.io":                 "v1alpha3",
+}
+
+// requiredPermissions are the permissions needed to install a mesh. Namespaced permissions are
+// checked in the mesh namespace.
+var requiredPermissions = []authorizationv1.ResourceAttributes{
+	{Verb: "create", Resource: "namespaces"},
+	{Verb: "create", Group: "apiextensions.k8s.io", Resource: "customresourcedefinitions"},
+	{Verb: "create", Group: "admissionregistration.k8s.io", Resource: "mutatingwebhookconfigurations"},
+	{Verb: "create", Group: "admissionregistration.k8s.io", Resource: "validatingwebhookconfigurations"},
+	{Verb: "create", Group: "rbac.authorization.k8s.io", Resource: "clusterroles"},
+	{Verb: "create", Group: "rbac.authorization.k8s.io", Resource: "clusterrolebindings"},
+	{Verb: "create", Group: "apps", Resource: "deployments", Namespace: "*"},
+	{Verb: "create", Resource: "services", Namespace: "*"},
+	{Verb: "create", Resource: "serviceaccounts", Namespace: "*"},
+	{Verb: "create", Resource: "secrets", Namespace: "*"},
+	{Verb: "create", Resource: "configmaps", Namespace: "*"},
+}
+
+// OsmCheckSpec selects the mesh that is checked.
+type OsmCheckSpec struct {
+	MeshName string `json:"meshName"`
+
+	Namespace string `json:"namespace"`
+
+	// EnforceSingleMesh is the setting of the mesh that is going to be installed. It is only used
+	// by the preflight checks.
+	EnforceSingleMesh bool `json:"enforceSingleMesh"`
+}
+
+func NewOsmCheckSpec() OsmCheckSpec {
+	osmCheckSpec := OsmCheckSpec{}
+	osmCheckSpec.MeshName = "osm"
+	osmCheckSpec.Namespace = "osm-system"
+	osmCheckSpec.EnforceSingleMesh = true
+	return osmCheckSpec
+}
+
+// CheckResult is the outcome of a single check.
+type CheckResult struct {
+	Name string `json:"name"`
+
+	Status CheckStatus `json:"status"`
+
+	Message string `json:"message"`
+
+	// Remediation explains how to fix a check that did not pass.
+	Remediation string `json:"remediation,omitempty"`
+}
+
+// CheckReport contains the results of all checks of a mode.
+type CheckReport struct {
+	Mode CheckMode `json:"mode"`
+
+	MeshName string `json:"meshName"`
+
+	Namespace string `json:"namespace"`
+
+	// Status is the worst status of all checks.
+	Status CheckStatus `json:"status"`
+
+	Checks []CheckResult `json:"checks"`
+}
+
+// checker runs the preflight and post-install checks of a mesh.
+type checker struct {
+	actionConfig     *helm.Configuration
+	k8sClient        kubernetes.Interface
+	extensionsClient apiextensionsclientset.Interface
+	canI             func(ssar *authorizationv1.SelfSubjectAccessReview) bool
+	spec             OsmCheckSpec
+}
+
+// run runs all checks of the given mode.
+func (self checker) run(mode CheckMode) (*CheckReport, error) {
+	var checks []func() CheckResult
+	switch mode {
+	case CheckModePreflight:
+		checks = []func() CheckResult{
+			self.checkKubernetesVersion,
+			self.checkPermissions,
+			self.checkCustomResourceDefinitions,
+			self.checkConflictingWebhooks,
+			self.checkSingleMesh,
+		}
+	case CheckModePostInstall:
+		checks = []func() CheckResult{
+			self.checkDeployment(constants.OSMControllerName),
+			self.checkDeployment(constants.OSMInjectorName),
+			self.checkDeployment(constants.OSMBootstrapName),
+			self.checkMutatingWebhook,
+			self.checkValidatingWebhook,
+			self.checkCABundle,
+		}
+	default:
+		return nil, backenderrors.NewBadRequest(fmt.Sprintf("unknown check mode %s", mode))
+	}
+
+	report := &CheckReport{
+		Mode:      mode,
+		MeshName:  self.spec.MeshName,
+		Namespace: self.spec.Namespace,
+		Status:    CheckStatusPass,
+		Checks:    make([]CheckResult, 0, len(checks)),
+	}
+
+	for _, check := range checks {
+		result := check()
+		report.Checks = append(report.Checks, result)
+
+		if result.Status == CheckStatusFail || (result.Status == CheckStatusWarn && report.Status == CheckStatusPass) {
+			report.Status = result.Status
+		}
+	}
+
+	return report, nil
+}
+
+func (self checker) checkKubernetesVersion() CheckResult {
+	result := CheckResult{Name: "Kubernetes version"}
+
+	chartRequested, err := loadChart()
+	if err != nil {
+		return failed(result, err, "")
+	}
+
+	serverVersion, err := self.k8sClient.Discovery().ServerVersion()
+	if err != nil {
+		return failed(result, err, "Make sure the Kubernetes API server is reachable.")
+	}
+
+	constraint := chartRequested.Metadata.KubeVersion
+	if len(constraint) > 0 && !chartutil.IsCompatibleRange(constraint, serverVersion.GitVersion) {
+		result.Status = CheckStatusFail
+		result.Message = fmt.Sprintf("Kubernetes %s is not supported, the mesh requires %s", serverVersion.GitVersion, constraint)
+		result.Remediation = fmt.Sprintf("Upgrade the cluster to a Kubernetes version matching %s.", constraint)
+		return result
+	}
+
+	result.Status = CheckStatusPass
+	result.Message = fmt.Sprintf("Kubernetes %s is supported", serverVersion.GitVersion)
+	return result
+}
+
+func (self checker) checkPermissions() CheckResult {
+	result := CheckResult{Name: "RBAC permissions"}
+
+	var missing []string
+	for _, permission := range requiredPermissions {
+		attributes := permission
+		if len(attributes.Namespace) > 0 {
+			attributes.Namespace = self.spec.Namespace
+		}
+
+		if !self.canI(&authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{ResourceAttributes: &attributes},
+		}) {
+			missing = append(missing, describePermission(attributes))
+		}
+	}
+
+	if len(missing) > 0 {
+		result.Status = CheckStatusFail
+		result.Message = fmt.Sprintf("Missing permissions: %s", strings.Join(missing, ", "))
+		result.Remediation = "Log in with a user that is allowed to create cluster wide resources, e.g. a cluster-admin."
+		return result
+	}
+
+	result.Status = CheckStatusPass
+	result.Message = "All permissions required to install a mesh are granted"
+	return result
+}
+
+func describePermission(attributes authorizationv1.ResourceAttributes) string {
+	resource := attributes.Resource
+	if len(attributes.Group) > 0 {
+		resource = resource + "." + attributes.Group
+	}
+
+	if len(attributes.Namespace) > 0 {
+		return fmt.Sprintf("%s %s in namespace %s", attributes.Verb, resource, attributes.Namespace)
+	}
+
+	return fmt.Sprintf("%s %s", attributes.Verb, resource)
+}
+
+func (self checker) checkCustomResourceDefinitions() CheckResult {
+	result := CheckResult{Name: "Custom resource definitions"}
+
+	var outdated, existing []string
+	for _, name := range meshCustomResourceDefinitions {
+		crd, err := self.extensionsClient.ApiextensionsV1().CustomResourceDefinitions().Get(context.TODO(), name, metav1.GetOptions{})
+		if k8sapierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return failed(result, err, "")
+		}
+
+		existing = append(existing, name)
+
+		served := false
+		for _, version := range crd.Spec.Versions {
+			if version.Name == expectedCRDVersions[name] && version.Served {
+				served = true
+			}
+		}
+		if !served {
+			outdated = append(outdated, fmt.Sprintf("%s (expected %s)", name, expectedCRDVersions[name]))
+		}
+	}
+
+	if len(outdated) > 0 {
+		result.Status = CheckStatusWarn
+		result.Message = fmt.Sprintf("Existing CRDs do not serve the expected versions: %s", strings.Join(outdated, ", "))
+		result.Remediation = "The CRDs are updated by osm-bootstrap. Make sure no other mesh or SMI implementation relies on the old versions."
+		return result
+	}
+
+	result.Status = CheckStatusPass
+	if len(existing) > 0 {
+		result.Message = fmt.Sprintf("%d existing CRDs serve the expected versions", len(existing))
+	} else {
+		result.Message = "No mesh CRDs are installed yet"
+	}
+	return result
+}
+
+func (self checker) checkConflictingWebhooks() CheckResult {
+	result := CheckResult{Name: "Webhook configurations"}
+
+	selector := labels.Set{constants.OSMAppNameLabelKey: constants.OSMAppNameLabelValue}.String()
+
+	mutatingWebhookConfigurations, err := self.k8sClient.AdmissionregistrationV1().MutatingWebhookConfigurations().List(context.TODO(),
+		metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return failed(result, err, "")
+	}
+
+	validatingWebhookConfigurations, err := self.k8sClient.AdmissionregistrationV1().ValidatingWebhookConfigurations().List(context.TODO(),
+		metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return failed(result, err, "")
+	}
+
+	var conflicting []string
+	for _, webhook := range mutatingWebhookConfigurations.Items {
+		if webhook.Labels[constants.OSMAppInstanceLabelKey] == self.spec.MeshName {
+			conflicting = append(conflicting, "MutatingWebhookConfiguration "+webhook.Name)
+		}
+	}
+	for _, webhook := range validatingWebhookConfigurations.Items {
+		if webhook.Labels[constants.OSMAppInstanceLabelKey] == self.spec.MeshName {
+			conflicting = append(conflicting, "ValidatingWebhookConfiguration "+webhook.Name)
+		}
+	}
+
+	if len(conflicting) > 0 {
+		result.Status = CheckStatusFail
+		result.Message = fmt.Sprintf("Webhooks of a mesh named %s already exist: %s", self.spec.MeshName, strings.Join(conflicting, ", "))
+		result.Remediation = "Choose another mesh name, or uninstall the existing mesh to remove its leftover webhooks."
+		return result
+	}
+
+	others := len(mutatingWebhookConfigurations.Items) + len(validatingWebhookConfigurations.Items)
+	if others > 0 {
+		result.Status = CheckStatusWarn
+		result.Message = fmt.Sprintf("%d webhook configurations of other meshes exist", others)
+		result.Remediation = "Make sure the namespaces of the new mesh are not monitored by another mesh."
+		return result
+	}
+
+	result.Status = CheckStatusPass
+	result.Message = "No conflicting webhook configurations found"
+	return result
+}
+
+func (self checker) checkSingleMesh() CheckResult {
+	result := CheckResult{Name: "Single mesh enforcement"}
+
+	deployments, err := self.k8sClient.AppsV1().Deployments(metav1.NamespaceAll).List(context.TODO(), metav1.ListOptions{
+		LabelSelector: labels.Set{constants.AppLabel: constants.OSMControllerName}.String(),
+	})
+	if err != nil {
+		return failed(result, err, "")
+	}
+
+	var existingMeshes, existingSingleMeshes, sameNamespace []string
+	for _, deployment := range deployments.Items {
+		mesh := fmt.Sprintf("%s/%s", deployment.Namespace, deployment.Labels[sidecar.MeshNameLabel])
+		existingMeshes = append(existingMeshes, mesh)
+		if deployment.Labels["enforceSingleMesh"] == "true" {
+			existingSingleMeshes = append(existingSingleMeshes, mesh)
+		}
+		if deployment.Namespace == self.spec.Namespace {
+			sameNamespace = append(sameNamespace, mesh)
+		}
+	}
+	sort.Strings(existingMeshes)
+
+	switch {
+	case len(sameNamespace) > 0:
+		result.Status = CheckStatusFail
+		result.Message = fmt.Sprintf("Namespace %s already contains meshes %s", self.spec.Namespace, strings.Join(sameNamespace, ", "))
+		result.Remediation = "Install the mesh in another namespace."
+	case len(existingSingleMeshes) > 0:
+		result.Status = CheckStatusFail
+		result.Message = fmt.Sprintf("Meshes %s already enforce being the only mesh in the cluster", strings.Join(existingSingleMeshes, ", "))
+		result.Remediation = "Uninstall the existing mesh before installing a new one."
+	case self.spec.EnforceSingleMesh && len(existingMeshes) > 0:
+		result.Status = CheckStatusFail
+		result.Message = fmt.Sprintf("Meshes %s already exist, so a mesh enforcing being the only one cannot be installed", strings.Join(existingMeshes, ", "))
+		result.Remediation = "Disable enforceSingleMesh, or uninstall the existing meshes."
+	default:
+		result.Status = CheckStatusPass
+		result.Message = fmt.Sprintf("%d other meshes installed", len(existingMeshes))
+	}
+
+	return result
+}
+
+// checkDeployment returns a check that the given control plane deployment is ready.
+func (self checker) checkDeployment(name string) func() CheckResult {
+	return func() CheckResult {
+		result := CheckResult{Name: fmt.Sprintf("Deployment %s", name)}
+
+		deployment, err := self.k8sClient.AppsV1().Deployments(self.spec.Namespace).Get(context.TODO(), name, metav1.GetOptions{})
+		if k8sapierrors.IsNotFound(err) {
+			result.Status = CheckStatusFail
+			result.Message = fmt.Sprintf("Deployment %s not found in namespace %s", name, self.spec.Namespace)
+			result.Remediation = "Make sure the mesh is installed in this namespace."
+			return result
+		}
+		if err != nil {
+			return failed(result, err, "")
+		}
+
+		desired := int32(1)
+		if deployment.Spec.Replicas != nil {
+			desired = *deployment.Spec.Replicas
+		}
+
+		if deployment.Status.ReadyReplicas < desired {
+			result.Status = CheckStatusFail
+			result.Message = fmt.Sprintf("%d of %d replicas are ready", deployment.Status.ReadyReplicas, desired)
+			result.Remediation = fmt.Sprintf("Check the events and logs of the %s pods in namespace %s.", name, self.spec.Namespace)
+			return result
+		}
+
+		result.Status = CheckStatusPass
+		result.Message = fmt.Sprintf("%d of %d replicas are ready", deployment.Status.ReadyReplicas, desired)
+		return result
+	}
+}
+
+func (self checker) checkMutatingWebhook() CheckResult {
+	result := CheckResult{Name: "Sidecar injection webhook"}
+
+	list, err := self.k8sClient.AdmissionregistrationV1().MutatingWebhookConfigurations().List(context.TODO(),
+		webhookConfigurationsListOptions(self.spec.MeshName, constants.OSMInjectorName))
+	if err != nil {
+		return failed(result, err, "")
+	}
+
+	if len(list.Items) == 0 {
+		result.Status = CheckStatusFail
+		result.Message = "No MutatingWebhookConfiguration registered for the mesh"
+		result.Remediation = "Check the logs of osm-injector, it registers the webhook on startup."
+		return result
+	}
+
+	for _, webhookConfiguration := range list.Items {
+		for _, webhook := range webhookConfiguration.Webhooks {
+			if len(webhook.ClientConfig.CABundle) == 0 {
+				result.Status = CheckStatusWarn
+				result.Message = fmt.Sprintf("Webhook %s of %s has no CA bundle", webhook.Name, webhookConfiguration.Name)
+				result.Remediation = "Restart osm-injector to patch the CA bundle into the webhook."
+				return result
+			}
+		}
+	}
+
+	result.Status = CheckStatusPass
+	result.Message = fmt.Sprintf("MutatingWebhookConfiguration %s is registered", list.Items[0].Name)
+	return result
+}
+
+func (self checker) checkValidatingWebhook() CheckResult {
+	result := CheckResult{Name: "Validating webhook"}
+
+	list, err := self.k8sClient.AdmissionregistrationV1().ValidatingWebhookConfigurations().List(context.TODO(),
+		webhookConfigurationsListOptions(self.spec.MeshName, constants.OSMControllerName))
+	if err != nil {
+		return failed(result, err, "")
+	}
+
+	if len(list.Items) == 0 {
+		result.Status = CheckStatusWarn
+		result.Message = "No ValidatingWebhookConfiguration registered for the mesh"
+		result.Remediation = "Check the logs of osm-controller, policies are not validated without the webhook."
+		return result
+	}
+
+	result.Status = CheckStatusPass
+	result.Message = fmt.Sprintf("ValidatingWebhookConfiguration %s is registered", list.Items[0].Name)
+	return result
+}
+
+func (self checker) checkCABundle() CheckResult {
+	result := CheckResult{Name: "CA bundle"}
+
+	secretName := constants.DefaultCABundleSecretName
+	if current, err := findMeshRelease(self.actionConfig, self.spec.Namespace, self.spec.MeshName); err == nil {
+		secretName = releaseCABundleSecretName(current)
+	}
+
+	secret, err := self.k8sClient.CoreV1().Secrets(self.spec.Namespace).Get(context.TODO(), secretName, metav1.GetOptions{})
+	if k8sapierrors.IsNotFound(err) {
+		result.Status = CheckStatusFail
+		result.Message = fmt.Sprintf("Secret %s not found in namespace %s", secretName, self.spec.Namespace)
+		result.Remediation = "Check the logs of osm-bootstrap, it creates the CA bundle on startup."
+		return result
+	}
+	if err != nil {
+		return failed(result, err, "")
+	}
+
+	if len(secret.Data[constants.KubernetesOpaqueSecretCAKey]) == 0 {
+		result.Status = CheckStatusFail
+		result.Message = fmt.Sprintf("Secret %s has no %s entry", secretName, constants.KubernetesOpaqueSecretCAKey)
+		result.Remediation = "Delete the secret and restart osm-bootstrap to recreate it."
+		return result
+	}
+
+	result.Status = CheckStatusPass
+	result.Message = fmt.Sprintf("Secret %s holds the CA bundle", secretName)
+	return result
+}
+
+// failed marks a check as failed because of an unexpected error.
+func failed(result CheckResult, err error, remediation string) CheckResult {
+	result.Status = CheckStatusFail
+	result.Message = err.Error()
+	result.Remediation = remediation
+	return result
+}
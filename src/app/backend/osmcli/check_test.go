@@ -0,0 +1,163 @@
+package osmcli
+
+import (
+	"encoding/json"
+	"testing"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	v1 "k8s.io/api/core/v1"
+	apiextensionsfake "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/version"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func checkStatuses(report *CheckReport) map[string]CheckStatus {
+	result := make(map[string]CheckStatus)
+	for _, check := range report.Checks {
+		result[check.Name] = check.Status
+	}
+	return result
+}
+
+func TestPreflightChecks(t *testing.T) {
+	k8sClient := fake.NewSimpleClientset(
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+			Name:      "osm-controller",
+			Namespace: "other-system",
+			Labels:    map[string]string{"app": "osm-controller", "meshName": "other", "enforceSingleMesh": "false"},
+		}},
+	)
+	k8sClient.Discovery().(*fakediscovery.FakeDiscovery).FakedServerVersion = &version.Info{GitVersion: "v1.18.3"}
+
+	spec := NewOsmCheckSpec()
+	meshChecker := checker{
+		k8sClient:        k8sClient,
+		extensionsClient: apiextensionsfake.NewSimpleClientset(),
+		canI: func(ssar *authorizationv1.SelfSubjectAccessReview) bool {
+			return ssar.Spec.ResourceAttributes.Resource != "clusterroles"
+		},
+		spec: spec,
+	}
+
+	report, err := meshChecker.run(CheckModePreflight)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := map[string]CheckStatus{
+		"Kubernetes version":          CheckStatusFail,
+		"RBAC permissions":            CheckStatusFail,
+		"Custom resource definitions": CheckStatusPass,
+		"Webhook configurations":      CheckStatusPass,
+		"Single mesh enforcement":     CheckStatusFail,
+	}
+
+	actual := checkStatuses(report)
+	for name, status := range expected {
+		if actual[name] != status {
+			t.Errorf("expected check %s to be %s, but got %s", name, status, actual[name])
+		}
+	}
+
+	if report.Status != CheckStatusFail {
+		t.Errorf("expected report status %s, but got %s", CheckStatusFail, report.Status)
+	}
+
+	for _, check := range report.Checks {
+		if check.Status != CheckStatusPass && len(check.Remediation) == 0 {
+			t.Errorf("expected remediation for check %s", check.Name)
+		}
+	}
+}
+
+func TestPostInstallChecks(t *testing.T) {
+	spec := NewOsmCheckSpec()
+	replicas := int32(1)
+
+	newDeployment := func(name string, ready int32) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: spec.Namespace},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+			Status:     appsv1.DeploymentStatus{ReadyReplicas: ready},
+		}
+	}
+
+	k8sClient := fake.NewSimpleClientset(
+		newDeployment("osm-controller", 1),
+		newDeployment("osm-injector", 0),
+		&admissionregistrationv1.MutatingWebhookConfiguration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "osm-webhook-osm",
+				Labels: map[string]string{
+					"app.kubernetes.io/name":     "openservicemesh.io",
+					"app.kubernetes.io/instance": spec.MeshName,
+					"app":                        "osm-injector",
+				},
+			},
+			Webhooks: []admissionregistrationv1.MutatingWebhook{{
+				Name:         "osm-inject.k8s.io",
+				ClientConfig: admissionregistrationv1.WebhookClientConfig{CABundle: []byte("ca")},
+			}},
+		},
+		&v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "osm-ca-bundle", Namespace: spec.Namespace},
+			Data:       map[string][]byte{"ca.crt": []byte("ca")},
+		},
+	)
+
+	meshChecker := checker{
+		actionConfig: newFakeActionConfig(t, NewOsmInstallSpec()),
+		k8sClient:    k8sClient,
+		spec:         spec,
+	}
+
+	report, err := meshChecker.run(CheckModePostInstall)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := map[string]CheckStatus{
+		"Deployment osm-controller": CheckStatusPass,
+		"Deployment osm-injector":   CheckStatusFail,
+		"Deployment osm-bootstrap":  CheckStatusFail,
+		"Sidecar injection webhook": CheckStatusPass,
+		"Validating webhook":        CheckStatusWarn,
+		"CA bundle":                 CheckStatusPass,
+	}
+
+	actual := checkStatuses(report)
+	for name, status := range expected {
+		if actual[name] != status {
+			t.Errorf("expected check %s to be %s, but got %s", name, status, actual[name])
+		}
+	}
+}
+
+func TestCABundleCheckFindsReleaseOfMesh(t *testing.T) {
+	installSpec := NewOsmInstallSpec()
+	installSpec.Values = json.RawMessage(`{"osm": {"caBundleSecretName": "mesh-ca-bundle"}}`)
+
+	spec := NewOsmCheckSpec()
+	meshChecker := checker{
+		actionConfig: newFakeActionConfigWithRelease(t, installSpec, "osm-edge"),
+		k8sClient: fake.NewSimpleClientset(&v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "mesh-ca-bundle", Namespace: spec.Namespace},
+			Data:       map[string][]byte{"ca.crt": []byte("ca")},
+		}),
+		spec: spec,
+	}
+
+	if result := meshChecker.checkCABundle(); result.Status != CheckStatusPass {
+		t.Errorf("expected the CA bundle secret of release osm-edge to be checked, but got %#v", result)
+	}
+}
+
+func TestUnknownCheckMode(t *testing.T) {
+	if _, err := (checker{spec: NewOsmCheckSpec()}).run("unknown"); err == nil {
+		t.Error("expected an error for an unknown check mode")
+	}
+}
@@ -0,0 +1,155 @@
+package osmcli
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+)
+
+// ChangeType describes how a value or an object differs between two revisions.
+type ChangeType string
+
+const (
+	ChangeTypeAdded    ChangeType = "added"
+	ChangeTypeRemoved  ChangeType = "removed"
+	ChangeTypeModified ChangeType = "modified"
+)
+
+// ValueDiff is a single changed leaf of a values document, addressed by its dotted path.
+type ValueDiff struct {
+	Path     string      `json:"path"`
+	Change   ChangeType  `json:"change"`
+	OldValue interface{} `json:"oldValue,omitempty"`
+	NewValue interface{} `json:"newValue,omitempty"`
+}
+
+// ManifestDiff describes how a single rendered object changes.
+type ManifestDiff struct {
+	APIVersion string     `json:"apiVersion"`
+	Kind       string     `json:"kind"`
+	Name       string     `json:"name"`
+	Namespace  string     `json:"namespace,omitempty"`
+	Change     ChangeType `json:"change"`
+
+	// Diff is a unified diff between the old and the new rendered object.
+	Diff string `json:"diff"`
+}
+
+// diffValues returns the leaves that differ between two values documents, sorted by path.
+func diffValues(oldValues, newValues map[string]interface{}) []ValueDiff {
+	oldLeaves := common.FlattenValues(oldValues)
+	newLeaves := common.FlattenValues(newValues)
+
+	result := make([]ValueDiff, 0)
+	for path, oldValue := range oldLeaves {
+		newValue, ok := newLeaves[path]
+		if !ok {
+			result = append(result, ValueDiff{Path: path, Change: ChangeTypeRemoved, OldValue: oldValue})
+			continue
+		}
+
+		if !reflect.DeepEqual(oldValue, newValue) {
+			result = append(result, ValueDiff{Path: path, Change: ChangeTypeModified, OldValue: oldValue, NewValue: newValue})
+		}
+	}
+
+	for path, newValue := range newLeaves {
+		if _, ok := oldLeaves[path]; !ok {
+			result = append(result, ValueDiff{Path: path, Change: ChangeTypeAdded, NewValue: newValue})
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Path < result[j].Path })
+	return result
+}
+
+// diffManifests compares two sets of rendered objects and returns the objects that were added,
+// removed or modified. Objects are matched by kind, namespace and name.
+func diffManifests(oldManifests, newManifests []Manifest) ([]ManifestDiff, error) {
+	oldByKey := make(map[string]Manifest, len(oldManifests))
+	for _, manifest := range oldManifests {
+		oldByKey[manifest.Key()] = manifest
+	}
+
+	newByKey := make(map[string]Manifest, len(newManifests))
+	for _, manifest := range newManifests {
+		newByKey[manifest.Key()] = manifest
+	}
+
+	result := make([]ManifestDiff, 0)
+	for _, oldManifest := range oldManifests {
+		newManifest, ok := newByKey[oldManifest.Key()]
+		if !ok {
+			diff, err := unifiedDiff(oldManifest, Manifest{})
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, toManifestDiff(oldManifest, ChangeTypeRemoved, diff))
+			continue
+		}
+
+		if contentOf(oldManifest) == contentOf(newManifest) {
+			continue
+		}
+
+		diff, err := unifiedDiff(oldManifest, newManifest)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, toManifestDiff(newManifest, ChangeTypeModified, diff))
+	}
+
+	for _, newManifest := range newManifests {
+		if _, ok := oldByKey[newManifest.Key()]; ok {
+			continue
+		}
+
+		diff, err := unifiedDiff(Manifest{}, newManifest)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, toManifestDiff(newManifest, ChangeTypeAdded, diff))
+	}
+
+	return result, nil
+}
+
+func toManifestDiff(manifest Manifest, change ChangeType, diff string) ManifestDiff {
+	return ManifestDiff{
+		APIVersion: manifest.APIVersion,
+		Kind:       manifest.Kind,
+		Name:       manifest.Name,
+		Namespace:  manifest.Namespace,
+		Change:     change,
+		Diff:       diff,
+	}
+}
+
+// contentOf returns the rendered object without the leading source comment, which is not part of
+// the object itself.
+func contentOf(manifest Manifest) string {
+	return strings.TrimPrefix(manifest.Content, sourcePrefix+manifest.Source+"\n")
+}
+
+func unifiedDiff(oldManifest, newManifest Manifest) (string, error) {
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(contentOf(oldManifest)),
+		B:        difflib.SplitLines(contentOf(newManifest)),
+		FromFile: manifestLabel(oldManifest),
+		ToFile:   manifestLabel(newManifest),
+		Context:  3,
+	})
+}
+
+func manifestLabel(manifest Manifest) string {
+	if len(manifest.Kind) == 0 {
+		return "/dev/null"
+	}
+
+	return fmt.Sprintf("%s/%s", manifest.Kind, manifest.Name)
+}
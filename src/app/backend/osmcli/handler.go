@@ -1,30 +1,20 @@
 package osmcli
 
 import (
-	"bytes"
-	"context"
 	"fmt"
 	"net/http"
-	"time"
 
 	_ "embed" // required to embed resources
 
-	"github.com/pkg/errors"
-	"golang.org/x/net/xsrftoken"
-
 	cli "github.com/openservicemesh/osm/pkg/cli"
 
 	helm "helm.sh/helm/v3/pkg/action"
-	"helm.sh/helm/v3/pkg/chart/loader"
 
-	k8sapierrors "k8s.io/apimachinery/pkg/api/errors"
+	authorizationv1 "k8s.io/api/authorization/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/labels"
-
-	"github.com/openservicemesh/osm/pkg/constants"
+	"k8s.io/client-go/dynamic"
 
 	restful "github.com/emicklei/go-restful/v3"
-	"github.com/kubernetes/dashboard/src/app/backend/api"
 	clientapi "github.com/kubernetes/dashboard/src/app/backend/client/api"
 	backenderrors "github.com/kubernetes/dashboard/src/app/backend/errors"
 )
@@ -40,6 +30,7 @@ var chartTGZSource []byte
 
 type OsmCliHandler struct {
 	clientManager clientapi.ClientManager
+	operations    *OperationManager
 }
 
 func (self OsmCliHandler) Install(ws *restful.WebService) {
@@ -47,13 +38,58 @@ func (self OsmCliHandler) Install(ws *restful.WebService) {
 		ws.POST("/osm/cmd/cli/install").
 			To(self.handleOsmInstall).
 			Reads(OsmInstallSpec{}).
-			Writes(api.CsrfToken{}))
+			Writes(Operation{}))
+
+	ws.Route(
+		ws.POST("/osm/cmd/cli/install/dryrun").
+			To(self.handleOsmInstallDryRun).
+			Reads(OsmInstallSpec{}).
+			Writes(OsmInstallDryRun{}))
+
+	ws.Route(
+		ws.POST("/osm/cmd/cli/upgrade/preview").
+			To(self.handleOsmUpgradePreview).
+			Reads(OsmUpgradeSpec{}).
+			Writes(OsmUpgradePreview{}))
+
+	ws.Route(
+		ws.POST("/osm/cmd/cli/upgrade").
+			To(self.handleOsmUpgrade).
+			Reads(OsmUpgradeSpec{}).
+			Writes(Operation{}))
+
+	ws.Route(
+		ws.POST("/osm/cmd/cli/uninstall/preview").
+			To(self.handleOsmUninstallPreview).
+			Reads(OsmUninstallSpec{}).
+			Writes(UninstallReport{}))
 
 	ws.Route(
 		ws.POST("/osm/cmd/cli/uninstall").
 			To(self.handleOsmUninstall).
 			Reads(OsmUninstallSpec{}).
-			Writes(api.CsrfToken{}))
+			Writes(Operation{}))
+
+	ws.Route(
+		ws.POST("/osm/cmd/cli/check/{mode}").
+			To(self.handleOsmCheck).
+			Reads(OsmCheckSpec{}).
+			Writes(CheckReport{}))
+
+	ws.Route(
+		ws.GET("/osm/cmd/cli/mesh").
+			To(self.handleGetMeshes).
+			Writes(MeshList{}))
+
+	ws.Route(
+		ws.GET("/osm/cmd/cli/operation").
+			To(self.handleGetOperations).
+			Writes(OperationList{}))
+
+	ws.Route(
+		ws.GET("/osm/cmd/cli/operation/{id}").
+			To(self.handleGetOperation).
+			Writes(Operation{}))
 }
 
 func debug(format string, v ...interface{}) {
@@ -63,6 +99,17 @@ func debug(format string, v ...interface{}) {
 	}
 }
 
+// newActionConfig returns a Helm configuration that stores releases in the given namespace and
+// writes the Helm debug output to the given log func.
+func newActionConfig(namespace string, log helm.DebugLog) (*helm.Configuration, error) {
+	actionConfig := new(helm.Configuration)
+	if err := actionConfig.Init(settings.RESTClientGetter(), namespace, "secret", log); err != nil {
+		return nil, err
+	}
+
+	return actionConfig, nil
+}
+
 func (self OsmCliHandler) handleOsmInstall(request *restful.Request, response *restful.Response) {
 	osmInstallSpec := NewOsmInstallSpec()
 	if err := request.ReadEntity(&osmInstallSpec); err != nil {
@@ -70,28 +117,13 @@ func (self OsmCliHandler) handleOsmInstall(request *restful.Request, response *r
 		return
 	}
 
-	actionConfig := new(helm.Configuration)
-	_ = actionConfig.Init(settings.RESTClientGetter(), osmInstallSpec.Namespace, "secret", debug)
-
-	installClient := helm.NewInstall(actionConfig)
-
-	installClient.ReleaseName = osmInstallSpec.MeshName
-	installClient.Namespace = osmInstallSpec.Namespace
-	installClient.CreateNamespace = true
-	installClient.Wait = false
-	installClient.Atomic = false
-	installClient.Timeout = 5 * time.Minute
-
-	values := map[string]interface{}{}
-	values["deployGrafana"] = true
-	values["deployJaeger"] = true
-	values["deployPrometheus"] = true
-	values["enablePermissiveTrafficPolicy"] = true
-	values["enforceSingleMesh"] = osmInstallSpec.EnforceSingleMesh
-	values["meshName"] = osmInstallSpec.MeshName
-	values["osmNamespace"] = osmInstallSpec.Namespace
+	values, err := resolveInstallValues(osmInstallSpec)
+	if err != nil {
+		backenderrors.HandleInternalError(response, err)
+		return
+	}
 
-	chartRequested, err := loader.LoadArchive(bytes.NewReader(chartTGZSource))
+	chartRequested, err := loadChart()
 	if err != nil {
 		backenderrors.HandleInternalError(response, err)
 		return
@@ -103,274 +135,362 @@ func (self OsmCliHandler) handleOsmInstall(request *restful.Request, response *r
 		return
 	}
 
-	if _, err = installClient.Run(chartRequested, values); err != nil {
-		if !settings.Verbose() {
-			backenderrors.HandleInternalError(response, err)
-			return
-		}
-		pods, _ := k8sClient.CoreV1().Pods(settings.Namespace()).List(context.Background(), metav1.ListOptions{})
-
-		for _, pod := range pods.Items {
-			fmt.Printf("Status for pod %s in namespace %s:\n %v\n\n", pod.Name, pod.Namespace, pod.Status)
-		}
+	user, err := self.clientManager.Username(request)
+	if err != nil {
+		backenderrors.HandleInternalError(response, err)
+		return
 	}
 
-	fmt.Printf("OSM installed successfully in namespace [%s] with mesh name [%s]\n", settings.Namespace(), osmInstallSpec.MeshName)
+	result := self.operations.Start(OperationTypeInstall, osmInstallSpec.MeshName, osmInstallSpec.Namespace, user,
+		func(recorder *operationRecorder) error {
+			actionConfig, err := newActionConfig(osmInstallSpec.Namespace, recorder.logf)
+			if err != nil {
+				return err
+			}
+
+			return runInstall(recorder, actionConfig, k8sClient, osmInstallSpec, chartRequested, values)
+		})
 
-	// TODO
-	action := request.PathParameter("action")
-	token := xsrftoken.Generate(self.clientManager.CSRFKey(), "none", action)
-	response.WriteHeaderAndEntity(http.StatusOK, api.CsrfToken{Token: token})
+	response.WriteHeaderAndEntity(http.StatusAccepted, result)
 }
 
-func (self OsmCliHandler) handleOsmUninstall(request *restful.Request, response *restful.Response) {
-	osmUninstallSpec := NewOsmUninstallSpec()
-	if err := request.ReadEntity(&osmUninstallSpec); err != nil {
+func (self OsmCliHandler) handleOsmInstallDryRun(request *restful.Request, response *restful.Response) {
+	osmInstallSpec := NewOsmInstallSpec()
+	if err := request.ReadEntity(&osmInstallSpec); err != nil {
 		backenderrors.HandleInternalError(response, err)
 		return
 	}
 
-	actionConfig := new(helm.Configuration)
-	_ = actionConfig.Init(settings.RESTClientGetter(), settings.Namespace(), "secret", debug)
+	result, err := renderInstall(osmInstallSpec)
+	if err != nil {
+		backenderrors.HandleInternalError(response, err)
+		return
+	}
 
-	helmUninstallClient := helm.NewUninstall(actionConfig)
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (self OsmCliHandler) handleOsmUpgradePreview(request *restful.Request, response *restful.Response) {
+	osmUpgradeSpec := NewOsmUpgradeSpec()
+	if err := request.ReadEntity(&osmUpgradeSpec); err != nil {
+		backenderrors.HandleInternalError(response, err)
+		return
+	}
 
-	deleteClusterWideResources := true
+	actionConfig, err := newActionConfig(osmUpgradeSpec.Namespace, debug)
+	if err != nil {
+		backenderrors.HandleInternalError(response, err)
+		return
+	}
 
-	_, err := helmUninstallClient.Run("osm")
+	result, err := previewUpgrade(actionConfig, osmUpgradeSpec)
 	if err != nil {
-		println("error")
+		backenderrors.HandleInternalError(response, err)
+		return
 	}
 
-	if deleteClusterWideResources {
-		var failedDeletions []string
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
 
-		err := self.uninstallCustomResourceDefinitions(request, response)
-		if err != nil {
-			failedDeletions = append(failedDeletions, "CustomResourceDefinitions")
-		}
+func (self OsmCliHandler) handleOsmUpgrade(request *restful.Request, response *restful.Response) {
+	osmUpgradeSpec := NewOsmUpgradeSpec()
+	if err := request.ReadEntity(&osmUpgradeSpec); err != nil {
+		backenderrors.HandleInternalError(response, err)
+		return
+	}
 
-		err = self.uninstallMutatingWebhookConfigurations(request, response)
-		if err != nil {
-			failedDeletions = append(failedDeletions, "MutatingWebhookConfigurations")
-		}
+	k8sClient, err := self.clientManager.Client(request)
+	if err != nil {
+		backenderrors.HandleInternalError(response, err)
+		return
+	}
 
-		err = self.uninstallValidatingWebhookConfigurations(request, response)
-		if err != nil {
-			failedDeletions = append(failedDeletions, "ValidatingWebhookConfigurations")
-		}
+	user, err := self.clientManager.Username(request)
+	if err != nil {
+		backenderrors.HandleInternalError(response, err)
+		return
+	}
 
-		err = self.uninstallSecrets(request, response)
-		if err != nil {
-			failedDeletions = append(failedDeletions, "Secrets")
-		}
+	// A missing release is reported right away instead of as a failed operation.
+	actionConfig, err := newActionConfig(osmUpgradeSpec.Namespace, debug)
+	if err != nil {
+		backenderrors.HandleInternalError(response, err)
+		return
+	}
 
-		if len(failedDeletions) != 0 {
-			fmt.Printf("Failed to completely delete the following OSM resource types: %+v", failedDeletions)
-		}
+	current, err := getUpgradeRelease(actionConfig, osmUpgradeSpec)
+	if err != nil {
+		backenderrors.HandleInternalError(response, err)
+		return
 	}
+	osmUpgradeSpec.Release = current.Name
+
+	result := self.operations.Start(OperationTypeUpgrade, osmUpgradeSpec.MeshName, osmUpgradeSpec.Namespace, user,
+		func(recorder *operationRecorder) error {
+			actionConfig, err := newActionConfig(osmUpgradeSpec.Namespace, recorder.logf)
+			if err != nil {
+				return err
+			}
+
+			return runUpgrade(recorder, actionConfig, k8sClient, osmUpgradeSpec)
+		})
 
-	// TODO
-	action := request.PathParameter("action")
-	token := xsrftoken.Generate(self.clientManager.CSRFKey(), "none", action)
-	response.WriteHeaderAndEntity(http.StatusOK, api.CsrfToken{Token: token})
+	response.WriteHeaderAndEntity(http.StatusAccepted, result)
 }
 
-// uninstallCustomResourceDefinitions uninstalls osm and smi-related crds from the cluster.
-func (self OsmCliHandler) uninstallCustomResourceDefinitions(request *restful.Request, response *restful.Response) error {
-	crds := []string{
-		"egresses.policy.openservicemesh.io",
-		"ingressbackends.policy.openservicemesh.io",
-		"meshconfigs.config.openservicemesh.io",
-		"upstreamtrafficsettings.policy.openservicemesh.io",
-		"retries.policy.openservicemesh.io",
-		"multiclusterservices.config.openservicemesh.io",
-		"httproutegroups.specs.smi-spec.io",
-		"tcproutes.specs.smi-spec.io",
-		"trafficsplits.split.smi-spec.io",
-		"traffictargets.access.smi-spec.io",
+func (self OsmCliHandler) handleOsmUninstall(request *restful.Request, response *restful.Response) {
+	osmUninstallSpec := NewOsmUninstallSpec()
+	if err := request.ReadEntity(&osmUninstallSpec); err != nil {
+		backenderrors.HandleInternalError(response, err)
+		return
+	}
+
+	k8sClient, err := self.clientManager.Client(request)
+	if err != nil {
+		backenderrors.HandleInternalError(response, err)
+		return
 	}
 
 	extensionsClient, err := self.clientManager.APIExtensionsClient(request)
 	if err != nil {
 		backenderrors.HandleInternalError(response, err)
-		return nil
+		return
 	}
 
-	var failedDeletions []string
-	for _, crd := range crds {
-		err := extensionsClient.ApiextensionsV1().CustomResourceDefinitions().Delete(context.Background(), crd, metav1.DeleteOptions{})
+	configClient, err := self.clientManager.OsmConfigClient(request)
+	if err != nil {
+		backenderrors.HandleInternalError(response, err)
+		return
+	}
 
-		if err == nil {
-			fmt.Printf("Successfully deleted OSM CRD: %s\n", crd)
-			continue
-		}
+	cfg, err := self.clientManager.Config(request)
+	if err != nil {
+		backenderrors.HandleInternalError(response, err)
+		return
+	}
 
-		if k8sapierrors.IsNotFound(err) {
-			fmt.Printf("Ignoring - did not find OSM CRD: %s\n", crd)
-		} else {
-			fmt.Printf("Failed to delete OSM CRD %s: %s\n", crd, err.Error())
-			failedDeletions = append(failedDeletions, crd)
-		}
+	dynamicClient, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		backenderrors.HandleInternalError(response, err)
+		return
 	}
 
-	if len(failedDeletions) != 0 {
-		return errors.Errorf("Failed to delete the following OSM CRDs: %+v", failedDeletions)
+	user, err := self.clientManager.Username(request)
+	if err != nil {
+		backenderrors.HandleInternalError(response, err)
+		return
 	}
 
-	return nil
-}
+	result := self.operations.Start(OperationTypeUninstall, osmUninstallSpec.MeshName, osmUninstallSpec.Namespace, user,
+		func(recorder *operationRecorder) error {
+			actionConfig, err := newActionConfig(osmUninstallSpec.Namespace, recorder.logf)
+			if err != nil {
+				return err
+			}
 
-// uninstallMutatingWebhookConfigurations uninstalls osm-related mutating webhook configurations from the cluster.
-func (self OsmCliHandler) uninstallMutatingWebhookConfigurations(request *restful.Request, response *restful.Response) error {
-	// These label selectors should always match the Helm post-delete hook at charts/osm/templates/cleanup-hook.yaml.
-	// TODO
-	webhookConfigurationsLabelSelector := metav1.LabelSelector{
-		MatchLabels: map[string]string{
-			constants.OSMAppNameLabelKey:     constants.OSMAppNameLabelValue,
-			constants.OSMAppInstanceLabelKey: "osm",
-			constants.AppLabel:               constants.OSMInjectorName,
-		},
-	}
+			listConfig, err := newActionConfig(metav1.NamespaceAll, recorder.logf)
+			if err != nil {
+				return err
+			}
+
+			otherMeshes, err := getOtherMeshes(listConfig, k8sClient, configClient, osmUninstallSpec)
+			if err != nil {
+				return err
+			}
+
+			return runUninstall(recorder, actionConfig, k8sClient, extensionsClient, dynamicClient, osmUninstallSpec,
+				otherMeshes)
+		})
 
-	webhookConfigurationsListOptions := metav1.ListOptions{
-		LabelSelector: labels.Set(webhookConfigurationsLabelSelector.MatchLabels).String(),
+	response.WriteHeaderAndEntity(http.StatusAccepted, result)
+}
+
+func (self OsmCliHandler) handleOsmUninstallPreview(request *restful.Request, response *restful.Response) {
+	osmUninstallSpec := NewOsmUninstallSpec()
+	if err := request.ReadEntity(&osmUninstallSpec); err != nil {
+		backenderrors.HandleInternalError(response, err)
+		return
 	}
 
 	k8sClient, err := self.clientManager.Client(request)
 	if err != nil {
 		backenderrors.HandleInternalError(response, err)
-		return nil
+		return
 	}
 
-	mutatingWebhookConfigurations, err := k8sClient.AdmissionregistrationV1().MutatingWebhookConfigurations().List(context.Background(), webhookConfigurationsListOptions)
+	extensionsClient, err := self.clientManager.APIExtensionsClient(request)
+	if err != nil {
+		backenderrors.HandleInternalError(response, err)
+		return
+	}
 
+	configClient, err := self.clientManager.OsmConfigClient(request)
 	if err != nil {
-		errMsg := fmt.Sprintf("Failed to list OSM MutatingWebhookConfigurations in the cluster: %s", err.Error())
-		fmt.Println(errMsg)
-		return errors.New(errMsg)
+		backenderrors.HandleInternalError(response, err)
+		return
 	}
 
-	if len(mutatingWebhookConfigurations.Items) == 0 {
-		fmt.Print("Ignoring - did not find any OSM MutatingWebhookConfigurations in the cluster. Use --mesh-name to delete MutatingWebhookConfigurations belonging to a specific mesh if desired\n")
-		return nil
+	cfg, err := self.clientManager.Config(request)
+	if err != nil {
+		backenderrors.HandleInternalError(response, err)
+		return
 	}
 
-	var failedDeletions []string
-	for _, mutatingWebhookConfiguration := range mutatingWebhookConfigurations.Items {
-		err := k8sClient.AdmissionregistrationV1().MutatingWebhookConfigurations().Delete(context.Background(), mutatingWebhookConfiguration.Name, metav1.DeleteOptions{})
+	dynamicClient, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		backenderrors.HandleInternalError(response, err)
+		return
+	}
 
-		if err == nil {
-			fmt.Printf("Successfully deleted OSM MutatingWebhookConfiguration: %s\n", mutatingWebhookConfiguration.Name)
-		} else {
-			fmt.Printf("Found but failed to delete OSM MutatingWebhookConfiguration %s: %s\n", mutatingWebhookConfiguration.Name, err.Error())
-			failedDeletions = append(failedDeletions, mutatingWebhookConfiguration.Name)
-		}
+	actionConfig, err := newActionConfig(osmUninstallSpec.Namespace, debug)
+	if err != nil {
+		backenderrors.HandleInternalError(response, err)
+		return
 	}
 
-	if len(failedDeletions) != 0 {
-		return errors.Errorf("Found but failed to delete the following OSM MutatingWebhookConfigurations: %+v", failedDeletions)
+	listConfig, err := newActionConfig(metav1.NamespaceAll, debug)
+	if err != nil {
+		backenderrors.HandleInternalError(response, err)
+		return
 	}
 
-	return nil
-}
+	otherMeshes, err := getOtherMeshes(listConfig, k8sClient, configClient, osmUninstallSpec)
+	if err != nil {
+		backenderrors.HandleInternalError(response, err)
+		return
+	}
 
-// uninstallValidatingWebhookConfigurations uninstalls osm-related validating webhook configurations from the cluster.
-func (self OsmCliHandler) uninstallValidatingWebhookConfigurations(request *restful.Request, response *restful.Response) error {
-	// These label selectors should always match the Helm post-delete hook at charts/osm/templates/cleanup-hook.yaml.
-	// TODO
-	webhookConfigurationsLabelSelector := metav1.LabelSelector{
-		MatchLabels: map[string]string{
-			constants.OSMAppNameLabelKey:     constants.OSMAppNameLabelValue,
-			constants.OSMAppInstanceLabelKey: "osm",
-			constants.AppLabel:               constants.OSMControllerName,
-		},
+	result, err := planUninstall(actionConfig, k8sClient, extensionsClient, dynamicClient, osmUninstallSpec, otherMeshes)
+	if err != nil {
+		backenderrors.HandleInternalError(response, err)
+		return
 	}
 
-	webhookConfigurationsListOptions := metav1.ListOptions{
-		LabelSelector: labels.Set(webhookConfigurationsLabelSelector.MatchLabels).String(),
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (self OsmCliHandler) handleOsmCheck(request *restful.Request, response *restful.Response) {
+	osmCheckSpec := NewOsmCheckSpec()
+	if err := request.ReadEntity(&osmCheckSpec); err != nil {
+		backenderrors.HandleInternalError(response, err)
+		return
 	}
 
 	k8sClient, err := self.clientManager.Client(request)
 	if err != nil {
 		backenderrors.HandleInternalError(response, err)
-		return nil
+		return
 	}
 
-	validatingWebhookConfigurations, err := k8sClient.AdmissionregistrationV1().ValidatingWebhookConfigurations().List(context.Background(), webhookConfigurationsListOptions)
-
+	extensionsClient, err := self.clientManager.APIExtensionsClient(request)
 	if err != nil {
-		errMsg := fmt.Sprintf("Failed to list OSM ValidatingWebhookConfigurations in the cluster: %s", err.Error())
-		fmt.Println(errMsg)
-		return errors.New(errMsg)
+		backenderrors.HandleInternalError(response, err)
+		return
 	}
 
-	if len(validatingWebhookConfigurations.Items) == 0 {
-		fmt.Print("Ignoring - did not find any OSM ValidatingWebhookConfigurations in the cluster. Use --mesh-name to delete ValidatingWebhookConfigurations belonging to a specific mesh if desired\n")
-		return nil
+	actionConfig, err := newActionConfig(osmCheckSpec.Namespace, debug)
+	if err != nil {
+		backenderrors.HandleInternalError(response, err)
+		return
 	}
 
-	var failedDeletions []string
-	for _, validatingWebhookConfiguration := range validatingWebhookConfigurations.Items {
-		err := k8sClient.AdmissionregistrationV1().ValidatingWebhookConfigurations().Delete(context.Background(), validatingWebhookConfiguration.Name, metav1.DeleteOptions{})
-
-		if err == nil {
-			fmt.Printf("Successfully deleted OSM ValidatingWebhookConfiguration: %s\n", validatingWebhookConfiguration.Name)
-			continue
-		} else {
-			fmt.Printf("Found but failed to delete OSM ValidatingWebhookConfiguration %s: %s\n", validatingWebhookConfiguration.Name, err.Error())
-			failedDeletions = append(failedDeletions, validatingWebhookConfiguration.Name)
-		}
+	meshChecker := checker{
+		actionConfig:     actionConfig,
+		k8sClient:        k8sClient,
+		extensionsClient: extensionsClient,
+		canI: func(ssar *authorizationv1.SelfSubjectAccessReview) bool {
+			return self.clientManager.CanI(request, ssar)
+		},
+		spec: osmCheckSpec,
 	}
 
-	if len(failedDeletions) != 0 {
-		return errors.Errorf("Found but failed to delete the following OSM ValidatingWebhookConfigurations: %+v", failedDeletions)
+	result, err := meshChecker.run(CheckMode(request.PathParameter("mode")))
+	if err != nil {
+		backenderrors.HandleInternalError(response, err)
+		return
 	}
 
-	return nil
+	response.WriteHeaderAndEntity(http.StatusOK, result)
 }
 
-// uninstallSecrets uninstalls osm-related secrets from the cluster.
-func (self OsmCliHandler) uninstallSecrets(request *restful.Request, response *restful.Response) error {
-	// TODO
-	secrets := []string{
-		"osm-ca-bundle",
+func (self OsmCliHandler) handleGetMeshes(request *restful.Request, response *restful.Response) {
+	k8sClient, err := self.clientManager.Client(request)
+	if err != nil {
+		backenderrors.HandleInternalError(response, err)
+		return
 	}
 
-	k8sClient, err := self.clientManager.Client(request)
+	configClient, err := self.clientManager.OsmConfigClient(request)
 	if err != nil {
 		backenderrors.HandleInternalError(response, err)
-		return nil
+		return
 	}
 
-	var failedDeletions []string
-	for _, secret := range secrets {
-		err := k8sClient.CoreV1().Secrets("osm-system").Delete(context.Background(), secret, metav1.DeleteOptions{})
+	actionConfig, err := newActionConfig(metav1.NamespaceAll, debug)
+	if err != nil {
+		backenderrors.HandleInternalError(response, err)
+		return
+	}
 
-		if err == nil {
-			fmt.Printf("Successfully deleted OSM secret %s in namespace %s\n", secret, "osm-system")
-			continue
-		}
+	result, err := listMeshes(actionConfig, k8sClient, configClient)
+	if err != nil {
+		backenderrors.HandleInternalError(response, err)
+		return
+	}
 
-		if k8sapierrors.IsNotFound(err) {
-			if secret == "osm-ca-bundle" {
-				fmt.Printf("Ignoring - did not find OSM CA bundle secret %s in namespace %s. Use --ca-bundle-secret-name and --osm-namespace to delete a specific mesh namespace's CA bundle secret if desired\n", secret, "osm-system")
-			} else {
-				fmt.Printf("Ignoring - did not find OSM secret %s in namespace %s. Use --osm-namespace to delete a specific mesh namespace's secret if desired\n", secret, "osm-system")
-			}
-		} else {
-			fmt.Printf("Found but failed to delete the OSM secret %s in namespace %s: %s\n", secret, "osm-system", err.Error())
-			failedDeletions = append(failedDeletions, secret)
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (self OsmCliHandler) handleGetOperations(request *restful.Request, response *restful.Response) {
+	user, err := self.clientManager.Username(request)
+	if err != nil {
+		backenderrors.HandleInternalError(response, err)
+		return
+	}
+
+	operations := self.operations.List(user)
+	result := OperationList{Operations: make([]Operation, 0, len(operations.Operations))}
+	for _, operation := range operations.Operations {
+		if self.canViewOperation(request, operation) {
+			result.Operations = append(result.Operations, operation)
 		}
 	}
 
-	if len(failedDeletions) != 0 {
-		return errors.Errorf("Found but failed to delete the following OSM secrets in namespace %s: %+v", "osm-system", failedDeletions)
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (self OsmCliHandler) handleGetOperation(request *restful.Request, response *restful.Response) {
+	user, err := self.clientManager.Username(request)
+	if err != nil {
+		backenderrors.HandleInternalError(response, err)
+		return
 	}
 
-	return nil
+	// Operations of other users are reported as missing, so that their ids cannot be probed.
+	id := request.PathParameter("id")
+	result, ok := self.operations.Get(id)
+	if !ok || result.User != user || !self.canViewOperation(request, result) {
+		backenderrors.HandleInternalError(response, backenderrors.NewNotFound(fmt.Sprintf("operation %s not found", id)))
+		return
+	}
+
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+// canViewOperation checks that the user of a request can still get the Helm releases in the
+// namespace of an operation, which hold the values its log may show.
+func (self OsmCliHandler) canViewOperation(request *restful.Request, operation Operation) bool {
+	return self.clientManager.CanI(request, &authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: operation.Namespace,
+				Verb:      "get",
+				Resource:  "secrets",
+			},
+		},
+	})
 }
 
 func NewOsmCliHandler(clientManager clientapi.ClientManager) OsmCliHandler {
-	return OsmCliHandler{clientManager: clientManager}
+	return OsmCliHandler{clientManager: clientManager, operations: NewOperationManager()}
 }
@@ -0,0 +1,92 @@
+package osmcli
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	helm "helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart"
+
+	"k8s.io/client-go/kubernetes"
+)
+
+// renderInstall renders the chart for the given install spec without contacting the cluster. The
+// result holds the rendered objects and the effective values the chart was rendered with.
+func renderInstall(spec OsmInstallSpec) (*OsmInstallDryRun, error) {
+	chartRequested, err := loadChart()
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := resolveInstallValues(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	actionConfig := &helm.Configuration{Log: debug}
+
+	installClient := helm.NewInstall(actionConfig)
+	installClient.ReleaseName = spec.MeshName
+	installClient.Namespace = spec.Namespace
+	installClient.DryRun = true
+	installClient.ClientOnly = true
+
+	rel, err := installClient.Run(chartRequested, values)
+	if err != nil {
+		return nil, err
+	}
+
+	mergedValues, err := mergeChartValues(chartRequested, values)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OsmInstallDryRun{
+		MeshName:     spec.MeshName,
+		Namespace:    spec.Namespace,
+		ChartName:    chartRequested.Metadata.Name,
+		ChartVersion: chartRequested.Metadata.Version,
+		Values:       mergedValues,
+		Manifests:    splitManifest(rel.Manifest),
+		Hooks:        hookManifests(rel.Hooks),
+		Notes:        rel.Info.Notes,
+	}, nil
+}
+
+// runInstall installs the chart for the given install spec and waits until the control plane is
+// ready. The readiness of the control plane pods is recorded while Helm is waiting.
+func runInstall(recorder *operationRecorder, actionConfig *helm.Configuration, k8sClient kubernetes.Interface,
+	spec OsmInstallSpec, chartRequested *chart.Chart, values map[string]interface{}) error {
+	recorder.setPhase(OperationPhaseInstalling)
+	recorder.logf("Installing mesh [%s] in namespace [%s]", spec.MeshName, spec.Namespace)
+
+	installClient := helm.NewInstall(actionConfig)
+	installClient.ReleaseName = spec.MeshName
+	installClient.Namespace = spec.Namespace
+	installClient.CreateNamespace = true
+	installClient.Wait = true
+	installClient.Atomic = false
+	installClient.Timeout = 5 * time.Minute
+
+	ctx, cancel := context.WithCancel(context.Background())
+	watched := make(chan struct{})
+	go func() {
+		recorder.watchControlPlane(ctx, k8sClient, spec.Namespace)
+		close(watched)
+	}()
+
+	_, err := installClient.Run(chartRequested, values)
+
+	cancel()
+	<-watched
+	recorder.refreshControlPlane(k8sClient, spec.Namespace)
+
+	if err != nil {
+		return errors.Wrap(err, "failed to install mesh release")
+	}
+
+	recorder.logf("OSM installed successfully in namespace [%s] with mesh name [%s]", spec.Namespace, spec.MeshName)
+	return nil
+}
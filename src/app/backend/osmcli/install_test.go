@@ -0,0 +1,116 @@
+package osmcli
+
+import (
+	"encoding/json"
+	"testing"
+
+	"helm.sh/helm/v3/pkg/chartutil"
+)
+
+func TestResolveInstallValues(t *testing.T) {
+	cases := []struct {
+		info     string
+		values   string
+		path     string
+		expected interface{}
+	}{
+		{
+			"dashboard defaults are applied",
+			``,
+			"osm.deployPrometheus",
+			true,
+		},
+		{
+			"nested object overrides dashboard defaults",
+			`{"osm": {"deployPrometheus": false}}`,
+			"osm.deployPrometheus",
+			false,
+		},
+		{
+			"yaml document overrides dashboard defaults",
+			`"osm:\n  deployGrafana: false\n"`,
+			"osm.deployGrafana",
+			false,
+		},
+		{
+			"spec fields take precedence over the values document",
+			`{"osm": {"meshName": "other"}}`,
+			"osm.meshName",
+			"test-mesh",
+		},
+		{
+			"untouched defaults are kept next to overrides",
+			`{"osm": {"deployPrometheus": false}}`,
+			"osm.deployJaeger",
+			true,
+		},
+	}
+
+	for _, c := range cases {
+		spec := NewOsmInstallSpec()
+		spec.MeshName = "test-mesh"
+		spec.Values = json.RawMessage(c.values)
+
+		values, err := resolveInstallValues(spec)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", c.info, err)
+		}
+
+		actual, err := chartutil.Values(values).PathValue(c.path)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", c.info, err)
+		}
+
+		if actual != c.expected {
+			t.Errorf("%s: expected %s to be %v, but got %v", c.info, c.path, c.expected, actual)
+		}
+	}
+}
+
+func TestResolveInstallValuesInvalid(t *testing.T) {
+	spec := NewOsmInstallSpec()
+	spec.Values = json.RawMessage(`"osm: [unclosed"`)
+
+	if _, err := resolveInstallValues(spec); err == nil {
+		t.Error("expected an error for an invalid values document")
+	}
+}
+
+func TestRenderInstall(t *testing.T) {
+	spec := NewOsmInstallSpec()
+	spec.MeshName = "test-mesh"
+	spec.Namespace = "test-namespace"
+	spec.Values = json.RawMessage(`{"osm": {"deployGrafana": false, "osmController": {"replicaCount": 2}}}`)
+
+	result, err := renderInstall(spec)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(result.Manifests) == 0 {
+		t.Fatal("expected rendered manifests")
+	}
+
+	replicas, err := chartutil.Values(result.Values).PathValue("osm.osmController.replicaCount")
+	if err != nil || replicas != float64(2) {
+		t.Errorf("expected merged replica count 2, but got %v (%v)", replicas, err)
+	}
+
+	if _, err := chartutil.Values(result.Values).PathValue("osm.sidecarLogLevel"); err != nil {
+		t.Errorf("expected chart defaults in merged values: %s", err)
+	}
+
+	foundController := false
+	for _, manifest := range result.Manifests {
+		if manifest.Kind == "Deployment" && manifest.Name == "osm-controller" {
+			foundController = true
+		}
+		if manifest.Kind == "Deployment" && manifest.Name == "osm-grafana" {
+			t.Error("expected grafana not to be rendered")
+		}
+	}
+
+	if !foundController {
+		t.Error("expected osm-controller deployment to be rendered")
+	}
+}
@@ -0,0 +1,93 @@
+package osmcli
+
+import (
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/releaseutil"
+)
+
+// Manifest is a single Kubernetes object rendered from the chart.
+type Manifest struct {
+	// Source is the chart template the object was rendered from.
+	Source string `json:"source"`
+
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+	Namespace  string `json:"namespace,omitempty"`
+
+	// Content is the rendered YAML of the object.
+	Content string `json:"content"`
+}
+
+// Key identifies the object the manifest describes.
+func (self Manifest) Key() string {
+	return strings.Join([]string{self.Kind, self.Namespace, self.Name}, "/")
+}
+
+type manifestHead struct {
+	APIVersion string `yaml:"apiVersion"`
+	Kind       string `yaml:"kind"`
+	Metadata   struct {
+		Name      string `yaml:"name"`
+		Namespace string `yaml:"namespace"`
+	} `yaml:"metadata"`
+}
+
+const sourcePrefix = "# Source: "
+
+// splitManifest splits a rendered release manifest into its objects, in the order Helm rendered
+// them. Empty documents are skipped.
+func splitManifest(manifest string) []Manifest {
+	documents := releaseutil.SplitManifests(manifest)
+
+	keys := make([]string, 0, len(documents))
+	for key := range documents {
+		keys = append(keys, key)
+	}
+	sort.Sort(releaseutil.BySplitManifestsOrder(keys))
+
+	result := make([]Manifest, 0, len(keys))
+	for _, key := range keys {
+		content := strings.TrimSpace(documents[key])
+
+		head := manifestHead{}
+		if err := yaml.Unmarshal([]byte(content), &head); err != nil || len(head.Kind) == 0 {
+			continue
+		}
+
+		source := ""
+		if strings.HasPrefix(content, sourcePrefix) {
+			source = strings.TrimPrefix(strings.SplitN(content, "\n", 2)[0], sourcePrefix)
+		}
+
+		result = append(result, Manifest{
+			Source:     source,
+			APIVersion: head.APIVersion,
+			Kind:       head.Kind,
+			Name:       head.Metadata.Name,
+			Namespace:  head.Metadata.Namespace,
+			Content:    content,
+		})
+	}
+
+	return result
+}
+
+// hookManifests returns the rendered manifests of the release hooks.
+func hookManifests(hooks []*release.Hook) []Manifest {
+	result := make([]Manifest, 0, len(hooks))
+	for _, hook := range hooks {
+		for _, manifest := range splitManifest(hook.Manifest) {
+			if len(manifest.Source) == 0 {
+				manifest.Source = hook.Path
+			}
+			result = append(result, manifest)
+		}
+	}
+
+	return result
+}
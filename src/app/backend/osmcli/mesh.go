@@ -0,0 +1,212 @@
+package osmcli
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	osmconfigclientset "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned"
+
+	helm "helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/release"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/osm/sidecar"
+)
+
+// meshChartNames are the names of the charts that install a mesh control plane.
+var meshChartNames = map[string]bool{
+	"osm-edge": true,
+	"osm":      true,
+}
+
+// MeshInfo describes a single mesh installed in the cluster.
+type MeshInfo struct {
+	Name string `json:"name"`
+
+	Namespace string `json:"namespace"`
+
+	// Release is the name of the Helm release of the mesh. It is empty for meshes that were not
+	// installed with Helm.
+	Release string `json:"release,omitempty"`
+
+	Revision int `json:"revision,omitempty"`
+
+	Status string `json:"status,omitempty"`
+
+	ChartVersion string `json:"chartVersion,omitempty"`
+
+	AppVersion string `json:"appVersion,omitempty"`
+
+	// MeshConfigs are the names of the MeshConfigs in the mesh namespace.
+	MeshConfigs []string `json:"meshConfigs"`
+
+	// MonitoredNamespaces are the namespaces enrolled in the mesh.
+	MonitoredNamespaces []string `json:"monitoredNamespaces"`
+}
+
+// MeshList contains the meshes installed in the cluster.
+type MeshList struct {
+	Meshes []MeshInfo `json:"meshes"`
+}
+
+// listMeshes returns every mesh installed in the cluster. Meshes are discovered from the Helm
+// releases of the mesh chart in all namespaces. Namespaces that hold a MeshConfig but no release
+// are reported as well, named after their osm-controller deployment.
+func listMeshes(actionConfig *helm.Configuration, k8sClient kubernetes.Interface,
+	configClient osmconfigclientset.Interface) (*MeshList, error) {
+	listClient := helm.NewList(actionConfig)
+	listClient.AllNamespaces = true
+	listClient.StateMask = helm.ListDeployed | helm.ListFailed | helm.ListPendingInstall | helm.ListPendingUpgrade |
+		helm.ListPendingRollback
+
+	releases, err := listClient.Run()
+	if err != nil {
+		return nil, err
+	}
+
+	meshConfigs, err := configClient.ConfigV1alpha2().MeshConfigs(metav1.NamespaceAll).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	meshConfigsByNamespace := make(map[string][]string)
+	for _, meshConfig := range meshConfigs.Items {
+		meshConfigsByNamespace[meshConfig.Namespace] = append(meshConfigsByNamespace[meshConfig.Namespace], meshConfig.Name)
+	}
+
+	result := &MeshList{Meshes: make([]MeshInfo, 0)}
+	covered := make(map[string]bool)
+	for _, rel := range releases {
+		if rel.Chart == nil || rel.Chart.Metadata == nil || !meshChartNames[rel.Chart.Metadata.Name] {
+			continue
+		}
+
+		result.Meshes = append(result.Meshes, toMeshInfo(rel))
+		covered[rel.Namespace] = true
+	}
+
+	for namespace := range meshConfigsByNamespace {
+		if covered[namespace] {
+			continue
+		}
+
+		meshName, err := getControllerMeshName(k8sClient, namespace)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Meshes = append(result.Meshes, MeshInfo{Name: meshName, Namespace: namespace})
+	}
+
+	for i := range result.Meshes {
+		mesh := &result.Meshes[i]
+		mesh.MeshConfigs = append([]string{}, meshConfigsByNamespace[mesh.Namespace]...)
+		sort.Strings(mesh.MeshConfigs)
+
+		mesh.MonitoredNamespaces, err = getMonitoredNamespaces(k8sClient, mesh.Name)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Slice(result.Meshes, func(i, j int) bool {
+		if result.Meshes[i].Namespace != result.Meshes[j].Namespace {
+			return result.Meshes[i].Namespace < result.Meshes[j].Namespace
+		}
+		return result.Meshes[i].Name < result.Meshes[j].Name
+	})
+
+	return result, nil
+}
+
+func toMeshInfo(rel *release.Release) MeshInfo {
+	return MeshInfo{
+		Name:         releaseMeshName(rel),
+		Namespace:    rel.Namespace,
+		Release:      rel.Name,
+		Revision:     rel.Version,
+		Status:       rel.Info.Status.String(),
+		ChartVersion: rel.Chart.Metadata.Version,
+		AppVersion:   rel.Chart.Metadata.AppVersion,
+	}
+}
+
+// releaseMeshName returns the mesh name a release was installed with, falling back to the release
+// name when the values do not set it.
+func releaseMeshName(rel *release.Release) string {
+	meshName, err := chartutil.Values(rel.Config).PathValue("osm.meshName")
+	if err != nil {
+		return rel.Name
+	}
+
+	if name, ok := meshName.(string); ok && len(name) > 0 {
+		return name
+	}
+
+	return rel.Name
+}
+
+// releaseCABundleSecretName returns the name of the CA bundle secret of a release.
+func releaseCABundleSecretName(rel *release.Release) string {
+	values, err := mergeChartValues(rel.Chart, rel.Config)
+	if err != nil {
+		return constants.DefaultCABundleSecretName
+	}
+
+	secretName, err := chartutil.Values(values).PathValue("osm.caBundleSecretName")
+	if err != nil {
+		return constants.DefaultCABundleSecretName
+	}
+
+	if name, ok := secretName.(string); ok && len(name) > 0 {
+		return name
+	}
+
+	return constants.DefaultCABundleSecretName
+}
+
+// getControllerMeshName returns the mesh name of the osm-controller deployment in the given namespace.
+func getControllerMeshName(k8sClient kubernetes.Interface, namespace string) (string, error) {
+	deployments, err := k8sClient.AppsV1().Deployments(namespace).List(context.TODO(), metav1.ListOptions{
+		LabelSelector: labels.Set{constants.AppLabel: constants.OSMControllerName}.String(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	for _, deployment := range deployments.Items {
+		if meshName := deployment.Labels[sidecar.MeshNameLabel]; len(meshName) > 0 {
+			return meshName, nil
+		}
+	}
+
+	return "", nil
+}
+
+// getMonitoredNamespaces returns the names of the namespaces enrolled in the given mesh.
+func getMonitoredNamespaces(k8sClient kubernetes.Interface, meshName string) ([]string, error) {
+	result := make([]string, 0)
+	if len(meshName) == 0 {
+		return result, nil
+	}
+
+	namespaces, err := k8sClient.CoreV1().Namespaces().List(context.TODO(), metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", constants.OSMKubeResourceMonitorAnnotation, meshName),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, namespace := range namespaces.Items {
+		result = append(result, namespace.Name)
+	}
+
+	sort.Strings(result)
+	return result, nil
+}
@@ -0,0 +1,76 @@
+package osmcli
+
+import (
+	"reflect"
+	"testing"
+
+	configv1alpha2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	osmconfigfake "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned/fake"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestListMeshes(t *testing.T) {
+	spec := NewOsmInstallSpec()
+	spec.MeshName = "edge"
+	spec.Namespace = "edge-system"
+	actionConfig := newFakeActionConfig(t, spec)
+
+	k8sClient := fake.NewSimpleClientset(
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name:   "bookstore",
+			Labels: map[string]string{"openservicemesh.io/monitored-by": "edge"},
+		}},
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name:   "bookbuyer",
+			Labels: map[string]string{"openservicemesh.io/monitored-by": "other"},
+		}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+			Name:      "osm-controller",
+			Namespace: "other-system",
+			Labels:    map[string]string{"app": "osm-controller", "meshName": "other"},
+		}},
+	)
+
+	configClient := osmconfigfake.NewSimpleClientset(
+		&configv1alpha2.MeshConfig{ObjectMeta: metav1.ObjectMeta{Name: "osm-mesh-config", Namespace: "edge-system"}},
+		&configv1alpha2.MeshConfig{ObjectMeta: metav1.ObjectMeta{Name: "osm-mesh-config", Namespace: "other-system"}},
+	)
+
+	result, err := listMeshes(actionConfig, k8sClient, configClient)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	chartRequested, err := loadChart()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []MeshInfo{
+		{
+			Name:                "edge",
+			Namespace:           "edge-system",
+			Release:             "edge",
+			Revision:            1,
+			Status:              "deployed",
+			ChartVersion:        chartRequested.Metadata.Version,
+			AppVersion:          chartRequested.Metadata.AppVersion,
+			MeshConfigs:         []string{"osm-mesh-config"},
+			MonitoredNamespaces: []string{"bookstore"},
+		},
+		{
+			Name:                "other",
+			Namespace:           "other-system",
+			MeshConfigs:         []string{"osm-mesh-config"},
+			MonitoredNamespaces: []string{"bookbuyer"},
+		},
+	}
+
+	if !reflect.DeepEqual(result.Meshes, expected) {
+		t.Errorf("expected %#v, but got %#v", expected, result.Meshes)
+	}
+}
@@ -0,0 +1,347 @@
+package osmcli
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/kubernetes"
+)
+
+// OperationType is the kind of mesh operation that is tracked.
+type OperationType string
+
+const (
+	OperationTypeInstall   OperationType = "install"
+	OperationTypeUninstall OperationType = "uninstall"
+	OperationTypeUpgrade   OperationType = "upgrade"
+)
+
+// OperationPhase is the current stage of a tracked mesh operation.
+type OperationPhase string
+
+const (
+	OperationPhasePending      OperationPhase = "Pending"
+	OperationPhaseInstalling   OperationPhase = "Installing"
+	OperationPhaseUpgrading    OperationPhase = "Upgrading"
+	OperationPhaseUninstalling OperationPhase = "Uninstalling"
+	OperationPhaseCleaningUp   OperationPhase = "CleaningUp"
+	OperationPhaseSucceeded    OperationPhase = "Succeeded"
+	OperationPhaseFailed       OperationPhase = "Failed"
+)
+
+const (
+	// operationRetention is how long finished operations are kept for status queries.
+	operationRetention = time.Hour
+
+	// operationLogLimit is the maximum number of log lines kept per operation.
+	operationLogLimit = 2000
+
+	// readinessPollInterval is how often control plane pods are checked during an install.
+	readinessPollInterval = 5 * time.Second
+)
+
+// controlPlaneApps are the app label values of the mesh control plane pods.
+var controlPlaneApps = []string{
+	constants.OSMControllerName,
+	constants.OSMInjectorName,
+	constants.OSMBootstrapName,
+}
+
+// ResourceStatus is the readiness of a single control plane pod.
+type ResourceStatus struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	App       string `json:"app"`
+	Phase     string `json:"phase"`
+	Ready     bool   `json:"ready"`
+	Message   string `json:"message,omitempty"`
+}
+
+// Operation is a mesh install, upgrade or uninstall that runs in the background.
+type Operation struct {
+	ID string `json:"id"`
+
+	Type OperationType `json:"type"`
+
+	MeshName string `json:"meshName"`
+
+	Namespace string `json:"namespace"`
+
+	// User is the name of the user that started the operation, empty when the request had no
+	// auth info. Operations are only shown to the user that started them.
+	User string `json:"user"`
+
+	Phase OperationPhase `json:"phase"`
+
+	StartTime metav1.Time `json:"startTime"`
+
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Resources is the readiness of the control plane pods of the mesh.
+	Resources []ResourceStatus `json:"resources"`
+
+	// Error is the reason of the failure, set when the operation failed.
+	Error string `json:"error,omitempty"`
+
+	// UninstallReport lists the objects removed by an uninstall, set when an uninstall finished.
+	UninstallReport *UninstallReport `json:"uninstallReport,omitempty"`
+
+	// UpgradeResult is the upgraded release, set when an upgrade succeeded.
+	UpgradeResult *OsmUpgradeResult `json:"upgradeResult,omitempty"`
+
+	// Log is the captured Helm debug log and the progress messages of the operation.
+	Log []string `json:"log"`
+}
+
+// OperationList contains the tracked mesh operations.
+type OperationList struct {
+	Operations []Operation `json:"operations"`
+}
+
+// operationRecorder updates a single tracked operation.
+type operationRecorder struct {
+	manager *OperationManager
+	id      string
+}
+
+// OperationManager keeps track of the mesh operations running in the background.
+type OperationManager struct {
+	mu         sync.RWMutex
+	operations map[string]*Operation
+}
+
+// NewOperationManager creates an empty operation manager.
+func NewOperationManager() *OperationManager {
+	return &OperationManager{operations: make(map[string]*Operation)}
+}
+
+// Start registers a new operation and runs it in the background. The returned operation is a
+// snapshot taken before the operation starts running.
+func (self *OperationManager) Start(operationType OperationType, meshName, namespace, user string,
+	run func(recorder *operationRecorder) error) Operation {
+	operation := &Operation{
+		ID:        string(uuid.NewUUID()),
+		Type:      operationType,
+		MeshName:  meshName,
+		Namespace: namespace,
+		User:      user,
+		Phase:     OperationPhasePending,
+		StartTime: metav1.Now(),
+		Resources: make([]ResourceStatus, 0),
+		Log:       make([]string, 0),
+	}
+
+	self.mu.Lock()
+	self.prune()
+	self.operations[operation.ID] = operation
+	snapshot := operation.copy()
+	self.mu.Unlock()
+
+	recorder := &operationRecorder{manager: self, id: operation.ID}
+	go func() {
+		if err := run(recorder); err != nil {
+			recorder.fail(err)
+			return
+		}
+		recorder.succeed()
+	}()
+
+	return snapshot
+}
+
+// Get returns a snapshot of the operation with the given id.
+func (self *OperationManager) Get(id string) (Operation, bool) {
+	self.mu.RLock()
+	defer self.mu.RUnlock()
+
+	operation, ok := self.operations[id]
+	if !ok {
+		return Operation{}, false
+	}
+
+	return operation.copy(), true
+}
+
+// List returns snapshots of the tracked operations started by a user, most recent first.
+func (self *OperationManager) List(user string) OperationList {
+	self.mu.RLock()
+	defer self.mu.RUnlock()
+
+	result := OperationList{Operations: make([]Operation, 0)}
+	for _, operation := range self.operations {
+		if operation.User == user {
+			result.Operations = append(result.Operations, operation.copy())
+		}
+	}
+
+	sort.Slice(result.Operations, func(i, j int) bool {
+		return result.Operations[j].StartTime.Before(&result.Operations[i].StartTime)
+	})
+
+	return result
+}
+
+// prune drops finished operations older than the retention period. Callers must hold the lock.
+func (self *OperationManager) prune() {
+	for id, operation := range self.operations {
+		if operation.CompletionTime != nil && time.Since(operation.CompletionTime.Time) > operationRetention {
+			delete(self.operations, id)
+		}
+	}
+}
+
+func (self *OperationManager) update(id string, fn func(operation *Operation)) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	if operation, ok := self.operations[id]; ok {
+		fn(operation)
+	}
+}
+
+func (self *Operation) copy() Operation {
+	result := *self
+	result.Resources = append([]ResourceStatus{}, self.Resources...)
+	result.Log = append([]string{}, self.Log...)
+	return result
+}
+
+// logf appends a line to the operation log. It matches the Helm debug log signature, so that it
+// can be used to capture the Helm output.
+func (self *operationRecorder) logf(format string, v ...interface{}) {
+	line := fmt.Sprintf(format, v...)
+	debug("[operation %s] %s", self.id, line)
+
+	self.manager.update(self.id, func(operation *Operation) {
+		operation.Log = append(operation.Log, line)
+		if len(operation.Log) > operationLogLimit {
+			operation.Log = operation.Log[len(operation.Log)-operationLogLimit:]
+		}
+	})
+}
+
+func (self *operationRecorder) setPhase(phase OperationPhase) {
+	self.logf("Phase changed to %s", phase)
+	self.manager.update(self.id, func(operation *Operation) {
+		operation.Phase = phase
+	})
+}
+
+func (self *operationRecorder) setResources(resources []ResourceStatus) {
+	self.manager.update(self.id, func(operation *Operation) {
+		operation.Resources = resources
+	})
+}
+
+func (self *operationRecorder) setUninstallReport(report *UninstallReport) {
+	self.manager.update(self.id, func(operation *Operation) {
+		operation.UninstallReport = report
+	})
+}
+
+func (self *operationRecorder) setUpgradeResult(result *OsmUpgradeResult) {
+	self.manager.update(self.id, func(operation *Operation) {
+		operation.UpgradeResult = result
+	})
+}
+
+func (self *operationRecorder) succeed() {
+	self.logf("Operation succeeded")
+	self.manager.update(self.id, func(operation *Operation) {
+		now := metav1.Now()
+		operation.Phase = OperationPhaseSucceeded
+		operation.CompletionTime = &now
+	})
+}
+
+func (self *operationRecorder) fail(err error) {
+	log.Printf("Mesh operation %s failed: %s", self.id, err)
+	self.logf("Operation failed: %s", err)
+	self.manager.update(self.id, func(operation *Operation) {
+		now := metav1.Now()
+		operation.Phase = OperationPhaseFailed
+		operation.Error = err.Error()
+		operation.CompletionTime = &now
+	})
+}
+
+// watchControlPlane periodically records the readiness of the control plane pods of a mesh until
+// the given context is done.
+func (self *operationRecorder) watchControlPlane(ctx context.Context, client kubernetes.Interface, namespace string) {
+	ticker := time.NewTicker(readinessPollInterval)
+	defer ticker.Stop()
+
+	for {
+		self.refreshControlPlane(client, namespace)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// refreshControlPlane records the current readiness of the control plane pods of a mesh.
+func (self *operationRecorder) refreshControlPlane(client kubernetes.Interface, namespace string) {
+	resources, err := getControlPlaneStatus(client, namespace)
+	if err != nil {
+		self.logf("Failed to get control plane pods in namespace %s: %s", namespace, err)
+		return
+	}
+
+	self.setResources(resources)
+}
+
+// getControlPlaneStatus returns the readiness of the control plane pods in the given namespace.
+func getControlPlaneStatus(client kubernetes.Interface, namespace string) ([]ResourceStatus, error) {
+	pods, err := client.CoreV1().Pods(namespace).List(context.TODO(), metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s in (%s)", constants.AppLabel, strings.Join(controlPlaneApps, ",")),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]ResourceStatus, 0, len(pods.Items))
+	for _, pod := range pods.Items {
+		resources = append(resources, toResourceStatus(pod))
+	}
+
+	sort.Slice(resources, func(i, j int) bool { return resources[i].Name < resources[j].Name })
+	return resources, nil
+}
+
+func toResourceStatus(pod v1.Pod) ResourceStatus {
+	status := ResourceStatus{
+		Kind:      "Pod",
+		Name:      pod.Name,
+		Namespace: pod.Namespace,
+		App:       pod.Labels[constants.AppLabel],
+		Phase:     string(pod.Status.Phase),
+	}
+
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == v1.PodReady {
+			status.Ready = condition.Status == v1.ConditionTrue
+			status.Message = condition.Message
+		}
+	}
+
+	for _, containerStatus := range pod.Status.ContainerStatuses {
+		if containerStatus.State.Waiting != nil && len(containerStatus.State.Waiting.Reason) > 0 {
+			status.Message = fmt.Sprintf("%s: %s", containerStatus.Name, containerStatus.State.Waiting.Reason)
+		}
+	}
+
+	return status
+}
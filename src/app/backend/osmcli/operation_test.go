@@ -0,0 +1,115 @@
+package osmcli
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// waitForOperation polls the manager until the operation with the given id is finished.
+func waitForOperation(t *testing.T, manager *OperationManager, id string) Operation {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		operation, ok := manager.Get(id)
+		if !ok {
+			t.Fatalf("operation %s not found", id)
+		}
+		if operation.CompletionTime != nil {
+			return operation
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	t.Fatalf("operation %s did not finish in time", id)
+	return Operation{}
+}
+
+func TestOperationManager(t *testing.T) {
+	manager := NewOperationManager()
+
+	succeeded := manager.Start(OperationTypeInstall, "osm", "osm-system", "alice", func(recorder *operationRecorder) error {
+		recorder.setPhase(OperationPhaseInstalling)
+		recorder.logf("installing %s", "osm")
+		return nil
+	})
+	if succeeded.Phase != OperationPhasePending {
+		t.Errorf("expected phase %s, but got %s", OperationPhasePending, succeeded.Phase)
+	}
+
+	failed := manager.Start(OperationTypeUninstall, "osm", "osm-system", "alice", func(recorder *operationRecorder) error {
+		return errors.New("boom")
+	})
+
+	result := waitForOperation(t, manager, succeeded.ID)
+	if result.Phase != OperationPhaseSucceeded || len(result.Error) != 0 {
+		t.Errorf("expected operation to succeed, but got %#v", result)
+	}
+	if len(result.Log) == 0 {
+		t.Error("expected operation log to be captured")
+	}
+
+	result = waitForOperation(t, manager, failed.ID)
+	if result.Phase != OperationPhaseFailed || result.Error != "boom" {
+		t.Errorf("expected operation to fail, but got %#v", result)
+	}
+
+	if list := manager.List("alice"); len(list.Operations) != 2 || list.Operations[0].User != "alice" {
+		t.Errorf("expected 2 operations of alice, but got %#v", list.Operations)
+	}
+
+	if list := manager.List("bob"); len(list.Operations) != 0 {
+		t.Errorf("expected no operations of bob, but got %d", len(list.Operations))
+	}
+
+	if _, ok := manager.Get("missing"); ok {
+		t.Error("expected missing operation not to be found")
+	}
+}
+
+func TestGetControlPlaneStatus(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "osm-controller-1", Namespace: "osm-system", Labels: map[string]string{"app": "osm-controller"}},
+			Status: v1.PodStatus{
+				Phase:      v1.PodRunning,
+				Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionTrue}},
+			},
+		},
+		&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "osm-injector-1", Namespace: "osm-system", Labels: map[string]string{"app": "osm-injector"}},
+			Status: v1.PodStatus{
+				Phase: v1.PodPending,
+				ContainerStatuses: []v1.ContainerStatus{{
+					Name:  "osm-injector",
+					State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "ImagePullBackOff"}},
+				}},
+			},
+		},
+		&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "bookstore-1", Namespace: "osm-system", Labels: map[string]string{"app": "bookstore"}},
+		},
+	)
+
+	resources, err := getControlPlaneStatus(client, "osm-system")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []ResourceStatus{
+		{Kind: "Pod", Name: "osm-controller-1", Namespace: "osm-system", App: "osm-controller", Phase: "Running", Ready: true},
+		{Kind: "Pod", Name: "osm-injector-1", Namespace: "osm-system", App: "osm-injector", Phase: "Pending", Message: "osm-injector: ImagePullBackOff"},
+	}
+
+	if len(resources) != len(expected) {
+		t.Fatalf("expected %#v, but got %#v", expected, resources)
+	}
+	for i := range expected {
+		if resources[i] != expected[i] {
+			t.Errorf("expected %#v, but got %#v", expected[i], resources[i])
+		}
+	}
+}
@@ -1,11 +1,19 @@
 package osmcli
 
+import (
+	"encoding/json"
+)
+
 type OsmInstallSpec struct {
 	MeshName string `json:"meshName"`
 
 	Namespace string `json:"namespace"`
 
 	EnforceSingleMesh bool `json:"enforceSingleMesh"`
+
+	// Values is a chart values document merged over the chart defaults. It can be given either as
+	// a nested object or as a string holding a YAML or JSON document.
+	Values json.RawMessage `json:"values,omitempty"`
 }
 
 func NewOsmInstallSpec() OsmInstallSpec {
@@ -16,10 +24,36 @@ func NewOsmInstallSpec() OsmInstallSpec {
 	return osmInstallSpec
 }
 
+// OsmInstallDryRun is the result of rendering an install without applying it to the cluster.
+type OsmInstallDryRun struct {
+	MeshName string `json:"meshName"`
+
+	Namespace string `json:"namespace"`
+
+	ChartName string `json:"chartName"`
+
+	ChartVersion string `json:"chartVersion"`
+
+	// Values are the effective chart values, i.e. the requested values merged over the chart defaults.
+	Values map[string]interface{} `json:"values"`
+
+	// Manifests are the rendered objects that would be created.
+	Manifests []Manifest `json:"manifests"`
+
+	// Hooks are the rendered Helm hook objects that would be run.
+	Hooks []Manifest `json:"hooks"`
+
+	Notes string `json:"notes"`
+}
+
 type OsmUninstallSpec struct {
 	MeshName string `json:"meshName"`
 
 	Namespace string `json:"namespace"`
+
+	// DeleteCustomResourceDefinitions removes the cluster wide osm and smi crds, and with them all
+	// their custom resources. They are always kept while other meshes are installed.
+	DeleteCustomResourceDefinitions bool `json:"deleteCustomResourceDefinitions"`
 }
 
 func NewOsmUninstallSpec() OsmUninstallSpec {
@@ -28,3 +62,77 @@ func NewOsmUninstallSpec() OsmUninstallSpec {
 	osmUninstallSpec.Namespace = "osm-system"
 	return osmUninstallSpec
 }
+
+// OsmUpgradeSpec describes an upgrade of an existing mesh release to the embedded chart.
+type OsmUpgradeSpec struct {
+	MeshName string `json:"meshName"`
+
+	Namespace string `json:"namespace"`
+
+	// Release is the name of the Helm release of the mesh. When empty, it is the release of the
+	// mesh chart in Namespace that was installed with MeshName.
+	Release string `json:"release,omitempty"`
+
+	// Values is a chart values document applied by the upgrade, in the same format as for install.
+	Values json.RawMessage `json:"values,omitempty"`
+
+	// ReuseValues merges Values over the values of the current release, like `helm upgrade
+	// --reuse-values`. Otherwise only Values are applied over the new chart defaults.
+	ReuseValues bool `json:"reuseValues"`
+
+	// Atomic rolls the release back to the current revision when the upgrade fails.
+	Atomic bool `json:"atomic"`
+}
+
+func NewOsmUpgradeSpec() OsmUpgradeSpec {
+	osmUpgradeSpec := OsmUpgradeSpec{}
+	osmUpgradeSpec.MeshName = "osm"
+	osmUpgradeSpec.Namespace = "osm-system"
+	osmUpgradeSpec.ReuseValues = true
+	osmUpgradeSpec.Atomic = true
+	return osmUpgradeSpec
+}
+
+// OsmUpgradePreview describes what an upgrade would change without applying it.
+type OsmUpgradePreview struct {
+	MeshName string `json:"meshName"`
+
+	Namespace string `json:"namespace"`
+
+	// CurrentRevision is the revision of the release that would be upgraded.
+	CurrentRevision int `json:"currentRevision"`
+
+	CurrentChartVersion string `json:"currentChartVersion"`
+
+	TargetChartVersion string `json:"targetChartVersion"`
+
+	CurrentAppVersion string `json:"currentAppVersion"`
+
+	TargetAppVersion string `json:"targetAppVersion"`
+
+	// Values are the effective chart values after the upgrade.
+	Values map[string]interface{} `json:"values"`
+
+	// ValuesDiff lists the effective values that change.
+	ValuesDiff []ValueDiff `json:"valuesDiff"`
+
+	// ManifestDiff lists the rendered objects that are added, removed or modified.
+	ManifestDiff []ManifestDiff `json:"manifestDiff"`
+}
+
+// OsmUpgradeResult is the outcome of an applied upgrade.
+type OsmUpgradeResult struct {
+	MeshName string `json:"meshName"`
+
+	Namespace string `json:"namespace"`
+
+	Release string `json:"release"`
+
+	Revision int `json:"revision"`
+
+	ChartVersion string `json:"chartVersion"`
+
+	Status string `json:"status"`
+
+	Description string `json:"description"`
+}
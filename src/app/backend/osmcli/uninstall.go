@@ -0,0 +1,432 @@
+package osmcli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	osmconfigclientset "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned"
+
+	helm "helm.sh/helm/v3/pkg/action"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	k8sapierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+
+	backenderrors "github.com/kubernetes/dashboard/src/app/backend/errors"
+)
+
+// meshCustomResourceDefinitions are the osm and smi-related crds that are removed on uninstall.
+var meshCustomResourceDefinitions = []string{
+	"egresses.policy.openservicemesh.io",
+	"ingressbackends.policy.openservicemesh.io",
+	"meshconfigs.config.openservicemesh.io",
+	"upstreamtrafficsettings.policy.openservicemesh.io",
+	"retries.policy.openservicemesh.io",
+	"multiclusterservices.config.openservicemesh.io",
+	"httproutegroups.specs.smi-spec.io",
+	"tcproutes.specs.smi-spec.io",
+	"trafficsplits.split.smi-spec.io",
+	"traffictargets.access.smi-spec.io",
+}
+
+// UninstallItemStatus is the state of a single object in an uninstall report.
+type UninstallItemStatus string
+
+const (
+	// UninstallItemPending marks an object that exists and would be deleted by the uninstall.
+	UninstallItemPending  UninstallItemStatus = "Pending"
+	UninstallItemNotFound UninstallItemStatus = "NotFound"
+	UninstallItemDeleted  UninstallItemStatus = "Deleted"
+	UninstallItemFailed   UninstallItemStatus = "Failed"
+	// UninstallItemSkipped marks an object that exists but is kept by the uninstall.
+	UninstallItemSkipped UninstallItemStatus = "Skipped"
+)
+
+// UninstallItem is a single object removed by an uninstall.
+type UninstallItem struct {
+	APIVersion string              `json:"apiVersion,omitempty"`
+	Kind       string              `json:"kind"`
+	Name       string              `json:"name"`
+	Namespace  string              `json:"namespace,omitempty"`
+	Status     UninstallItemStatus `json:"status"`
+	Message    string              `json:"message,omitempty"`
+}
+
+// CustomResourceDefinitionItem is a crd removed by an uninstall.
+type CustomResourceDefinitionItem struct {
+	UninstallItem `json:",inline"`
+
+	// CustomResources is the number of existing custom resources of the crd. They are lost when
+	// the crd is deleted.
+	CustomResources int `json:"customResources"`
+}
+
+// UninstallReport lists everything an uninstall removes. Before the uninstall all existing objects
+// are Pending, afterwards they are either Deleted or Failed.
+type UninstallReport struct {
+	MeshName string `json:"meshName"`
+
+	Namespace string `json:"namespace"`
+
+	// ReleaseFound is false when the mesh has no Helm release. Only the cluster wide leftovers are
+	// removed in that case.
+	ReleaseFound bool `json:"releaseFound"`
+
+	// Release is the name of the Helm release of the mesh, which may differ from the mesh name.
+	Release string `json:"release,omitempty"`
+
+	Revision int `json:"revision,omitempty"`
+
+	ChartVersion string `json:"chartVersion,omitempty"`
+
+	// ReleaseObjects are the objects of the Helm release.
+	ReleaseObjects []UninstallItem `json:"releaseObjects"`
+
+	CustomResourceDefinitions []CustomResourceDefinitionItem `json:"customResourceDefinitions"`
+
+	MutatingWebhookConfigurations []UninstallItem `json:"mutatingWebhookConfigurations"`
+
+	ValidatingWebhookConfigurations []UninstallItem `json:"validatingWebhookConfigurations"`
+
+	Secrets []UninstallItem `json:"secrets"`
+}
+
+// planUninstall collects everything the uninstall of a mesh would remove without changing the cluster.
+// The crds are skipped when other meshes remain in the cluster or their removal was not requested.
+func planUninstall(actionConfig *helm.Configuration, k8sClient kubernetes.Interface,
+	extensionsClient apiextensionsclientset.Interface, dynamicClient dynamic.Interface, spec OsmUninstallSpec,
+	otherMeshes []string) (*UninstallReport, error) {
+	report := &UninstallReport{
+		MeshName:  spec.MeshName,
+		Namespace: spec.Namespace,
+	}
+
+	caBundleSecretName := constants.DefaultCABundleSecretName
+	current, err := findMeshRelease(actionConfig, spec.Namespace, spec.MeshName)
+	if err != nil && !backenderrors.IsNotFoundError(err) {
+		return nil, err
+	}
+
+	report.ReleaseObjects = make([]UninstallItem, 0)
+	if current != nil {
+		report.ReleaseFound = true
+		report.Release = current.Name
+		report.Revision = current.Version
+		report.ChartVersion = current.Chart.Metadata.Version
+		caBundleSecretName = releaseCABundleSecretName(current)
+
+		for _, manifest := range splitManifest(current.Manifest) {
+			report.ReleaseObjects = append(report.ReleaseObjects, UninstallItem{
+				APIVersion: manifest.APIVersion,
+				Kind:       manifest.Kind,
+				Name:       manifest.Name,
+				Namespace:  manifest.Namespace,
+				Status:     UninstallItemPending,
+			})
+		}
+	}
+
+	if report.CustomResourceDefinitions, err = planCustomResourceDefinitions(extensionsClient, dynamicClient); err != nil {
+		return nil, err
+	}
+
+	skipReason := ""
+	switch {
+	case len(otherMeshes) > 0:
+		skipReason = fmt.Sprintf("Kept because other meshes are installed: %s", strings.Join(otherMeshes, ", "))
+	case !spec.DeleteCustomResourceDefinitions:
+		skipReason = "Kept because deleteCustomResourceDefinitions is not set"
+	}
+	if len(skipReason) > 0 {
+		for i := range report.CustomResourceDefinitions {
+			if crd := &report.CustomResourceDefinitions[i]; crd.Status == UninstallItemPending {
+				crd.Status = UninstallItemSkipped
+				crd.Message = skipReason
+			}
+		}
+	}
+
+	if report.MutatingWebhookConfigurations, err = planMutatingWebhookConfigurations(k8sClient, spec.MeshName); err != nil {
+		return nil, err
+	}
+
+	if report.ValidatingWebhookConfigurations, err = planValidatingWebhookConfigurations(k8sClient, spec.MeshName); err != nil {
+		return nil, err
+	}
+
+	if report.Secrets, err = planSecrets(k8sClient, spec.Namespace, caBundleSecretName); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// getOtherMeshes returns the namespace/name of the meshes in the cluster other than the one to uninstall.
+// The list config must be able to read releases in all namespaces.
+func getOtherMeshes(listConfig *helm.Configuration, k8sClient kubernetes.Interface,
+	configClient osmconfigclientset.Interface, spec OsmUninstallSpec) ([]string, error) {
+	meshes, err := listMeshes(listConfig, k8sClient, configClient)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list the meshes in the cluster")
+	}
+
+	result := make([]string, 0)
+	for _, mesh := range meshes.Meshes {
+		if mesh.Namespace == spec.Namespace && (mesh.Name == spec.MeshName || mesh.Release == spec.MeshName) {
+			continue
+		}
+		result = append(result, mesh.Namespace+"/"+mesh.Name)
+	}
+
+	return result, nil
+}
+
+// planCustomResourceDefinitions returns the osm and smi-related crds with the number of their
+// existing custom resources.
+func planCustomResourceDefinitions(extensionsClient apiextensionsclientset.Interface,
+	dynamicClient dynamic.Interface) ([]CustomResourceDefinitionItem, error) {
+	result := make([]CustomResourceDefinitionItem, 0, len(meshCustomResourceDefinitions))
+	for _, name := range meshCustomResourceDefinitions {
+		item := CustomResourceDefinitionItem{UninstallItem: UninstallItem{
+			APIVersion: apiextensionsv1.SchemeGroupVersion.String(),
+			Kind:       "CustomResourceDefinition",
+			Name:       name,
+		}}
+
+		crd, err := extensionsClient.ApiextensionsV1().CustomResourceDefinitions().Get(context.TODO(), name, metav1.GetOptions{})
+		if k8sapierrors.IsNotFound(err) {
+			item.Status = UninstallItemNotFound
+			result = append(result, item)
+			continue
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get OSM CRD %s", name)
+		}
+
+		item.Status = UninstallItemPending
+		item.CustomResources, err = countCustomResources(dynamicClient, crd)
+		if err != nil {
+			item.Message = err.Error()
+		}
+
+		result = append(result, item)
+	}
+
+	return result, nil
+}
+
+// countCustomResources returns the number of custom resources of a crd in all namespaces.
+func countCustomResources(dynamicClient dynamic.Interface, crd *apiextensionsv1.CustomResourceDefinition) (int, error) {
+	for _, version := range crd.Spec.Versions {
+		if !version.Storage {
+			continue
+		}
+
+		resource := schema.GroupVersionResource{Group: crd.Spec.Group, Version: version.Name, Resource: crd.Spec.Names.Plural}
+		list, err := dynamicClient.Resource(resource).Namespace(metav1.NamespaceAll).List(context.TODO(), metav1.ListOptions{})
+		if err != nil {
+			return 0, err
+		}
+
+		return len(list.Items), nil
+	}
+
+	return 0, nil
+}
+
+// webhookConfigurationsListOptions selects the webhook configurations of a mesh component. These
+// label selectors should always match the Helm post-delete hook at charts/osm/templates/cleanup-hook.yaml.
+func webhookConfigurationsListOptions(meshName, app string) metav1.ListOptions {
+	return metav1.ListOptions{
+		LabelSelector: labels.Set{
+			constants.OSMAppNameLabelKey:     constants.OSMAppNameLabelValue,
+			constants.OSMAppInstanceLabelKey: meshName,
+			constants.AppLabel:               app,
+		}.String(),
+	}
+}
+
+// planMutatingWebhookConfigurations returns the mutating webhook configurations of a mesh.
+func planMutatingWebhookConfigurations(k8sClient kubernetes.Interface, meshName string) ([]UninstallItem, error) {
+	mutatingWebhookConfigurations, err := k8sClient.AdmissionregistrationV1().MutatingWebhookConfigurations().List(context.TODO(),
+		webhookConfigurationsListOptions(meshName, constants.OSMInjectorName))
+	if err != nil {
+		return nil, errors.Errorf("Failed to list OSM MutatingWebhookConfigurations in the cluster: %s", err.Error())
+	}
+
+	result := make([]UninstallItem, 0, len(mutatingWebhookConfigurations.Items))
+	for _, mutatingWebhookConfiguration := range mutatingWebhookConfigurations.Items {
+		result = append(result, UninstallItem{
+			APIVersion: "admissionregistration.k8s.io/v1",
+			Kind:       "MutatingWebhookConfiguration",
+			Name:       mutatingWebhookConfiguration.Name,
+			Status:     UninstallItemPending,
+		})
+	}
+
+	return result, nil
+}
+
+// planValidatingWebhookConfigurations returns the validating webhook configurations of a mesh.
+func planValidatingWebhookConfigurations(k8sClient kubernetes.Interface, meshName string) ([]UninstallItem, error) {
+	validatingWebhookConfigurations, err := k8sClient.AdmissionregistrationV1().ValidatingWebhookConfigurations().List(context.TODO(),
+		webhookConfigurationsListOptions(meshName, constants.OSMControllerName))
+	if err != nil {
+		return nil, errors.Errorf("Failed to list OSM ValidatingWebhookConfigurations in the cluster: %s", err.Error())
+	}
+
+	result := make([]UninstallItem, 0, len(validatingWebhookConfigurations.Items))
+	for _, validatingWebhookConfiguration := range validatingWebhookConfigurations.Items {
+		result = append(result, UninstallItem{
+			APIVersion: "admissionregistration.k8s.io/v1",
+			Kind:       "ValidatingWebhookConfiguration",
+			Name:       validatingWebhookConfiguration.Name,
+			Status:     UninstallItemPending,
+		})
+	}
+
+	return result, nil
+}
+
+// planSecrets returns the osm-related secrets in the mesh namespace.
+func planSecrets(k8sClient kubernetes.Interface, namespace, caBundleSecretName string) ([]UninstallItem, error) {
+	secrets := []string{
+		caBundleSecretName,
+	}
+
+	result := make([]UninstallItem, 0, len(secrets))
+	for _, secret := range secrets {
+		item := UninstallItem{APIVersion: "v1", Kind: "Secret", Name: secret, Namespace: namespace}
+
+		_, err := k8sClient.CoreV1().Secrets(namespace).Get(context.TODO(), secret, metav1.GetOptions{})
+		switch {
+		case err == nil:
+			item.Status = UninstallItemPending
+		case k8sapierrors.IsNotFound(err):
+			item.Status = UninstallItemNotFound
+		default:
+			return nil, errors.Wrapf(err, "failed to get OSM secret %s in namespace %s", secret, namespace)
+		}
+
+		result = append(result, item)
+	}
+
+	return result, nil
+}
+
+// runUninstall removes the release of a mesh and the cluster wide resources it leaves behind. The
+// report of the uninstall is recorded on the operation.
+func runUninstall(recorder *operationRecorder, actionConfig *helm.Configuration, k8sClient kubernetes.Interface,
+	extensionsClient apiextensionsclientset.Interface, dynamicClient dynamic.Interface, spec OsmUninstallSpec,
+	otherMeshes []string) error {
+	recorder.setPhase(OperationPhaseUninstalling)
+	recorder.logf("Uninstalling mesh [%s] from namespace [%s]", spec.MeshName, spec.Namespace)
+
+	report, err := planUninstall(actionConfig, k8sClient, extensionsClient, dynamicClient, spec, otherMeshes)
+	if err != nil {
+		return err
+	}
+	defer recorder.setUninstallReport(report)
+
+	if report.ReleaseFound {
+		if _, err := helm.NewUninstall(actionConfig).Run(report.Release); err != nil {
+			markItems(report.ReleaseObjects, UninstallItemFailed, err.Error())
+			return errors.Wrap(err, "failed to uninstall mesh release")
+		}
+		markItems(report.ReleaseObjects, UninstallItemDeleted, "")
+		recorder.logf("Successfully uninstalled mesh release %s", report.Release)
+	} else {
+		recorder.logf("Ignoring - did not find release of mesh %s in namespace %s", spec.MeshName, spec.Namespace)
+	}
+
+	recorder.setPhase(OperationPhaseCleaningUp)
+
+	var failedDeletions []string
+
+	crds := make([]UninstallItem, 0, len(report.CustomResourceDefinitions))
+	for _, crd := range report.CustomResourceDefinitions {
+		crds = append(crds, crd.UninstallItem)
+	}
+	if deleteItems(recorder, crds, func(item UninstallItem) error {
+		return extensionsClient.ApiextensionsV1().CustomResourceDefinitions().Delete(context.TODO(), item.Name, metav1.DeleteOptions{})
+	}) {
+		failedDeletions = append(failedDeletions, "CustomResourceDefinitions")
+	}
+	for i := range crds {
+		report.CustomResourceDefinitions[i].UninstallItem = crds[i]
+	}
+
+	if deleteItems(recorder, report.MutatingWebhookConfigurations, func(item UninstallItem) error {
+		return k8sClient.AdmissionregistrationV1().MutatingWebhookConfigurations().Delete(context.TODO(), item.Name, metav1.DeleteOptions{})
+	}) {
+		failedDeletions = append(failedDeletions, "MutatingWebhookConfigurations")
+	}
+
+	if deleteItems(recorder, report.ValidatingWebhookConfigurations, func(item UninstallItem) error {
+		return k8sClient.AdmissionregistrationV1().ValidatingWebhookConfigurations().Delete(context.TODO(), item.Name, metav1.DeleteOptions{})
+	}) {
+		failedDeletions = append(failedDeletions, "ValidatingWebhookConfigurations")
+	}
+
+	if deleteItems(recorder, report.Secrets, func(item UninstallItem) error {
+		return k8sClient.CoreV1().Secrets(item.Namespace).Delete(context.TODO(), item.Name, metav1.DeleteOptions{})
+	}) {
+		failedDeletions = append(failedDeletions, "Secrets")
+	}
+
+	if len(failedDeletions) != 0 {
+		return errors.Errorf("Failed to completely delete the following OSM resource types: %+v", failedDeletions)
+	}
+
+	return nil
+}
+
+// deleteItems deletes the pending items of a report with the given func and updates their status.
+// It returns true when any of the deletions failed.
+func deleteItems(recorder *operationRecorder, items []UninstallItem, deleteItem func(item UninstallItem) error) bool {
+	failed := false
+	for i := range items {
+		item := &items[i]
+		if item.Status == UninstallItemSkipped {
+			recorder.logf("Skipping OSM %s %s: %s", item.Kind, item.Name, item.Message)
+			continue
+		}
+		if item.Status != UninstallItemPending {
+			recorder.logf("Ignoring - did not find OSM %s %s", item.Kind, item.Name)
+			continue
+		}
+
+		err := deleteItem(*item)
+		switch {
+		case err == nil:
+			item.Status = UninstallItemDeleted
+			recorder.logf("Successfully deleted OSM %s: %s", item.Kind, item.Name)
+		case k8sapierrors.IsNotFound(err):
+			item.Status = UninstallItemNotFound
+			recorder.logf("Ignoring - did not find OSM %s %s", item.Kind, item.Name)
+		default:
+			item.Status = UninstallItemFailed
+			item.Message = err.Error()
+			recorder.logf("Found but failed to delete OSM %s %s: %s", item.Kind, item.Name, err.Error())
+			failed = true
+		}
+	}
+
+	return failed
+}
+
+func markItems(items []UninstallItem, status UninstallItemStatus, message string) {
+	for i := range items {
+		items[i].Status = status
+		items[i].Message = message
+	}
+}
@@ -0,0 +1,249 @@
+package osmcli
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	configv1alpha2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	osmconfigfake "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned/fake"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsfake "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newTrafficSplit(namespace, name string) *unstructured.Unstructured {
+	trafficSplit := &unstructured.Unstructured{}
+	trafficSplit.SetAPIVersion("split.smi-spec.io/v1alpha4")
+	trafficSplit.SetKind("TrafficSplit")
+	trafficSplit.SetNamespace(namespace)
+	trafficSplit.SetName(name)
+	return trafficSplit
+}
+
+func newUninstallFixtures(meshName, namespace string) (*fake.Clientset, *apiextensionsfake.Clientset, *dynamicfake.FakeDynamicClient) {
+	meshLabels := map[string]string{
+		"app.kubernetes.io/name":     "openservicemesh.io",
+		"app.kubernetes.io/instance": meshName,
+	}
+	injectorLabels := map[string]string{"app": "osm-injector"}
+	controllerLabels := map[string]string{"app": "osm-controller"}
+	for key, value := range meshLabels {
+		injectorLabels[key] = value
+		controllerLabels[key] = value
+	}
+
+	k8sClient := fake.NewSimpleClientset(
+		&admissionregistrationv1.MutatingWebhookConfiguration{ObjectMeta: metav1.ObjectMeta{
+			Name:   "osm-webhook-" + meshName,
+			Labels: injectorLabels,
+		}},
+		&admissionregistrationv1.MutatingWebhookConfiguration{ObjectMeta: metav1.ObjectMeta{
+			Name:   "osm-webhook-other",
+			Labels: map[string]string{"app.kubernetes.io/name": "openservicemesh.io", "app.kubernetes.io/instance": "other", "app": "osm-injector"},
+		}},
+		&admissionregistrationv1.ValidatingWebhookConfiguration{ObjectMeta: metav1.ObjectMeta{
+			Name:   "osm-validator-mesh-" + meshName,
+			Labels: controllerLabels,
+		}},
+		&v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "osm-ca-bundle", Namespace: namespace}},
+	)
+
+	extensionsClient := apiextensionsfake.NewSimpleClientset(
+		&apiextensionsv1.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{Name: "trafficsplits.split.smi-spec.io"},
+			Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+				Group:    "split.smi-spec.io",
+				Names:    apiextensionsv1.CustomResourceDefinitionNames{Plural: "trafficsplits"},
+				Versions: []apiextensionsv1.CustomResourceDefinitionVersion{{Name: "v1alpha4", Storage: true}},
+			},
+		},
+	)
+
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			{Group: "split.smi-spec.io", Version: "v1alpha4", Resource: "trafficsplits"}: "TrafficSplitList",
+		},
+		newTrafficSplit("bookstore", "bookstore-split"),
+		newTrafficSplit("bookbuyer", "bookbuyer-split"),
+	)
+
+	return k8sClient, extensionsClient, dynamicClient
+}
+
+func TestPlanUninstall(t *testing.T) {
+	installSpec := NewOsmInstallSpec()
+	actionConfig := newFakeActionConfig(t, installSpec)
+	k8sClient, extensionsClient, dynamicClient := newUninstallFixtures(installSpec.MeshName, installSpec.Namespace)
+
+	spec := NewOsmUninstallSpec()
+	spec.DeleteCustomResourceDefinitions = true
+	report, err := planUninstall(actionConfig, k8sClient, extensionsClient, dynamicClient, spec, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if !report.ReleaseFound || report.Revision != 1 || len(report.ReleaseObjects) == 0 {
+		t.Errorf("expected release objects of revision 1, but got %#v", report)
+	}
+
+	for _, crd := range report.CustomResourceDefinitions {
+		switch crd.Name {
+		case "trafficsplits.split.smi-spec.io":
+			if crd.Status != UninstallItemPending || crd.CustomResources != 2 {
+				t.Errorf("expected pending crd with 2 custom resources, but got %#v", crd)
+			}
+		default:
+			if crd.Status != UninstallItemNotFound {
+				t.Errorf("expected crd %s not to be found, but got %s", crd.Name, crd.Status)
+			}
+		}
+	}
+
+	if len(report.MutatingWebhookConfigurations) != 1 || report.MutatingWebhookConfigurations[0].Name != "osm-webhook-osm" {
+		t.Errorf("expected only the mutating webhook of the mesh, but got %#v", report.MutatingWebhookConfigurations)
+	}
+
+	if len(report.ValidatingWebhookConfigurations) != 1 {
+		t.Errorf("expected the validating webhook of the mesh, but got %#v", report.ValidatingWebhookConfigurations)
+	}
+
+	if len(report.Secrets) != 1 || report.Secrets[0].Status != UninstallItemPending {
+		t.Errorf("expected pending CA bundle secret, but got %#v", report.Secrets)
+	}
+
+	if _, err := actionConfig.Releases.Last(installSpec.MeshName); err != nil {
+		t.Errorf("expected preview not to remove the release: %s", err)
+	}
+}
+
+func TestRunUninstall(t *testing.T) {
+	installSpec := NewOsmInstallSpec()
+	actionConfig := newFakeActionConfig(t, installSpec)
+	k8sClient, extensionsClient, dynamicClient := newUninstallFixtures(installSpec.MeshName, installSpec.Namespace)
+
+	manager := NewOperationManager()
+	operation := manager.Start(OperationTypeUninstall, installSpec.MeshName, installSpec.Namespace, "",
+		func(recorder *operationRecorder) error {
+			return runUninstall(recorder, actionConfig, k8sClient, extensionsClient, dynamicClient, NewOsmUninstallSpec(), nil)
+		})
+
+	result := waitForOperation(t, manager, operation.ID)
+	if result.Phase != OperationPhaseSucceeded {
+		t.Fatalf("expected uninstall to succeed, but got %s: %s", result.Phase, result.Error)
+	}
+
+	report := result.UninstallReport
+	if report == nil {
+		t.Fatal("expected uninstall report")
+	}
+
+	for _, item := range report.ReleaseObjects {
+		if item.Status != UninstallItemDeleted {
+			t.Errorf("expected release object %s to be deleted, but got %s", item.Name, item.Status)
+		}
+	}
+
+	if report.Secrets[0].Status != UninstallItemDeleted {
+		t.Errorf("expected CA bundle secret to be deleted, but got %s", report.Secrets[0].Status)
+	}
+
+	webhooks, _ := k8sClient.AdmissionregistrationV1().MutatingWebhookConfigurations().List(context.TODO(), metav1.ListOptions{})
+	if len(webhooks.Items) != 1 || webhooks.Items[0].Name != "osm-webhook-other" {
+		t.Errorf("expected only the webhook of the other mesh to be kept, but got %#v", webhooks.Items)
+	}
+
+	if _, err := extensionsClient.ApiextensionsV1().CustomResourceDefinitions().Get(context.TODO(),
+		"trafficsplits.split.smi-spec.io", metav1.GetOptions{}); err != nil {
+		t.Errorf("expected crds to be kept when their removal was not requested, but got %s", err)
+	}
+}
+
+func TestRunUninstallReleaseNamedDifferently(t *testing.T) {
+	installSpec := NewOsmInstallSpec()
+	actionConfig := newFakeActionConfigWithRelease(t, installSpec, "osm-edge")
+	k8sClient, extensionsClient, dynamicClient := newUninstallFixtures(installSpec.MeshName, installSpec.Namespace)
+
+	manager := NewOperationManager()
+	operation := manager.Start(OperationTypeUninstall, installSpec.MeshName, installSpec.Namespace, "",
+		func(recorder *operationRecorder) error {
+			return runUninstall(recorder, actionConfig, k8sClient, extensionsClient, dynamicClient, NewOsmUninstallSpec(), nil)
+		})
+
+	result := waitForOperation(t, manager, operation.ID)
+	if result.Phase != OperationPhaseSucceeded {
+		t.Fatalf("expected uninstall to succeed, but got %s: %s", result.Phase, result.Error)
+	}
+
+	report := result.UninstallReport
+	if report == nil || !report.ReleaseFound || report.Release != "osm-edge" || len(report.ReleaseObjects) == 0 {
+		t.Fatalf("expected release osm-edge of mesh osm to be found, but got %#v", report)
+	}
+
+	if history, _ := actionConfig.Releases.History("osm-edge"); len(history) != 0 {
+		t.Errorf("expected release osm-edge to be uninstalled, but got %d revisions", len(history))
+	}
+}
+
+func TestPlanUninstallSkipsCustomResourceDefinitions(t *testing.T) {
+	installSpec := NewOsmInstallSpec()
+	actionConfig := newFakeActionConfig(t, installSpec)
+	k8sClient, extensionsClient, dynamicClient := newUninstallFixtures(installSpec.MeshName, installSpec.Namespace)
+
+	cases := []struct {
+		deleteCustomResourceDefinitions bool
+		otherMeshes                     []string
+		expectedMessage                 string
+	}{
+		{false, nil, "Kept because deleteCustomResourceDefinitions is not set"},
+		{true, []string{"other-system/other"}, "Kept because other meshes are installed: other-system/other"},
+	}
+
+	for _, c := range cases {
+		spec := NewOsmUninstallSpec()
+		spec.DeleteCustomResourceDefinitions = c.deleteCustomResourceDefinitions
+		report, err := planUninstall(actionConfig, k8sClient, extensionsClient, dynamicClient, spec, c.otherMeshes)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		for _, crd := range report.CustomResourceDefinitions {
+			if crd.Name == "trafficsplits.split.smi-spec.io" &&
+				(crd.Status != UninstallItemSkipped || crd.Message != c.expectedMessage || crd.CustomResources != 2) {
+				t.Errorf("expected skipped crd with message %q, but got %#v", c.expectedMessage, crd)
+			}
+		}
+	}
+}
+
+func TestGetOtherMeshes(t *testing.T) {
+	installSpec := NewOsmInstallSpec()
+	actionConfig := newFakeActionConfig(t, installSpec)
+
+	k8sClient := fake.NewSimpleClientset(&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+		Name:      "osm-controller",
+		Namespace: "other-system",
+		Labels:    map[string]string{"app": "osm-controller", "meshName": "other"},
+	}})
+	configClient := osmconfigfake.NewSimpleClientset(
+		&configv1alpha2.MeshConfig{ObjectMeta: metav1.ObjectMeta{Name: "osm-mesh-config", Namespace: installSpec.Namespace}},
+		&configv1alpha2.MeshConfig{ObjectMeta: metav1.ObjectMeta{Name: "osm-mesh-config", Namespace: "other-system"}},
+	)
+
+	otherMeshes, err := getOtherMeshes(actionConfig, k8sClient, configClient, NewOsmUninstallSpec())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !reflect.DeepEqual(otherMeshes, []string{"other-system/other"}) {
+		t.Errorf("expected only the other mesh, but got %v", otherMeshes)
+	}
+}
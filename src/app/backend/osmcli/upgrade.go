@@ -0,0 +1,210 @@
+package osmcli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+
+	helm "helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/storage/driver"
+	"helm.sh/helm/v3/pkg/strvals"
+	"k8s.io/client-go/kubernetes"
+
+	backenderrors "github.com/kubernetes/dashboard/src/app/backend/errors"
+)
+
+// resolveUpgradeValues builds the values passed to Helm for an upgrade. When the values of the
+// current release are not reused, the dashboard defaults are applied the same way as on install,
+// and the single mesh enforcement the mesh was installed with is kept unless the values set it.
+// The mesh name always follows the release name.
+func resolveUpgradeValues(spec OsmUpgradeSpec, current *release.Release) (map[string]interface{}, error) {
+	finalValues, err := parseValues(spec.Values)
+	if err != nil {
+		return nil, err
+	}
+
+	if !spec.ReuseValues {
+		if _, err := chartutil.Values(finalValues).PathValue("osm.enforceSingleMesh"); err != nil {
+			if enforceSingleMesh, err := chartutil.Values(current.Config).PathValue("osm.enforceSingleMesh"); err == nil {
+				if err := strvals.ParseInto(fmt.Sprintf("osm.enforceSingleMesh=%v", enforceSingleMesh), finalValues); err != nil {
+					return nil, backenderrors.NewBadRequest(err.Error())
+				}
+			}
+		}
+		finalValues = chartutil.CoalesceTables(finalValues, copyValues(defaultInstallValues))
+	}
+
+	if err := strvals.ParseInto(fmt.Sprintf("osm.meshName=%s", spec.MeshName), finalValues); err != nil {
+		return nil, backenderrors.NewBadRequest(err.Error())
+	}
+
+	return finalValues, nil
+}
+
+func newUpgradeClient(actionConfig *helm.Configuration, spec OsmUpgradeSpec) *helm.Upgrade {
+	upgradeClient := helm.NewUpgrade(actionConfig)
+	upgradeClient.Namespace = spec.Namespace
+	upgradeClient.Wait = true
+	upgradeClient.Timeout = 5 * time.Minute
+	upgradeClient.Atomic = spec.Atomic
+	upgradeClient.ReuseValues = spec.ReuseValues
+	upgradeClient.ResetValues = !spec.ReuseValues
+	return upgradeClient
+}
+
+// getRelease returns the latest revision of the release with the given name.
+func getRelease(actionConfig *helm.Configuration, name string) (*release.Release, error) {
+	current, err := helm.NewGet(actionConfig).Run(name)
+	if errors.Is(err, driver.ErrReleaseNotFound) {
+		return nil, backenderrors.NewNotFound(fmt.Sprintf("mesh release %s not found", name))
+	}
+
+	return current, err
+}
+
+// findMeshRelease returns the release of the mesh chart in a namespace that was installed with the
+// given mesh name. Releases may be named differently than their mesh.
+func findMeshRelease(actionConfig *helm.Configuration, namespace, meshName string) (*release.Release, error) {
+	listClient := helm.NewList(actionConfig)
+	listClient.StateMask = helm.ListDeployed | helm.ListFailed
+
+	releases, err := listClient.Run()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rel := range releases {
+		if rel.Namespace != namespace || rel.Chart == nil || rel.Chart.Metadata == nil ||
+			!meshChartNames[rel.Chart.Metadata.Name] {
+			continue
+		}
+		if releaseMeshName(rel) == meshName {
+			return rel, nil
+		}
+	}
+
+	return nil, backenderrors.NewNotFound(fmt.Sprintf("no release of mesh %s found in namespace %s", meshName, namespace))
+}
+
+// getUpgradeRelease returns the release an upgrade applies to, looked up by mesh name unless the
+// spec names the release.
+func getUpgradeRelease(actionConfig *helm.Configuration, spec OsmUpgradeSpec) (*release.Release, error) {
+	if len(spec.Release) > 0 {
+		return getRelease(actionConfig, spec.Release)
+	}
+
+	return findMeshRelease(actionConfig, spec.Namespace, spec.MeshName)
+}
+
+// previewUpgrade renders the upgrade of a mesh to the embedded chart without applying it and
+// compares the result to the current release.
+func previewUpgrade(actionConfig *helm.Configuration, spec OsmUpgradeSpec) (*OsmUpgradePreview, error) {
+	current, err := getUpgradeRelease(actionConfig, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	chartRequested, err := loadChart()
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := resolveUpgradeValues(spec, current)
+	if err != nil {
+		return nil, err
+	}
+
+	upgradeClient := newUpgradeClient(actionConfig, spec)
+	upgradeClient.DryRun = true
+
+	target, err := upgradeClient.Run(current.Name, chartRequested, values)
+	if err != nil {
+		return nil, err
+	}
+
+	currentValues, err := mergeChartValues(current.Chart, current.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	targetValues, err := mergeChartValues(target.Chart, target.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	manifestDiff, err := diffManifests(splitManifest(current.Manifest), splitManifest(target.Manifest))
+	if err != nil {
+		return nil, err
+	}
+
+	return &OsmUpgradePreview{
+		MeshName:            spec.MeshName,
+		Namespace:           spec.Namespace,
+		CurrentRevision:     current.Version,
+		CurrentChartVersion: current.Chart.Metadata.Version,
+		TargetChartVersion:  target.Chart.Metadata.Version,
+		CurrentAppVersion:   current.Chart.Metadata.AppVersion,
+		TargetAppVersion:    target.Chart.Metadata.AppVersion,
+		Values:              targetValues,
+		ValuesDiff:          diffValues(currentValues, targetValues),
+		ManifestDiff:        manifestDiff,
+	}, nil
+}
+
+// runUpgrade upgrades the release of a mesh to the embedded chart and waits until the control plane
+// is ready. The readiness of the control plane pods is recorded while Helm is waiting.
+func runUpgrade(recorder *operationRecorder, actionConfig *helm.Configuration, k8sClient kubernetes.Interface,
+	spec OsmUpgradeSpec) error {
+	current, err := getUpgradeRelease(actionConfig, spec)
+	if err != nil {
+		return err
+	}
+
+	chartRequested, err := loadChart()
+	if err != nil {
+		return err
+	}
+
+	values, err := resolveUpgradeValues(spec, current)
+	if err != nil {
+		return err
+	}
+
+	recorder.setPhase(OperationPhaseUpgrading)
+	recorder.logf("Upgrading release [%s] of mesh [%s] in namespace [%s] from revision %d", current.Name,
+		spec.MeshName, spec.Namespace, current.Version)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	watched := make(chan struct{})
+	go func() {
+		recorder.watchControlPlane(ctx, k8sClient, spec.Namespace)
+		close(watched)
+	}()
+
+	upgraded, err := newUpgradeClient(actionConfig, spec).Run(current.Name, chartRequested, values)
+
+	cancel()
+	<-watched
+	recorder.refreshControlPlane(k8sClient, spec.Namespace)
+
+	if err != nil {
+		return errors.Wrap(err, "failed to upgrade mesh release")
+	}
+
+	recorder.setUpgradeResult(&OsmUpgradeResult{
+		MeshName:     spec.MeshName,
+		Namespace:    spec.Namespace,
+		Release:      upgraded.Name,
+		Revision:     upgraded.Version,
+		ChartVersion: upgraded.Chart.Metadata.Version,
+		Status:       upgraded.Info.Status.String(),
+		Description:  upgraded.Info.Description,
+	})
+	recorder.logf("Release [%s] upgraded to revision %d with chart version %s", upgraded.Name, upgraded.Version,
+		upgraded.Chart.Metadata.Version)
+	return nil
+}
@@ -0,0 +1,219 @@
+package osmcli
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	helm "helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chartutil"
+	kubefake "helm.sh/helm/v3/pkg/kube/fake"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/storage"
+	"helm.sh/helm/v3/pkg/storage/driver"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// newFakeActionConfig returns a Helm configuration backed by in-memory release storage that holds
+// a deployed release of the embedded chart.
+func newFakeActionConfig(t *testing.T, spec OsmInstallSpec) *helm.Configuration {
+	return newFakeActionConfigWithRelease(t, spec, spec.MeshName)
+}
+
+// newFakeActionConfigWithRelease is like newFakeActionConfig, with a release name that may differ
+// from the mesh name.
+func newFakeActionConfigWithRelease(t *testing.T, spec OsmInstallSpec, releaseName string) *helm.Configuration {
+	actionConfig := &helm.Configuration{
+		Releases:     storage.Init(driver.NewMemory()),
+		KubeClient:   &kubefake.PrintingKubeClient{Out: ioutil.Discard},
+		Capabilities: chartutil.DefaultCapabilities,
+		Log:          func(format string, v ...interface{}) {},
+	}
+
+	chartRequested, err := loadChart()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	values, err := resolveInstallValues(spec)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	installClient := helm.NewInstall(actionConfig)
+	installClient.ReleaseName = releaseName
+	installClient.Namespace = spec.Namespace
+	if _, err := installClient.Run(chartRequested, values); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	return actionConfig
+}
+
+func TestDiffValues(t *testing.T) {
+	oldValues := map[string]interface{}{
+		"osm": map[string]interface{}{
+			"meshName":      "osm",
+			"sidecarImage":  "pipy:0.50",
+			"deployJaeger":  true,
+			"featureFlags":  map[string]interface{}{"enableEgressPolicy": true},
+			"imagePullList": []interface{}{"a"},
+		},
+	}
+	newValues := map[string]interface{}{
+		"osm": map[string]interface{}{
+			"meshName":      "osm",
+			"sidecarImage":  "pipy:0.70",
+			"featureFlags":  map[string]interface{}{"enableEgressPolicy": true, "enableRetryPolicy": true},
+			"imagePullList": []interface{}{"a"},
+		},
+	}
+
+	expected := []ValueDiff{
+		{Path: "osm.deployJaeger", Change: ChangeTypeRemoved, OldValue: true},
+		{Path: "osm.featureFlags.enableRetryPolicy", Change: ChangeTypeAdded, NewValue: true},
+		{Path: "osm.sidecarImage", Change: ChangeTypeModified, OldValue: "pipy:0.50", NewValue: "pipy:0.70"},
+	}
+
+	actual := diffValues(oldValues, newValues)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %#v, but got %#v", expected, actual)
+	}
+}
+
+func TestResolveUpgradeValuesKeepsSingleMesh(t *testing.T) {
+	installSpec := NewOsmInstallSpec()
+	installSpec.EnforceSingleMesh = true
+	current, err := getRelease(newFakeActionConfig(t, installSpec), installSpec.MeshName)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	cases := []struct {
+		values   string
+		expected bool
+	}{
+		{"", true},
+		{`{"osm": {"osmController": {"replicaCount": 2}}}`, true},
+		{`{"osm": {"enforceSingleMesh": false}}`, false},
+	}
+
+	for _, c := range cases {
+		spec := NewOsmUpgradeSpec()
+		spec.ReuseValues = false
+		spec.Values = json.RawMessage(c.values)
+
+		values, err := resolveUpgradeValues(spec, current)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		enforceSingleMesh, err := chartutil.Values(values).PathValue("osm.enforceSingleMesh")
+		if err != nil || enforceSingleMesh != c.expected {
+			t.Errorf("expected enforceSingleMesh %t for values %q, but got %v", c.expected, c.values, enforceSingleMesh)
+		}
+	}
+}
+
+func TestPreviewUpgrade(t *testing.T) {
+	installSpec := NewOsmInstallSpec()
+	actionConfig := newFakeActionConfig(t, installSpec)
+
+	spec := NewOsmUpgradeSpec()
+	spec.Values = json.RawMessage(`{"osm": {"osmController": {"replicaCount": 3}}}`)
+
+	preview, err := previewUpgrade(actionConfig, spec)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if preview.CurrentRevision != 1 {
+		t.Errorf("expected current revision 1, but got %d", preview.CurrentRevision)
+	}
+
+	foundReplicas := false
+	for _, diff := range preview.ValuesDiff {
+		if diff.Path == "osm.osmController.replicaCount" {
+			foundReplicas = true
+		}
+		if diff.Path == "osm.deployPrometheus" {
+			t.Errorf("expected reused values to be kept, but got %#v", diff)
+		}
+	}
+	if !foundReplicas {
+		t.Errorf("expected replica count change in values diff, but got %#v", preview.ValuesDiff)
+	}
+
+	foundController := false
+	for _, diff := range preview.ManifestDiff {
+		if diff.Kind == "Deployment" && diff.Name == "osm-controller" {
+			foundController = diff.Change == ChangeTypeModified && len(diff.Diff) > 0
+		}
+	}
+	if !foundController {
+		t.Errorf("expected osm-controller deployment to be modified, but got %#v", preview.ManifestDiff)
+	}
+
+	history, _ := actionConfig.Releases.History(installSpec.MeshName)
+	if len(history) != 1 || history[0].Info.Status != release.StatusDeployed {
+		t.Errorf("expected preview not to create a release revision")
+	}
+}
+
+func TestPreviewUpgradeNotFound(t *testing.T) {
+	actionConfig := newFakeActionConfig(t, NewOsmInstallSpec())
+
+	spec := NewOsmUpgradeSpec()
+	spec.MeshName = "missing"
+
+	if _, err := previewUpgrade(actionConfig, spec); err == nil {
+		t.Error("expected an error for a missing release")
+	}
+}
+
+func TestRunUpgrade(t *testing.T) {
+	installSpec := NewOsmInstallSpec()
+	actionConfig := newFakeActionConfigWithRelease(t, installSpec, "osm-edge")
+
+	spec := NewOsmUpgradeSpec()
+	manager := NewOperationManager()
+	operation := manager.Start(OperationTypeUpgrade, spec.MeshName, spec.Namespace, "", func(recorder *operationRecorder) error {
+		return runUpgrade(recorder, actionConfig, fake.NewSimpleClientset(), spec)
+	})
+
+	result := waitForOperation(t, manager, operation.ID)
+	if result.Phase != OperationPhaseSucceeded {
+		t.Fatalf("expected upgrade to succeed, but got %#v", result)
+	}
+
+	upgrade := result.UpgradeResult
+	if upgrade == nil || upgrade.Release != "osm-edge" || upgrade.Revision != 2 || upgrade.MeshName != spec.MeshName {
+		t.Errorf("expected revision 2 of release osm-edge, but got %#v", upgrade)
+	}
+
+	if history, _ := actionConfig.Releases.History(spec.MeshName); len(history) != 0 {
+		t.Errorf("expected no release named after the mesh, but got %d revisions", len(history))
+	}
+}
+
+func TestGetUpgradeRelease(t *testing.T) {
+	actionConfig := newFakeActionConfigWithRelease(t, NewOsmInstallSpec(), "osm-edge")
+
+	spec := NewOsmUpgradeSpec()
+	if current, err := getUpgradeRelease(actionConfig, spec); err != nil || current.Name != "osm-edge" {
+		t.Errorf("expected release osm-edge of mesh osm, but got %v, %v", current, err)
+	}
+
+	spec.Release = "osm-edge"
+	spec.MeshName = "ignored"
+	if current, err := getUpgradeRelease(actionConfig, spec); err != nil || current.Name != "osm-edge" {
+		t.Errorf("expected named release osm-edge, but got %v, %v", current, err)
+	}
+
+	spec = NewOsmUpgradeSpec()
+	spec.Namespace = "other"
+	if _, err := getUpgradeRelease(actionConfig, spec); err == nil {
+		t.Error("expected no release of the mesh in another namespace")
+	}
+}
@@ -28,7 +28,10 @@ import (
 	"github.com/kubernetes/dashboard/src/app/backend/plugin/client/clientset/versioned"
 	fakePluginClientset "github.com/kubernetes/dashboard/src/app/backend/plugin/client/clientset/versioned/fake"
 	osmconfigclientset "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned"
+	osmpolicyclientset "github.com/openservicemesh/osm/pkg/gen/client/policy/clientset/versioned"
 	smiaccessclientset "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/access/clientset/versioned"
+	smispecsclientset "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/specs/clientset/versioned"
+	smisplitclientset "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/split/clientset/versioned"
 	v1 "k8s.io/api/authorization/v1"
 	coreV1 "k8s.io/api/core/v1"
 	"k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
@@ -94,6 +97,14 @@ func (cm *fakeClientManager) PluginClient(req *restful.Request) (versioned.Inter
 	return cm.pluginClient, nil
 }
 
+func (cm *fakeClientManager) SmiSpecsClient(req *restful.Request) (smispecsclientset.Interface, error) {
+	panic("implement me")
+}
+
+func (cm *fakeClientManager) SmiSplitClient(req *restful.Request) (smisplitclientset.Interface, error) {
+	panic("implement me")
+}
+
 func (cm *fakeClientManager) SmiAccessClient(req *restful.Request) (smiaccessclientset.Interface, error) {
 	panic("implement me")
 }
@@ -102,6 +113,10 @@ func (cm *fakeClientManager) OsmConfigClient(req *restful.Request) (osmconfigcli
 	panic("implement me")
 }
 
+func (cm *fakeClientManager) OsmPolicyClient(req *restful.Request) (osmpolicyclientset.Interface, error) {
+	panic("implement me")
+}
+
 func (cm *fakeClientManager) InsecureAPIExtensionsClient() clientset.Interface {
 	panic("implement me")
 }
@@ -113,6 +128,14 @@ func (cm *fakeClientManager) InsecurePluginClient() versioned.Interface {
 	return cm.pluginClient
 }
 
+func (cm *fakeClientManager) InsecureSmiSpecsClient() smispecsclientset.Interface {
+	panic("implement me")
+}
+
+func (cm *fakeClientManager) InsecureSmiSplitClient() smisplitclientset.Interface {
+	panic("implement me")
+}
+
 func (cm *fakeClientManager) InsecureSmiAccessClient() smiaccessclientset.Interface {
 	panic("implement me")
 }
@@ -121,6 +144,10 @@ func (cm *fakeClientManager) InsecureOsmConfigClient() osmconfigclientset.Interf
 	panic("implement me")
 }
 
+func (cm *fakeClientManager) InsecureOsmPolicyClient() osmpolicyclientset.Interface {
+	panic("implement me")
+}
+
 func (cm *fakeClientManager) CanI(req *restful.Request, ssar *v1.SelfSubjectAccessReview) bool {
 	panic("implement me")
 }
@@ -141,6 +168,10 @@ func (cm *fakeClientManager) HasAccess(authInfo api.AuthInfo) (string, error) {
 	panic("implement me")
 }
 
+func (cm *fakeClientManager) Username(req *restful.Request) (string, error) {
+	panic("implement me")
+}
+
 func (cm *fakeClientManager) VerberClient(req *restful.Request, config *rest.Config) (clientapi.ResourceVerber, error) {
 	panic("implement me")
 }
@@ -0,0 +1,25 @@
+package common
+
+// FlattenValues returns the leaves of a nested document, e.g. decoded JSON or chart values, keyed
+// by their dotted path. Lists and empty objects are treated as leaves.
+func FlattenValues(document map[string]interface{}) map[string]interface{} {
+	leaves := make(map[string]interface{})
+	flattenValues("", document, leaves)
+	return leaves
+}
+
+func flattenValues(prefix string, document map[string]interface{}, leaves map[string]interface{}) {
+	for key, value := range document {
+		path := key
+		if len(prefix) > 0 {
+			path = prefix + "." + key
+		}
+
+		if nested, ok := value.(map[string]interface{}); ok && len(nested) > 0 {
+			flattenValues(path, nested, leaves)
+			continue
+		}
+
+		leaves[path] = value
+	}
+}
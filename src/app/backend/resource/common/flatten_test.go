@@ -0,0 +1,30 @@
+package common
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFlattenValues(t *testing.T) {
+	document := map[string]interface{}{
+		"osm": map[string]interface{}{
+			"meshName":      "osm",
+			"featureFlags":  map[string]interface{}{"enableEgressPolicy": true},
+			"imagePullList": []interface{}{"a"},
+			"annotations":   map[string]interface{}{},
+		},
+		"replicas": 2,
+	}
+
+	expected := map[string]interface{}{
+		"osm.meshName":                        "osm",
+		"osm.featureFlags.enableEgressPolicy": true,
+		"osm.imagePullList":                   []interface{}{"a"},
+		"osm.annotations":                     map[string]interface{}{},
+		"replicas":                            2,
+	}
+
+	if actual := FlattenValues(document); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %#v, but got %#v", expected, actual)
+	}
+}
@@ -28,6 +28,7 @@ import (
 	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 
 	osmconfigv1alph2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	osmpolicyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
 	smiaccessv1alpha3 "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/access/v1alpha3"
 	smispecsv1alpha4 "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/specs/v1alpha4"
 	smisplitv1alpha2 "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/split/v1alpha2"
@@ -37,6 +38,7 @@ import (
 	client "k8s.io/client-go/kubernetes"
 
 	osmconfigclientset "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned"
+	osmpolicyclientset "github.com/openservicemesh/osm/pkg/gen/client/policy/clientset/versioned"
 	smiaccessclientset "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/access/clientset/versioned"
 	smispecsclientset "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/specs/clientset/versioned"
 	smisplitclientset "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/split/clientset/versioned"
@@ -148,6 +150,27 @@ type ResourceChannels struct {
 
 	// List and error channels to MeshConfigs.
 	MeshConfigList MeshConfigListChannel
+
+	// List and error channels to TCPRoutes.
+	TCPRouteList TCPRouteListChannel
+
+	// List and error channels to ServiceAccounts.
+	ServiceAccountList ServiceAccountListChannel
+
+	// List and error channels to MultiClusterServices.
+	MultiClusterServiceList MultiClusterServiceListChannel
+
+	// List and error channels to Egresses.
+	EgressList EgressListChannel
+
+	// List and error channels to IngressBackends.
+	IngressBackendList IngressBackendListChannel
+
+	// List and error channels to UpstreamTrafficSettings.
+	UpstreamTrafficSettingList UpstreamTrafficSettingListChannel
+
+	// List and error channels to Retries.
+	RetryList RetryListChannel
 }
 
 // ServiceListChannel is a list and error channels to Services.
@@ -215,6 +238,38 @@ func GetHttpRouteGroupListChannel(smiSpecsClient smispecsclientset.Interface, ns
 	return channel
 }
 
+// TCPRouteListChannel is a list and error channels to TCPRoutes.
+type TCPRouteListChannel struct {
+	List  chan *smispecsv1alpha4.TCPRouteList
+	Error chan error
+}
+
+// GetTCPRouteListChannel returns a pair of channels to a TCPRoute list and errors that both
+// must be read numReads times.
+func GetTCPRouteListChannel(smiSpecsClient smispecsclientset.Interface, nsQuery *NamespaceQuery,
+	numReads int) TCPRouteListChannel {
+	channel := TCPRouteListChannel{
+		List:  make(chan *smispecsv1alpha4.TCPRouteList, numReads),
+		Error: make(chan error, numReads),
+	}
+	go func() {
+		list, err := smiSpecsClient.SpecsV1alpha4().TCPRoutes(nsQuery.ToRequestParam()).List(context.TODO(), api.ListEverything)
+		var filteredItems []smispecsv1alpha4.TCPRoute
+		for _, item := range list.Items {
+			if nsQuery.Matches(item.ObjectMeta.Namespace) {
+				filteredItems = append(filteredItems, item)
+			}
+		}
+		list.Items = filteredItems
+		for i := 0; i < numReads; i++ {
+			channel.List <- list
+			channel.Error <- err
+		}
+	}()
+
+	return channel
+}
+
 // TrafficSplitListChannel is a list and error channels to TrafficSplit.
 type TrafficSplitListChannel struct {
 	List  chan *smisplitv1alpha2.TrafficSplitList
@@ -320,6 +375,209 @@ func GetMeshConfigListChannel(osmConfigClient osmconfigclientset.Interface, nsQu
 	return channel
 }
 
+// MultiClusterServiceListChannel is a list and error channels to MultiClusterServices.
+type MultiClusterServiceListChannel struct {
+	List  chan *osmconfigv1alph2.MultiClusterServiceList
+	Error chan error
+}
+
+// GetMultiClusterServiceListChannel returns a pair of channels to a MultiClusterService list and
+// errors that both must be read numReads times.
+func GetMultiClusterServiceListChannel(osmConfigClient osmconfigclientset.Interface, nsQuery *NamespaceQuery,
+	numReads int) MultiClusterServiceListChannel {
+
+	channel := MultiClusterServiceListChannel{
+		List:  make(chan *osmconfigv1alph2.MultiClusterServiceList, numReads),
+		Error: make(chan error, numReads),
+	}
+
+	go func() {
+		list, err := osmConfigClient.ConfigV1alpha2().MultiClusterServices(nsQuery.ToRequestParam()).List(context.TODO(), api.ListEverything)
+		var filteredItems []osmconfigv1alph2.MultiClusterService
+		for _, item := range list.Items {
+			if nsQuery.Matches(item.ObjectMeta.Namespace) {
+				filteredItems = append(filteredItems, item)
+			}
+		}
+		list.Items = filteredItems
+		for i := 0; i < numReads; i++ {
+			channel.List <- list
+			channel.Error <- err
+		}
+	}()
+
+	return channel
+}
+
+// EgressListChannel is a list and error channels to Egresses.
+type EgressListChannel struct {
+	List  chan *osmpolicyv1alpha1.EgressList
+	Error chan error
+}
+
+// GetEgressListChannel returns a pair of channels to an Egress list and errors that both must be
+// read numReads times.
+func GetEgressListChannel(osmPolicyClient osmpolicyclientset.Interface, nsQuery *NamespaceQuery,
+	numReads int) EgressListChannel {
+
+	channel := EgressListChannel{
+		List:  make(chan *osmpolicyv1alpha1.EgressList, numReads),
+		Error: make(chan error, numReads),
+	}
+
+	go func() {
+		list, err := osmPolicyClient.PolicyV1alpha1().Egresses(nsQuery.ToRequestParam()).List(context.TODO(), api.ListEverything)
+		var filteredItems []osmpolicyv1alpha1.Egress
+		for _, item := range list.Items {
+			if nsQuery.Matches(item.ObjectMeta.Namespace) {
+				filteredItems = append(filteredItems, item)
+			}
+		}
+		list.Items = filteredItems
+		for i := 0; i < numReads; i++ {
+			channel.List <- list
+			channel.Error <- err
+		}
+	}()
+
+	return channel
+}
+
+// IngressBackendListChannel is a list and error channels to IngressBackends.
+type IngressBackendListChannel struct {
+	List  chan *osmpolicyv1alpha1.IngressBackendList
+	Error chan error
+}
+
+// GetIngressBackendListChannel returns a pair of channels to an IngressBackend list and errors that
+// both must be read numReads times.
+func GetIngressBackendListChannel(osmPolicyClient osmpolicyclientset.Interface, nsQuery *NamespaceQuery,
+	numReads int) IngressBackendListChannel {
+
+	channel := IngressBackendListChannel{
+		List:  make(chan *osmpolicyv1alpha1.IngressBackendList, numReads),
+		Error: make(chan error, numReads),
+	}
+
+	go func() {
+		list, err := osmPolicyClient.PolicyV1alpha1().IngressBackends(nsQuery.ToRequestParam()).List(context.TODO(), api.ListEverything)
+		var filteredItems []osmpolicyv1alpha1.IngressBackend
+		for _, item := range list.Items {
+			if nsQuery.Matches(item.ObjectMeta.Namespace) {
+				filteredItems = append(filteredItems, item)
+			}
+		}
+		list.Items = filteredItems
+		for i := 0; i < numReads; i++ {
+			channel.List <- list
+			channel.Error <- err
+		}
+	}()
+
+	return channel
+}
+
+// UpstreamTrafficSettingListChannel is a list and error channels to UpstreamTrafficSettings.
+type UpstreamTrafficSettingListChannel struct {
+	List  chan *osmpolicyv1alpha1.UpstreamTrafficSettingList
+	Error chan error
+}
+
+// GetUpstreamTrafficSettingListChannel returns a pair of channels to an UpstreamTrafficSetting list and errors that
+// both must be read numReads times.
+func GetUpstreamTrafficSettingListChannel(osmPolicyClient osmpolicyclientset.Interface, nsQuery *NamespaceQuery,
+	numReads int) UpstreamTrafficSettingListChannel {
+
+	channel := UpstreamTrafficSettingListChannel{
+		List:  make(chan *osmpolicyv1alpha1.UpstreamTrafficSettingList, numReads),
+		Error: make(chan error, numReads),
+	}
+
+	go func() {
+		list, err := osmPolicyClient.PolicyV1alpha1().UpstreamTrafficSettings(nsQuery.ToRequestParam()).List(context.TODO(), api.ListEverything)
+		var filteredItems []osmpolicyv1alpha1.UpstreamTrafficSetting
+		for _, item := range list.Items {
+			if nsQuery.Matches(item.ObjectMeta.Namespace) {
+				filteredItems = append(filteredItems, item)
+			}
+		}
+		list.Items = filteredItems
+		for i := 0; i < numReads; i++ {
+			channel.List <- list
+			channel.Error <- err
+		}
+	}()
+
+	return channel
+}
+
+// RetryListChannel is a list and error channels to Retries.
+type RetryListChannel struct {
+	List  chan *osmpolicyv1alpha1.RetryList
+	Error chan error
+}
+
+// GetRetryListChannel returns a pair of channels to a Retry list and errors that
+// both must be read numReads times.
+func GetRetryListChannel(osmPolicyClient osmpolicyclientset.Interface, nsQuery *NamespaceQuery,
+	numReads int) RetryListChannel {
+
+	channel := RetryListChannel{
+		List:  make(chan *osmpolicyv1alpha1.RetryList, numReads),
+		Error: make(chan error, numReads),
+	}
+
+	go func() {
+		list, err := osmPolicyClient.PolicyV1alpha1().Retries(nsQuery.ToRequestParam()).List(context.TODO(), api.ListEverything)
+		var filteredItems []osmpolicyv1alpha1.Retry
+		for _, item := range list.Items {
+			if nsQuery.Matches(item.ObjectMeta.Namespace) {
+				filteredItems = append(filteredItems, item)
+			}
+		}
+		list.Items = filteredItems
+		for i := 0; i < numReads; i++ {
+			channel.List <- list
+			channel.Error <- err
+		}
+	}()
+
+	return channel
+}
+
+// ServiceAccountListChannel is a list and error channels to ServiceAccounts.
+type ServiceAccountListChannel struct {
+	List  chan *v1.ServiceAccountList
+	Error chan error
+}
+
+// GetServiceAccountListChannel returns a pair of channels to a ServiceAccount list and errors that
+// both must be read numReads times.
+func GetServiceAccountListChannel(client client.Interface, nsQuery *NamespaceQuery,
+	numReads int) ServiceAccountListChannel {
+
+	channel := ServiceAccountListChannel{
+		List:  make(chan *v1.ServiceAccountList, numReads),
+		Error: make(chan error, numReads),
+	}
+	go func() {
+		list, err := client.CoreV1().ServiceAccounts(nsQuery.ToRequestParam()).List(context.TODO(), api.ListEverything)
+		var filteredItems []v1.ServiceAccount
+		for _, item := range list.Items {
+			if nsQuery.Matches(item.ObjectMeta.Namespace) {
+				filteredItems = append(filteredItems, item)
+			}
+		}
+		list.Items = filteredItems
+		for i := 0; i < numReads; i++ {
+			channel.List <- list
+			channel.Error <- err
+		}
+	}()
+
+	return channel
+}
+
 // IngressListChannel is a list and error channels to Ingresss.
 type IngressListChannel struct {
 	List  chan *networkingv1.IngressList
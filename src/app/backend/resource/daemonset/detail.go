@@ -20,6 +20,8 @@ import (
 
 	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/osm/sidecar"
+	osmconfigclientset "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8sClient "k8s.io/client-go/kubernetes"
@@ -37,8 +39,8 @@ type DaemonSetDetail struct {
 }
 
 // GetDaemonSetDetail Returns detailed information about the given daemon set in the given namespace.
-func GetDaemonSetDetail(client k8sClient.Interface, metricClient metricapi.MetricClient,
-	namespace, name string) (*DaemonSetDetail, error) {
+func GetDaemonSetDetail(osmConfigClient osmconfigclientset.Interface, client k8sClient.Interface,
+	metricClient metricapi.MetricClient, namespace, name string) (*DaemonSetDetail, error) {
 
 	log.Printf("Getting details of %s daemon set in %s namespace", name, namespace)
 	daemonSet, err := client.AppsV1().DaemonSets(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
@@ -61,8 +63,15 @@ func GetDaemonSetDetail(client k8sClient.Interface, metricClient metricapi.Metri
 		return nil, err
 	}
 
+	item := toDaemonSet(*daemonSet, podList.Items, eventList.Items)
+	meshes, err := sidecar.GetMeshes(osmConfigClient, client, sidecar.SidecarNamespaces(podList.Items))
+	if err != nil {
+		return nil, err
+	}
+	meshes.ResolveWorkload(&item.Sidecars, namespace)
+
 	return &DaemonSetDetail{
-		DaemonSet:     toDaemonSet(*daemonSet, podList.Items, eventList.Items),
+		DaemonSet:     item,
 		LabelSelector: daemonSet.Spec.Selector,
 		Errors:        []error{},
 	}, nil
@@ -21,6 +21,8 @@ import (
 	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/event"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/osm/sidecar"
+	osmconfigclientset "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned"
 	apps "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/kubernetes"
@@ -44,11 +46,14 @@ type DaemonSet struct {
 	Pods                common.PodInfo `json:"podInfo"`
 	ContainerImages     []string       `json:"containerImages"`
 	InitContainerImages []string       `json:"initContainerImages"`
+
+	// Sidecar proxies of the pods of the Daemon Set.
+	Sidecars sidecar.WorkloadSidecars `json:"sidecars"`
 }
 
-// GetDaemonSetList returns a list of all Daemon Set in the cluster.
-func GetDaemonSetList(client kubernetes.Interface, nsQuery *common.NamespaceQuery, dsQuery *dataselect.DataSelectQuery,
-	metricClient metricapi.MetricClient) (*DaemonSetList, error) {
+// GetDaemonSetList returns a list of all Daemon Set in the cluster, with the mesh of their sidecars.
+func GetDaemonSetList(osmConfigClient osmconfigclientset.Interface, client kubernetes.Interface, nsQuery *common.NamespaceQuery,
+	dsQuery *dataselect.DataSelectQuery, metricClient metricapi.MetricClient) (*DaemonSetList, error) {
 	channels := &common.ResourceChannels{
 		DaemonSetList: common.GetDaemonSetListChannel(client, nsQuery, 1),
 		ServiceList:   common.GetServiceListChannel(client, nsQuery, 1),
@@ -56,7 +61,27 @@ func GetDaemonSetList(client kubernetes.Interface, nsQuery *common.NamespaceQuer
 		EventList:     common.GetEventListChannel(client, nsQuery, 1),
 	}
 
-	return GetDaemonSetListFromChannels(channels, dsQuery, metricClient)
+	daemonSetList, err := GetDaemonSetListFromChannels(channels, dsQuery, metricClient)
+	if err != nil {
+		return nil, err
+	}
+
+	namespaces := make([]string, 0)
+	for _, item := range daemonSetList.DaemonSets {
+		if item.Sidecars.Injected > 0 {
+			namespaces = append(namespaces, item.ObjectMeta.Namespace)
+		}
+	}
+	meshes, err := sidecar.GetMeshes(osmConfigClient, client, namespaces)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range daemonSetList.DaemonSets {
+		meshes.ResolveWorkload(&daemonSetList.DaemonSets[i].Sidecars, daemonSetList.DaemonSets[i].ObjectMeta.Namespace)
+	}
+
+	return daemonSetList, nil
 }
 
 // GetDaemonSetListFromChannels returns a list of all Daemon Set in the cluster
@@ -132,5 +157,6 @@ func toDaemonSet(daemonSet apps.DaemonSet, pods []v1.Pod, events []v1.Event) Dae
 		Pods:                podInfo,
 		ContainerImages:     common.GetContainerImages(&daemonSet.Spec.Template.Spec),
 		InitContainerImages: common.GetInitContainerImages(&daemonSet.Spec.Template.Spec),
+		Sidecars:            sidecar.GetWorkloadSidecars(matchingPods),
 	}
 }
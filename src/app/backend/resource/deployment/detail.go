@@ -20,6 +20,8 @@ import (
 
 	"github.com/kubernetes/dashboard/src/app/backend/errors"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/osm/sidecar"
+	osmconfigclientset "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned"
 	apps "k8s.io/api/apps/v1"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -80,7 +82,8 @@ type DeploymentDetail struct {
 }
 
 // GetDeploymentDetail returns model object of deployment and error, if any.
-func GetDeploymentDetail(client client.Interface, namespace string, deploymentName string) (*DeploymentDetail, error) {
+func GetDeploymentDetail(osmConfigClient osmconfigclientset.Interface, client client.Interface, namespace string,
+	deploymentName string) (*DeploymentDetail, error) {
 
 	log.Printf("Getting details of %s deployment in %s namespace", deploymentName, namespace)
 
@@ -134,8 +137,15 @@ func GetDeploymentDetail(client client.Interface, namespace string, deploymentNa
 		}
 	}
 
+	item := toDeployment(deployment, rawRs.Items, rawPods.Items, rawEvents.Items)
+	meshes, err := sidecar.GetMeshes(osmConfigClient, client, sidecar.SidecarNamespaces(rawPods.Items))
+	if err != nil {
+		return nil, err
+	}
+	meshes.ResolveWorkload(&item.Sidecars, namespace)
+
 	return &DeploymentDetail{
-		Deployment:            toDeployment(deployment, rawRs.Items, rawPods.Items, rawEvents.Items),
+		Deployment:            item,
 		Selector:              deployment.Spec.Selector.MatchLabels,
 		StatusInfo:            GetStatusInfo(&deployment.Status),
 		Conditions:            getConditions(deployment.Status.Conditions),
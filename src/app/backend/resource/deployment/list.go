@@ -23,6 +23,8 @@ import (
 	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/event"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/osm/sidecar"
+	osmconfigclientset "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned"
 	apps "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	client "k8s.io/client-go/kubernetes"
@@ -58,11 +60,14 @@ type Deployment struct {
 
 	// Init Container images of the Deployment.
 	InitContainerImages []string `json:"initContainerImages"`
+
+	// Sidecar proxies of the pods of the Deployment.
+	Sidecars sidecar.WorkloadSidecars `json:"sidecars"`
 }
 
-// GetDeploymentList returns a list of all Deployments in the cluster.
-func GetDeploymentList(client client.Interface, nsQuery *common.NamespaceQuery, dsQuery *dataselect.DataSelectQuery,
-	metricClient metricapi.MetricClient) (*DeploymentList, error) {
+// GetDeploymentList returns a list of all Deployments in the cluster, with the mesh of their sidecars.
+func GetDeploymentList(osmConfigClient osmconfigclientset.Interface, client client.Interface, nsQuery *common.NamespaceQuery,
+	dsQuery *dataselect.DataSelectQuery, metricClient metricapi.MetricClient) (*DeploymentList, error) {
 	log.Print("Getting list of all deployments in the cluster")
 
 	channels := &common.ResourceChannels{
@@ -72,7 +77,27 @@ func GetDeploymentList(client client.Interface, nsQuery *common.NamespaceQuery,
 		ReplicaSetList: common.GetReplicaSetListChannel(client, nsQuery, 1),
 	}
 
-	return GetDeploymentListFromChannels(channels, dsQuery, metricClient)
+	deploymentList, err := GetDeploymentListFromChannels(channels, dsQuery, metricClient)
+	if err != nil {
+		return nil, err
+	}
+
+	namespaces := make([]string, 0)
+	for _, item := range deploymentList.Deployments {
+		if item.Sidecars.Injected > 0 {
+			namespaces = append(namespaces, item.ObjectMeta.Namespace)
+		}
+	}
+	meshes, err := sidecar.GetMeshes(osmConfigClient, client, namespaces)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range deploymentList.Deployments {
+		meshes.ResolveWorkload(&deploymentList.Deployments[i].Sidecars, deploymentList.Deployments[i].ObjectMeta.Namespace)
+	}
+
+	return deploymentList, nil
 }
 
 // GetDeploymentListFromChannels returns a list of all Deployments in the cluster
@@ -155,5 +180,6 @@ func toDeployment(deployment *apps.Deployment, rs []apps.ReplicaSet, pods []v1.P
 		Pods:                podInfo,
 		ContainerImages:     common.GetContainerImages(&deployment.Spec.Template.Spec),
 		InitContainerImages: common.GetInitContainerImages(&deployment.Spec.Template.Spec),
+		Sidecars:            sidecar.GetWorkloadSidecars(matchingPods),
 	}
 }
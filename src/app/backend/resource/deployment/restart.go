@@ -0,0 +1,68 @@
+package deployment
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	client "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+)
+
+// RestartWorkload restarts a deployment, stateful set or daemon set in the manner of `kubectl
+// rollout restart`. The workload is read again when it changed before the update, and a workload
+// that no longer exists needs no restart.
+func RestartWorkload(client client.Interface, kind api.ResourceKind, namespace, name, restartedAt string) error {
+	var restart func() error
+	switch kind {
+	case api.ResourceKindDeployment:
+		restart = func() error {
+			item, err := client.AppsV1().Deployments(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			setRestartedAt(&item.Spec.Template, restartedAt)
+			_, err = client.AppsV1().Deployments(namespace).Update(context.TODO(), item, metaV1.UpdateOptions{})
+			return err
+		}
+	case api.ResourceKindStatefulSet:
+		restart = func() error {
+			item, err := client.AppsV1().StatefulSets(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			setRestartedAt(&item.Spec.Template, restartedAt)
+			_, err = client.AppsV1().StatefulSets(namespace).Update(context.TODO(), item, metaV1.UpdateOptions{})
+			return err
+		}
+	case api.ResourceKindDaemonSet:
+		restart = func() error {
+			item, err := client.AppsV1().DaemonSets(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			setRestartedAt(&item.Spec.Template, restartedAt)
+			_, err = client.AppsV1().DaemonSets(namespace).Update(context.TODO(), item, metaV1.UpdateOptions{})
+			return err
+		}
+	default:
+		return fmt.Errorf("cannot restart %s %s/%s", kind, namespace, name)
+	}
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, restart)
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+func setRestartedAt(template *v1.PodTemplateSpec, restartedAt string) {
+	if template.Annotations == nil {
+		template.Annotations = make(map[string]string)
+	}
+	template.Annotations[RestartedAtAnnotationKey] = restartedAt
+}
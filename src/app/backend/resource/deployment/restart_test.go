@@ -0,0 +1,47 @@
+package deployment
+
+import (
+	"context"
+	"testing"
+
+	apps "k8s.io/api/apps/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+)
+
+func TestRestartWorkload(t *testing.T) {
+	client := fake.NewSimpleClientset(&apps.StatefulSet{ObjectMeta: metaV1.ObjectMeta{Name: "bookwarehouse", Namespace: "bookwarehouse"}})
+	conflicts := 0
+	client.PrependReactor("update", "statefulsets", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		if conflicts > 0 {
+			return false, nil, nil
+		}
+		conflicts++
+		return true, nil, k8serrors.NewConflict(schema.GroupResource{Group: "apps", Resource: "statefulsets"}, "bookwarehouse", nil)
+	})
+
+	if err := RestartWorkload(client, api.ResourceKindStatefulSet, "bookwarehouse", "bookwarehouse", "2022-07-01T00:00:00Z"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	restarted, err := client.AppsV1().StatefulSets("bookwarehouse").Get(context.TODO(), "bookwarehouse", metaV1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if conflicts != 1 || restarted.Spec.Template.Annotations[RestartedAtAnnotationKey] != "2022-07-01T00:00:00Z" {
+		t.Errorf("expected the stateful set to be restarted after a conflict, but got %#v", restarted.Spec.Template.Annotations)
+	}
+
+	if err := RestartWorkload(client, api.ResourceKindDeployment, "bookwarehouse", "deleted", "2022-07-01T00:00:00Z"); err != nil {
+		t.Errorf("expected a deleted workload to need no restart, but got %s", err)
+	}
+
+	if err := RestartWorkload(client, api.ResourceKindReplicaSet, "bookwarehouse", "bookwarehouse", "2022-07-01T00:00:00Z"); err == nil {
+		t.Error("expected a replica set not to be restarted")
+	}
+}
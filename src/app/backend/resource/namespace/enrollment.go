@@ -0,0 +1,429 @@
+package namespace
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/deployment"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/osm/sidecar"
+)
+
+// MeshMembership describes whether and how a namespace is enrolled in a mesh.
+type MeshMembership struct {
+	// Mesh is the name of the mesh that monitors the namespace, empty if it is not enrolled.
+	Mesh string `json:"mesh"`
+
+	// SidecarInjection tells whether sidecars are injected into new pods of the namespace.
+	SidecarInjection bool `json:"sidecarInjection"`
+
+	// Metrics tells whether metrics are scraped from the sidecars of the namespace.
+	Metrics bool `json:"metrics"`
+
+	// Ignored tells whether the namespace carries the label that keeps it out of every mesh.
+	Ignored bool `json:"ignored"`
+}
+
+// NamespaceEnrollment is a namespace with its mesh membership.
+type NamespaceEnrollment struct {
+	ObjectMeta api.ObjectMeta `json:"objectMeta"`
+	TypeMeta   api.TypeMeta   `json:"typeMeta"`
+
+	// Phase is the current lifecycle phase of the namespace.
+	Phase v1.NamespacePhase `json:"phase"`
+
+	Membership MeshMembership `json:"membership"`
+}
+
+// NamespaceEnrollmentList contains the namespaces of the cluster with their mesh membership.
+type NamespaceEnrollmentList struct {
+	ListMeta api.ListMeta `json:"listMeta"`
+
+	Namespaces []NamespaceEnrollment `json:"namespaces"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// EnrollmentSpec adds a namespace to a mesh.
+type EnrollmentSpec struct {
+	// Mesh is the name of the mesh to add the namespace to.
+	Mesh string `json:"mesh"`
+
+	DisableSidecarInjection bool `json:"disableSidecarInjection"`
+
+	EnableMetrics bool `json:"enableMetrics"`
+
+	// Restart rolls out the workloads of the namespace so that their pods gain or lose sidecars.
+	Restart bool `json:"restart"`
+}
+
+// WorkloadRollout is the progress of the rollout of a workload towards the sidecar state its
+// namespace requires.
+type WorkloadRollout struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+
+	Desired int32 `json:"desired"`
+	Updated int32 `json:"updated"`
+	Ready   int32 `json:"ready"`
+
+	// Sidecar tells whether the pods of the workload should run a sidecar.
+	Sidecar bool `json:"sidecar"`
+
+	// Pods and PodsWithSidecar count the pods of the workload and those of them that run a sidecar.
+	Pods            int32 `json:"pods"`
+	PodsWithSidecar int32 `json:"podsWithSidecar"`
+
+	// Done tells whether the rollout is complete and every pod has the required sidecar state.
+	Done bool `json:"done"`
+}
+
+// EnrollmentStatus is the mesh membership of a namespace with the progress of its workloads.
+type EnrollmentStatus struct {
+	Namespace NamespaceEnrollment `json:"namespace"`
+
+	// RestartedAt is the time the workloads were restarted at, if they were restarted.
+	RestartedAt string `json:"restartedAt,omitempty"`
+
+	Workloads []WorkloadRollout `json:"workloads"`
+
+	// Done tells whether every workload is done.
+	Done bool `json:"done"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// GetNamespaceEnrollmentList returns the namespaces of the cluster with their mesh membership.
+func GetNamespaceEnrollmentList(client kubernetes.Interface, dsQuery *dataselect.DataSelectQuery) (*NamespaceEnrollmentList, error) {
+	log.Println("Getting list of namespace enrollments")
+	namespaces, err := client.CoreV1().Namespaces().List(context.TODO(), api.ListEverything)
+
+	nonCriticalErrors, criticalError := errors.HandleError(err)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	result := &NamespaceEnrollmentList{
+		Namespaces: make([]NamespaceEnrollment, 0),
+		ListMeta:   api.ListMeta{TotalItems: len(namespaces.Items)},
+		Errors:     nonCriticalErrors,
+	}
+
+	namespaceCells, filteredTotal := dataselect.GenericDataSelectWithFilter(toCells(namespaces.Items), dsQuery)
+	result.ListMeta = api.ListMeta{TotalItems: filteredTotal}
+	for _, namespace := range fromCells(namespaceCells) {
+		result.Namespaces = append(result.Namespaces, toNamespaceEnrollment(&namespace))
+	}
+
+	return result, nil
+}
+
+// GetEnrollmentStatus returns the mesh membership of a namespace and the progress of its
+// workloads towards it.
+func GetEnrollmentStatus(client kubernetes.Interface, name string) (*EnrollmentStatus, error) {
+	log.Printf("Getting enrollment status of %s namespace", name)
+	namespace, err := client.CoreV1().Namespaces().Get(context.TODO(), name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return getEnrollmentStatus(client, namespace, "")
+}
+
+// EnrollNamespace adds a namespace to a mesh, in the same way `osm namespace add` does.
+func EnrollNamespace(client kubernetes.Interface, name string, spec *EnrollmentSpec) (*EnrollmentStatus, error) {
+	log.Printf("Adding %s namespace to %s mesh", name, spec.Mesh)
+
+	if len(spec.Mesh) == 0 {
+		return nil, errors.NewBadRequest("mesh is required")
+	}
+	if problems := validation.IsValidLabelValue(spec.Mesh); len(problems) > 0 {
+		return nil, errors.NewBadRequest(fmt.Sprintf("mesh %q: %s", spec.Mesh, strings.Join(problems, "; ")))
+	}
+
+	namespace, err := client.CoreV1().Namespaces().Get(context.TODO(), name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	membership := getMeshMembership(namespace)
+	if membership.Ignored {
+		return nil, errors.NewBadRequest(fmt.Sprintf("namespace %s is labeled %s and cannot be added to a mesh", name, constants.IgnoreLabel))
+	}
+	if len(membership.Mesh) > 0 && membership.Mesh != spec.Mesh {
+		return nil, errors.NewGenericResponse(http.StatusConflict, fmt.Sprintf(
+			"namespace %s is already part of mesh %s, remove it from that mesh first", name, membership.Mesh))
+	}
+
+	controllers, err := client.AppsV1().Deployments(name).List(context.TODO(), metaV1.ListOptions{
+		LabelSelector: labels.Set{constants.AppLabel: constants.OSMControllerName}.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(controllers.Items) > 0 {
+		return nil, errors.NewBadRequest(fmt.Sprintf("namespace %s runs %s and cannot be added to a mesh", name, constants.OSMControllerName))
+	}
+
+	meshControllers, err := client.AppsV1().Deployments(v1.NamespaceAll).List(context.TODO(), metaV1.ListOptions{
+		LabelSelector: labels.Set{sidecar.MeshNameLabel: spec.Mesh}.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(meshControllers.Items) == 0 {
+		return nil, errors.NewBadRequest(fmt.Sprintf("mesh %s does not exist", spec.Mesh))
+	}
+
+	if namespace.Labels == nil {
+		namespace.Labels = make(map[string]string)
+	}
+	if namespace.Annotations == nil {
+		namespace.Annotations = make(map[string]string)
+	}
+
+	namespace.Labels[constants.OSMKubeResourceMonitorAnnotation] = spec.Mesh
+	namespace.Annotations[constants.SidecarInjectionAnnotation] = "enabled"
+	if spec.DisableSidecarInjection {
+		namespace.Annotations[constants.SidecarInjectionAnnotation] = "disabled"
+	}
+	if spec.EnableMetrics {
+		namespace.Annotations[constants.MetricsAnnotation] = "enabled"
+	} else {
+		delete(namespace.Annotations, constants.MetricsAnnotation)
+	}
+
+	return updateEnrollment(client, namespace, spec.Restart)
+}
+
+// UnenrollNamespace removes a namespace from its mesh, in the same way `osm namespace remove`
+// does. If mesh is not empty, the namespace must be part of it.
+func UnenrollNamespace(client kubernetes.Interface, name, mesh string, restart bool) (*EnrollmentStatus, error) {
+	log.Printf("Removing %s namespace from its mesh", name)
+
+	namespace, err := client.CoreV1().Namespaces().Get(context.TODO(), name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	membership := getMeshMembership(namespace)
+	if len(mesh) > 0 && len(membership.Mesh) > 0 && membership.Mesh != mesh {
+		return nil, errors.NewGenericResponse(http.StatusConflict, fmt.Sprintf(
+			"namespace %s is part of mesh %s, not %s", name, membership.Mesh, mesh))
+	}
+
+	delete(namespace.Labels, constants.OSMKubeResourceMonitorAnnotation)
+	delete(namespace.Annotations, constants.SidecarInjectionAnnotation)
+	delete(namespace.Annotations, constants.MetricsAnnotation)
+
+	return updateEnrollment(client, namespace, restart)
+}
+
+func updateEnrollment(client kubernetes.Interface, namespace *v1.Namespace, restart bool) (*EnrollmentStatus, error) {
+	updated, err := client.CoreV1().Namespaces().Update(context.TODO(), namespace, metaV1.UpdateOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	restartedAt := ""
+	if restart {
+		restartedAt = time.Now().Format(time.RFC3339)
+		if err := restartWorkloads(client, updated.Name, restartedAt); err != nil {
+			return nil, err
+		}
+	}
+
+	return getEnrollmentStatus(client, updated, restartedAt)
+}
+
+// restartWorkloads rolls out the deployments, stateful sets and daemon sets of a namespace, in
+// the same way `kubectl rollout restart` does.
+func restartWorkloads(client kubernetes.Interface, namespace, restartedAt string) error {
+	deployments, err := client.AppsV1().Deployments(namespace).List(context.TODO(), api.ListEverything)
+	if err != nil {
+		return err
+	}
+	for _, item := range deployments.Items {
+		if err := deployment.RestartWorkload(client, api.ResourceKindDeployment, namespace, item.Name, restartedAt); err != nil {
+			return err
+		}
+	}
+
+	statefulSets, err := client.AppsV1().StatefulSets(namespace).List(context.TODO(), api.ListEverything)
+	if err != nil {
+		return err
+	}
+	for _, item := range statefulSets.Items {
+		if err := deployment.RestartWorkload(client, api.ResourceKindStatefulSet, namespace, item.Name, restartedAt); err != nil {
+			return err
+		}
+	}
+
+	daemonSets, err := client.AppsV1().DaemonSets(namespace).List(context.TODO(), api.ListEverything)
+	if err != nil {
+		return err
+	}
+	for _, item := range daemonSets.Items {
+		if err := deployment.RestartWorkload(client, api.ResourceKindDaemonSet, namespace, item.Name, restartedAt); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func getEnrollmentStatus(client kubernetes.Interface, namespace *v1.Namespace, restartedAt string) (*EnrollmentStatus, error) {
+	status := &EnrollmentStatus{
+		Namespace:   toNamespaceEnrollment(namespace),
+		RestartedAt: restartedAt,
+		Workloads:   make([]WorkloadRollout, 0),
+		Errors:      make([]error, 0),
+		Done:        true,
+	}
+	membership := status.Namespace.Membership
+	injected := len(membership.Mesh) > 0 && membership.SidecarInjection
+
+	pods, err := client.CoreV1().Pods(namespace.Name).List(context.TODO(), api.ListEverything)
+	nonCriticalErrors, criticalError := errors.HandleError(err)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+	status.Errors = append(status.Errors, nonCriticalErrors...)
+
+	var podItems []v1.Pod
+	if err == nil {
+		podItems = pods.Items
+	}
+
+	appendWorkload := func(kind, name string, generation, observedGeneration int64, desired, updated, ready int32,
+		selector *metaV1.LabelSelector, template *v1.PodTemplateSpec) {
+		workload := WorkloadRollout{
+			Kind:    kind,
+			Name:    name,
+			Desired: desired,
+			Updated: updated,
+			Ready:   ready,
+			Sidecar: injected && injectionEnabled(template.Annotations, true),
+		}
+
+		if podSelector, err := metaV1.LabelSelectorAsSelector(selector); err == nil {
+			for _, pod := range podItems {
+				if pod.DeletionTimestamp != nil || !podSelector.Matches(labels.Set(pod.Labels)) {
+					continue
+				}
+				workload.Pods++
+				if sidecar.HasSidecar(&pod) {
+					workload.PodsWithSidecar++
+				}
+			}
+		}
+
+		sidecarsDone := workload.PodsWithSidecar == 0
+		if workload.Sidecar {
+			sidecarsDone = workload.PodsWithSidecar == workload.Pods
+		}
+		workload.Done = observedGeneration >= generation && updated >= desired && ready >= desired && sidecarsDone
+		status.Done = status.Done && workload.Done
+		status.Workloads = append(status.Workloads, workload)
+	}
+
+	deployments, err := client.AppsV1().Deployments(namespace.Name).List(context.TODO(), api.ListEverything)
+	nonCriticalErrors, criticalError = errors.HandleError(err)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+	status.Errors = append(status.Errors, nonCriticalErrors...)
+	if err == nil {
+		for _, item := range deployments.Items {
+			appendWorkload(api.ResourceKindDeployment, item.Name, item.Generation, item.Status.ObservedGeneration,
+				replicas(item.Spec.Replicas), item.Status.UpdatedReplicas, item.Status.ReadyReplicas, item.Spec.Selector, &item.Spec.Template)
+		}
+	}
+
+	statefulSets, err := client.AppsV1().StatefulSets(namespace.Name).List(context.TODO(), api.ListEverything)
+	nonCriticalErrors, criticalError = errors.HandleError(err)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+	status.Errors = append(status.Errors, nonCriticalErrors...)
+	if err == nil {
+		for _, item := range statefulSets.Items {
+			appendWorkload(api.ResourceKindStatefulSet, item.Name, item.Generation, item.Status.ObservedGeneration,
+				replicas(item.Spec.Replicas), item.Status.UpdatedReplicas, item.Status.ReadyReplicas, item.Spec.Selector, &item.Spec.Template)
+		}
+	}
+
+	daemonSets, err := client.AppsV1().DaemonSets(namespace.Name).List(context.TODO(), api.ListEverything)
+	nonCriticalErrors, criticalError = errors.HandleError(err)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+	status.Errors = append(status.Errors, nonCriticalErrors...)
+	if err == nil {
+		for _, item := range daemonSets.Items {
+			appendWorkload(api.ResourceKindDaemonSet, item.Name, item.Generation, item.Status.ObservedGeneration,
+				item.Status.DesiredNumberScheduled, item.Status.UpdatedNumberScheduled, item.Status.NumberReady, item.Spec.Selector, &item.Spec.Template)
+		}
+	}
+
+	return status, nil
+}
+
+func toNamespaceEnrollment(namespace *v1.Namespace) NamespaceEnrollment {
+	return NamespaceEnrollment{
+		ObjectMeta: api.NewObjectMeta(namespace.ObjectMeta),
+		TypeMeta:   api.NewTypeMeta(api.ResourceKindNamespace),
+		Phase:      namespace.Status.Phase,
+		Membership: getMeshMembership(namespace),
+	}
+}
+
+func getMeshMembership(namespace *v1.Namespace) MeshMembership {
+	return MeshMembership{
+		Mesh:             namespace.Labels[constants.OSMKubeResourceMonitorAnnotation],
+		SidecarInjection: injectionEnabled(namespace.Annotations, false),
+		Metrics:          annotationEnabled(namespace.Annotations[constants.MetricsAnnotation]),
+		Ignored:          namespace.Labels[constants.IgnoreLabel] == "true",
+	}
+}
+
+// injectionEnabled tells whether the sidecar injection annotation enables injection, or returns
+// the given default when the annotation is not set.
+func injectionEnabled(annotations map[string]string, defaultValue bool) bool {
+	value, ok := annotations[constants.SidecarInjectionAnnotation]
+	if !ok {
+		return defaultValue
+	}
+	return annotationEnabled(value)
+}
+
+// annotationEnabled accepts the values the OSM injector accepts for enabled annotations.
+func annotationEnabled(value string) bool {
+	switch strings.ToLower(value) {
+	case "enabled", "yes", "true":
+		return true
+	}
+	return false
+}
+
+func replicas(value *int32) int32 {
+	if value == nil {
+		return 1
+	}
+	return *value
+}
@@ -0,0 +1,135 @@
+package namespace
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/deployment"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/osm/sidecar"
+)
+
+func newEnrollmentClient() *fake.Clientset {
+	one := int32(1)
+	appLabels := map[string]string{"app": "bookstore"}
+	return fake.NewSimpleClientset(
+		&v1.Namespace{ObjectMeta: metaV1.ObjectMeta{Name: "bookstore"}},
+		&v1.Namespace{ObjectMeta: metaV1.ObjectMeta{Name: "kube-system", Labels: map[string]string{constants.IgnoreLabel: "true"}}},
+		&v1.Namespace{ObjectMeta: metaV1.ObjectMeta{Name: "other", Labels: map[string]string{constants.OSMKubeResourceMonitorAnnotation: "other-mesh"}}},
+		&appsv1.Deployment{ObjectMeta: metaV1.ObjectMeta{Name: "osm-controller", Namespace: "osm-system",
+			Labels: map[string]string{constants.AppLabel: constants.OSMControllerName, sidecar.MeshNameLabel: "osm"}}},
+		&appsv1.Deployment{
+			ObjectMeta: metaV1.ObjectMeta{Name: "bookstore", Namespace: "bookstore"},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: &one,
+				Selector: &metaV1.LabelSelector{MatchLabels: appLabels},
+			},
+			Status: appsv1.DeploymentStatus{UpdatedReplicas: 1, ReadyReplicas: 1},
+		},
+		&v1.Pod{
+			ObjectMeta: metaV1.ObjectMeta{Name: "bookstore-5d8b", Namespace: "bookstore", Labels: appLabels},
+			Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "bookstore"}}},
+		},
+	)
+}
+
+func TestEnrollNamespace(t *testing.T) {
+	client := newEnrollmentClient()
+
+	status, err := EnrollNamespace(client, "bookstore", &EnrollmentSpec{Mesh: "osm", EnableMetrics: true, Restart: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := MeshMembership{Mesh: "osm", SidecarInjection: true, Metrics: true}
+	if status.Namespace.Membership != expected {
+		t.Errorf("expected membership %#v, but got %#v", expected, status.Namespace.Membership)
+	}
+
+	if len(status.Workloads) != 1 || !status.Workloads[0].Sidecar || status.Workloads[0].Pods != 1 ||
+		status.Workloads[0].PodsWithSidecar != 0 || status.Workloads[0].Done || status.Done {
+		t.Errorf("expected bookstore deployment to be waiting for its sidecar, but got %#v", status.Workloads)
+	}
+
+	restarted, err := client.AppsV1().Deployments("bookstore").Get(nil, "bookstore", metaV1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if restarted.Spec.Template.Annotations[deployment.RestartedAtAnnotationKey] != status.RestartedAt || len(status.RestartedAt) == 0 {
+		t.Errorf("expected bookstore deployment to be restarted at %q, but got %#v", status.RestartedAt, restarted.Spec.Template.Annotations)
+	}
+
+	status, err = UnenrollNamespace(client, "bookstore", "osm", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if status.Namespace.Membership != (MeshMembership{}) || !status.Done {
+		t.Errorf("expected namespace to be removed from the mesh without pending workloads, but got %#v", status)
+	}
+
+	list, err := GetNamespaceEnrollmentList(client, dataselect.NoDataSelect)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for _, namespace := range list.Namespaces {
+		if namespace.ObjectMeta.Name == "other" && namespace.Membership.Mesh != "other-mesh" {
+			t.Errorf("expected other namespace to be part of other-mesh, but got %#v", namespace.Membership)
+		}
+	}
+}
+
+func TestRestartWorkloadsRetriesConflicts(t *testing.T) {
+	client := newEnrollmentClient()
+	conflicts := 0
+	client.PrependReactor("update", "deployments", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		if conflicts > 0 {
+			return false, nil, nil
+		}
+		conflicts++
+		return true, nil, k8serrors.NewConflict(schema.GroupResource{Group: "apps", Resource: "deployments"}, "bookstore", nil)
+	})
+
+	if err := restartWorkloads(client, "bookstore", "2022-07-01T00:00:00Z"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	restarted, err := client.AppsV1().Deployments("bookstore").Get(nil, "bookstore", metaV1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if conflicts != 1 || restarted.Spec.Template.Annotations[deployment.RestartedAtAnnotationKey] != "2022-07-01T00:00:00Z" {
+		t.Errorf("expected bookstore deployment to be restarted after a conflict, but got %#v", restarted.Spec.Template.Annotations)
+	}
+}
+
+func TestEnrollNamespaceRejected(t *testing.T) {
+	cases := []struct {
+		namespace string
+		mesh      string
+		code      int32
+	}{
+		{"bookstore", "", http.StatusBadRequest},
+		{"bookstore", "missing", http.StatusBadRequest},
+		{"kube-system", "osm", http.StatusBadRequest},
+		{"osm-system", "osm", http.StatusNotFound},
+		{"other", "osm", http.StatusConflict},
+	}
+
+	for _, c := range cases {
+		_, err := EnrollNamespace(newEnrollmentClient(), c.namespace, &EnrollmentSpec{Mesh: c.mesh})
+		if statusError, ok := err.(*k8serrors.StatusError); !ok || statusError.ErrStatus.Code != c.code {
+			t.Errorf("expected enrolling %s in mesh %q to fail with %d, but got %v", c.namespace, c.mesh, c.code, err)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package drift
+
+import (
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+)
+
+// The code below allows to perform complex data section on []DriftedWorkload
+
+type DriftedWorkloadCell DriftedWorkload
+
+func (self DriftedWorkloadCell) GetProperty(name dataselect.PropertyName) dataselect.ComparableValue {
+	switch name {
+	case dataselect.NameProperty:
+		return dataselect.StdComparableString(self.ObjectMeta.Name)
+	case dataselect.CreationTimestampProperty:
+		return dataselect.StdComparableTime(self.ObjectMeta.CreationTimestamp.Time)
+	case dataselect.NamespaceProperty:
+		return dataselect.StdComparableString(self.ObjectMeta.Namespace)
+	default:
+		// if name is not supported then just return a constant dummy value, sort will have no effect.
+		return nil
+	}
+}
+
+func toCells(std []DriftedWorkload) []dataselect.DataCell {
+	cells := make([]dataselect.DataCell, len(std))
+	for i := range std {
+		cells[i] = DriftedWorkloadCell(std[i])
+	}
+	return cells
+}
+
+func fromCells(cells []dataselect.DataCell) []DriftedWorkload {
+	std := make([]DriftedWorkload, len(cells))
+	for i := range std {
+		std[i] = DriftedWorkload(cells[i].(DriftedWorkloadCell))
+	}
+	return std
+}
@@ -0,0 +1,207 @@
+package drift
+
+import (
+	"log"
+	"sort"
+
+	osmconfigclientset "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned"
+	apps "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/osm/sidecar"
+)
+
+// DriftedWorkload is a workload with pods that run a sidecar image other than the one its mesh
+// currently injects, typically after the mesh was upgraded.
+type DriftedWorkload struct {
+	ObjectMeta api.ObjectMeta `json:"objectMeta"`
+	TypeMeta   api.TypeMeta   `json:"typeMeta"`
+
+	// Sidecars are the sidecar proxies of the pods of the workload.
+	Sidecars sidecar.WorkloadSidecars `json:"sidecars"`
+
+	// RollingOut tells whether the workload is being rolled out, for example after a restart.
+	RollingOut bool `json:"rollingOut"`
+}
+
+// DriftList contains the workloads of the cluster that run outdated sidecars.
+type DriftList struct {
+	ListMeta api.ListMeta `json:"listMeta"`
+
+	Workloads []DriftedWorkload `json:"workloads"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// GetDriftList returns the deployments, stateful sets and daemon sets of the cluster that still
+// run a sidecar image their mesh no longer injects.
+func GetDriftList(osmConfigClient osmconfigclientset.Interface, client kubernetes.Interface,
+	dsQuery *dataselect.DataSelectQuery) (*DriftList, error) {
+	log.Print("Getting list of workloads with outdated sidecars in the cluster")
+
+	workloads, nonCriticalErrors, err := getDriftedWorkloads(osmConfigClient, client)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &DriftList{
+		Workloads: make([]DriftedWorkload, 0),
+		ListMeta:  api.ListMeta{TotalItems: len(workloads)},
+		Errors:    nonCriticalErrors,
+	}
+
+	workloadCells, filteredTotal := dataselect.GenericDataSelectWithFilter(toCells(workloads), dsQuery)
+	result.Workloads = append(result.Workloads, fromCells(workloadCells)...)
+	result.ListMeta = api.ListMeta{TotalItems: filteredTotal}
+
+	return result, nil
+}
+
+// getDriftedWorkloads returns the drifted workloads of the cluster ordered by namespace, kind and
+// name, along with the non-critical errors that occurred while reading them.
+func getDriftedWorkloads(osmConfigClient osmconfigclientset.Interface, client kubernetes.Interface) (
+	[]DriftedWorkload, []error, error) {
+	nsQuery := common.NewNamespaceQuery(nil)
+	channels := &common.ResourceChannels{
+		DeploymentList:  common.GetDeploymentListChannel(client, nsQuery, 1),
+		ReplicaSetList:  common.GetReplicaSetListChannel(client, nsQuery, 1),
+		StatefulSetList: common.GetStatefulSetListChannel(client, nsQuery, 1),
+		DaemonSetList:   common.GetDaemonSetListChannel(client, nsQuery, 1),
+		PodList:         common.GetPodListChannel(client, nsQuery, 1),
+	}
+
+	deployments := <-channels.DeploymentList.List
+	err := <-channels.DeploymentList.Error
+	nonCriticalErrors, criticalError := errors.HandleError(err)
+	if criticalError != nil {
+		return nil, nil, criticalError
+	}
+
+	replicaSets := <-channels.ReplicaSetList.List
+	err = <-channels.ReplicaSetList.Error
+	nonCriticalErrors, criticalError = errors.AppendError(err, nonCriticalErrors)
+	if criticalError != nil {
+		return nil, nil, criticalError
+	}
+
+	statefulSets := <-channels.StatefulSetList.List
+	err = <-channels.StatefulSetList.Error
+	nonCriticalErrors, criticalError = errors.AppendError(err, nonCriticalErrors)
+	if criticalError != nil {
+		return nil, nil, criticalError
+	}
+
+	daemonSets := <-channels.DaemonSetList.List
+	err = <-channels.DaemonSetList.Error
+	nonCriticalErrors, criticalError = errors.AppendError(err, nonCriticalErrors)
+	if criticalError != nil {
+		return nil, nil, criticalError
+	}
+
+	pods := <-channels.PodList.List
+	err = <-channels.PodList.Error
+	nonCriticalErrors, criticalError = errors.AppendError(err, nonCriticalErrors)
+	if criticalError != nil {
+		return nil, nil, criticalError
+	}
+
+	var podItems []v1.Pod
+	if pods != nil {
+		podItems = pods.Items
+	}
+
+	meshes, err := sidecar.GetMeshes(osmConfigClient, client, sidecar.SidecarNamespaces(podItems))
+	if err != nil {
+		return nil, nil, err
+	}
+	var replicaSetItems []apps.ReplicaSet
+	if replicaSets != nil {
+		replicaSetItems = replicaSets.Items
+	}
+
+	result := make([]DriftedWorkload, 0)
+	appendDrifted := func(objectMeta api.ObjectMeta, kind api.ResourceKind, matchingPods []v1.Pod, rollingOut bool) {
+		sidecars := sidecar.GetWorkloadSidecars(matchingPods)
+		meshes.ResolveWorkload(&sidecars, objectMeta.Namespace)
+		if !sidecars.Drifted() {
+			return
+		}
+
+		result = append(result, DriftedWorkload{
+			ObjectMeta: objectMeta,
+			TypeMeta:   api.NewTypeMeta(kind),
+			Sidecars:   sidecars,
+			RollingOut: rollingOut,
+		})
+	}
+
+	if deployments != nil {
+		for _, deployment := range deployments.Items {
+			appendDrifted(api.NewObjectMeta(deployment.ObjectMeta), api.ResourceKindDeployment,
+				common.FilterDeploymentPodsByOwnerReference(deployment, replicaSetItems, podItems),
+				deploymentRollingOut(&deployment))
+		}
+	}
+
+	if statefulSets != nil {
+		for i := range statefulSets.Items {
+			statefulSet := &statefulSets.Items[i]
+			appendDrifted(api.NewObjectMeta(statefulSet.ObjectMeta), api.ResourceKindStatefulSet,
+				common.FilterPodsByControllerRef(statefulSet, podItems), statefulSetRollingOut(statefulSet))
+		}
+	}
+
+	if daemonSets != nil {
+		for i := range daemonSets.Items {
+			daemonSet := &daemonSets.Items[i]
+			appendDrifted(api.NewObjectMeta(daemonSet.ObjectMeta), api.ResourceKindDaemonSet,
+				common.FilterPodsByControllerRef(daemonSet, podItems), daemonSetRollingOut(daemonSet))
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.ObjectMeta.Namespace != b.ObjectMeta.Namespace {
+			return a.ObjectMeta.Namespace < b.ObjectMeta.Namespace
+		}
+		if a.TypeMeta.Kind != b.TypeMeta.Kind {
+			return a.TypeMeta.Kind < b.TypeMeta.Kind
+		}
+		return a.ObjectMeta.Name < b.ObjectMeta.Name
+	})
+
+	return result, nonCriticalErrors, nil
+}
+
+func deploymentRollingOut(deployment *apps.Deployment) bool {
+	desired := replicas(deployment.Spec.Replicas)
+	status := deployment.Status
+	return status.ObservedGeneration < deployment.Generation || status.UpdatedReplicas < desired ||
+		status.Replicas > status.UpdatedReplicas || status.AvailableReplicas < status.UpdatedReplicas
+}
+
+func statefulSetRollingOut(statefulSet *apps.StatefulSet) bool {
+	desired := replicas(statefulSet.Spec.Replicas)
+	status := statefulSet.Status
+	return status.ObservedGeneration < statefulSet.Generation || status.UpdatedReplicas < desired ||
+		status.ReadyReplicas < desired || (len(status.UpdateRevision) > 0 && status.CurrentRevision != status.UpdateRevision)
+}
+
+func daemonSetRollingOut(daemonSet *apps.DaemonSet) bool {
+	status := daemonSet.Status
+	return status.ObservedGeneration < daemonSet.Generation || status.UpdatedNumberScheduled < status.DesiredNumberScheduled ||
+		status.NumberAvailable < status.DesiredNumberScheduled
+}
+
+func replicas(value *int32) int32 {
+	if value == nil {
+		return 1
+	}
+	return *value
+}
@@ -0,0 +1,165 @@
+package drift
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	osmconfigv1alph2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	"github.com/openservicemesh/osm/pkg/constants"
+	osmconfigfake "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned/fake"
+	apps "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/deployment"
+)
+
+const (
+	currentImage  = "flomesh/pipy:0.50.0-25"
+	outdatedImage = "flomesh/pipy:0.40.0"
+)
+
+func controlledBy(kind, name string, uid types.UID) []metaV1.OwnerReference {
+	controller := true
+	return []metaV1.OwnerReference{{Kind: kind, Name: name, UID: uid, Controller: &controller}}
+}
+
+// newDeployment returns a deployment with a single pod that runs the given sidecar image.
+func newDeployment(name, image string) []runtime.Object {
+	one := int32(1)
+	deploymentUID, replicaSetUID := types.UID(name+"-deployment"), types.UID(name+"-replicaset")
+	return []runtime.Object{
+		&apps.Deployment{
+			ObjectMeta: metaV1.ObjectMeta{Name: name, Namespace: "bookstore", UID: deploymentUID},
+			Spec:       apps.DeploymentSpec{Replicas: &one},
+			Status:     apps.DeploymentStatus{Replicas: 1, UpdatedReplicas: 1, AvailableReplicas: 1},
+		},
+		&apps.ReplicaSet{ObjectMeta: metaV1.ObjectMeta{Name: name + "-5d8b", Namespace: "bookstore", UID: replicaSetUID,
+			OwnerReferences: controlledBy("Deployment", name, deploymentUID)}},
+		&v1.Pod{
+			ObjectMeta: metaV1.ObjectMeta{Name: name + "-5d8b-x7k2", Namespace: "bookstore",
+				OwnerReferences: controlledBy("ReplicaSet", name+"-5d8b", replicaSetUID)},
+			Spec: v1.PodSpec{Containers: []v1.Container{{Name: name}, {Name: constants.SidecarContainerName, Image: image}}},
+		},
+	}
+}
+
+func newClients() (*osmconfigfake.Clientset, *fake.Clientset) {
+	osmConfigClient := osmconfigfake.NewSimpleClientset(&osmconfigv1alph2.MeshConfig{
+		ObjectMeta: metaV1.ObjectMeta{Name: constants.OSMMeshConfig, Namespace: "osm-system"},
+		Spec:       osmconfigv1alph2.MeshConfigSpec{Sidecar: osmconfigv1alph2.SidecarSpec{SidecarImage: currentImage}},
+	})
+
+	objects := []runtime.Object{
+		&v1.Namespace{ObjectMeta: metaV1.ObjectMeta{Name: "bookstore",
+			Labels: map[string]string{constants.OSMKubeResourceMonitorAnnotation: "osm"}}},
+		&apps.Deployment{ObjectMeta: metaV1.ObjectMeta{Name: "osm-controller", Namespace: "osm-system",
+			Labels: map[string]string{constants.AppLabel: constants.OSMControllerName, "meshName": "osm"}}},
+	}
+	objects = append(objects, newDeployment("bookbuyer", outdatedImage)...)
+	objects = append(objects, newDeployment("bookstore", outdatedImage)...)
+	objects = append(objects, newDeployment("bookthief", outdatedImage)...)
+	objects = append(objects, newDeployment("bookwarehouse", currentImage)...)
+
+	return osmConfigClient, fake.NewSimpleClientset(objects...)
+}
+
+func TestGetDriftList(t *testing.T) {
+	osmConfigClient, client := newClients()
+
+	list, err := GetDriftList(osmConfigClient, client, dataselect.NoDataSelect)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if list.ListMeta.TotalItems != 3 {
+		t.Fatalf("expected three drifted deployments, but got %#v", list.Workloads)
+	}
+
+	for _, workload := range list.Workloads {
+		if workload.ObjectMeta.Name == "bookwarehouse" {
+			t.Errorf("expected bookwarehouse to be up to date, but it drifted: %#v", workload)
+		}
+		if workload.Sidecars.Outdated != 1 || workload.Sidecars.CurrentImage != currentImage || workload.RollingOut {
+			t.Errorf("expected %s to run one outdated sidecar, but got %#v", workload.ObjectMeta.Name, workload)
+		}
+	}
+}
+
+func TestRestartDriftedWorkloads(t *testing.T) {
+	osmConfigClient, client := newClients()
+
+	result, err := RestartDriftedWorkloads(osmConfigClient, client, &RestartSpec{BatchSize: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(result.Restarted) != 2 || result.Remaining != 1 || result.Done ||
+		result.Restarted[0].ObjectMeta.Name != "bookbuyer" || result.Restarted[1].ObjectMeta.Name != "bookstore" {
+		t.Fatalf("expected bookbuyer and bookstore to be restarted first, but got %#v", result)
+	}
+
+	for _, name := range []string{"bookbuyer", "bookstore"} {
+		restarted, err := client.AppsV1().Deployments("bookstore").Get(context.TODO(), name, metaV1.GetOptions{})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if restarted.Spec.Template.Annotations[deployment.RestartedAtAnnotationKey] != result.RestartedAt {
+			t.Errorf("expected %s to be restarted at %s, but got %#v", name, result.RestartedAt, restarted.Spec.Template.Annotations)
+		}
+
+		// The fake client does not roll out, so mark the restart as in progress the way the
+		// deployment controller would.
+		restarted.Generation = 2
+		if _, err := client.AppsV1().Deployments("bookstore").Update(context.TODO(), restarted, metaV1.UpdateOptions{}); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	result, err = RestartDriftedWorkloads(osmConfigClient, client, &RestartSpec{BatchSize: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(result.Restarted) != 0 || len(result.RollingOut) != 2 || result.Remaining != 1 || result.Done {
+		t.Errorf("expected the next batch to wait for the rollouts in progress, but got %#v", result)
+	}
+
+	_, err = RestartDriftedWorkloads(osmConfigClient, client, &RestartSpec{})
+	if statusError, ok := err.(*k8serrors.StatusError); !ok || statusError.ErrStatus.Code != http.StatusBadRequest {
+		t.Errorf("expected a missing batch size to be rejected with %d, but got %v", http.StatusBadRequest, err)
+	}
+}
+
+func TestRestartDriftedWorkloadsReportsFailures(t *testing.T) {
+	osmConfigClient, client := newClients()
+	updates := 0
+	client.PrependReactor("update", "deployments", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		updates++
+		switch updates {
+		case 1:
+			return true, nil, k8serrors.NewConflict(schema.GroupResource{Group: "apps", Resource: "deployments"}, "bookbuyer", nil)
+		case 3:
+			return true, nil, k8serrors.NewForbidden(schema.GroupResource{Group: "apps", Resource: "deployments"}, "bookstore", nil)
+		}
+		return false, nil, nil
+	})
+
+	result, err := RestartDriftedWorkloads(osmConfigClient, client, &RestartSpec{BatchSize: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(result.Restarted) != 1 || result.Restarted[0].ObjectMeta.Name != "bookbuyer" || result.Remaining != 2 ||
+		len(result.Errors) != 1 || !k8serrors.IsForbidden(result.Errors[0]) || result.Done {
+		t.Errorf("expected bookbuyer to be restarted after a conflict and bookstore to fail, but got %#v", result)
+	}
+}
@@ -0,0 +1,103 @@
+package drift
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	osmconfigclientset "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/deployment"
+)
+
+// RestartSpec selects the drifted workloads to restart in the next batch.
+type RestartSpec struct {
+	// BatchSize is the number of workloads that may roll out at the same time. Drifted workloads
+	// that are still rolling out from an earlier batch count towards it.
+	BatchSize int `json:"batchSize"`
+
+	// Mesh limits the restart to the workloads of a mesh. All meshes are restarted when it is empty.
+	Mesh string `json:"mesh"`
+}
+
+// RestartResult is the outcome of a batch of restarts. Callers repeat the restart until Done.
+type RestartResult struct {
+	// RestartedAt is the time the workloads of this batch were restarted at.
+	RestartedAt string `json:"restartedAt,omitempty"`
+
+	// Restarted are the workloads restarted by this batch.
+	Restarted []DriftedWorkload `json:"restarted"`
+
+	// RollingOut are the drifted workloads still rolling out from an earlier batch.
+	RollingOut []DriftedWorkload `json:"rollingOut"`
+
+	// Remaining counts the drifted workloads left for later batches.
+	Remaining int `json:"remaining"`
+
+	// Done tells whether no drifted workload is left.
+	Done bool `json:"done"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// RestartDriftedWorkloads restarts the next batch of drifted workloads, in the same way
+// `kubectl rollout restart` does, so that their pods get the sidecar the mesh injects now. At most
+// spec.BatchSize drifted workloads roll out at the same time.
+func RestartDriftedWorkloads(osmConfigClient osmconfigclientset.Interface, client kubernetes.Interface,
+	spec *RestartSpec) (*RestartResult, error) {
+	log.Printf("Restarting a batch of %d workloads with outdated sidecars", spec.BatchSize)
+
+	if spec.BatchSize < 1 {
+		return nil, errors.NewBadRequest(fmt.Sprintf("batchSize must be at least 1, got %d", spec.BatchSize))
+	}
+
+	workloads, nonCriticalErrors, err := getDriftedWorkloads(osmConfigClient, client)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &RestartResult{
+		Restarted:  make([]DriftedWorkload, 0),
+		RollingOut: make([]DriftedWorkload, 0),
+		Errors:     nonCriticalErrors,
+	}
+
+	pending := make([]DriftedWorkload, 0)
+	for _, workload := range workloads {
+		if len(spec.Mesh) > 0 && workload.Sidecars.Mesh != spec.Mesh {
+			continue
+		}
+		if workload.RollingOut {
+			result.RollingOut = append(result.RollingOut, workload)
+		} else {
+			pending = append(pending, workload)
+		}
+	}
+
+	restartedAt := time.Now().Format(time.RFC3339)
+	for _, workload := range pending {
+		if len(result.RollingOut)+len(result.Restarted) >= spec.BatchSize {
+			break
+		}
+
+		// A failed restart ends the batch, so that the workloads restarted before it are still reported.
+		err := deployment.RestartWorkload(client, workload.TypeMeta.Kind, workload.ObjectMeta.Namespace,
+			workload.ObjectMeta.Name, restartedAt)
+		if err != nil {
+			result.Errors = append(result.Errors, err)
+			break
+		}
+		workload.RollingOut = true
+		result.Restarted = append(result.Restarted, workload)
+	}
+
+	if len(result.Restarted) > 0 {
+		result.RestartedAt = restartedAt
+	}
+	result.Remaining = len(pending) - len(result.Restarted)
+	result.Done = result.Remaining == 0 && len(result.Restarted) == 0 && len(result.RollingOut) == 0
+	return result, nil
+}
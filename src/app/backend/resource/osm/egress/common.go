@@ -0,0 +1,41 @@
+package egress
+
+import (
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+
+	osmpolicyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+)
+
+// The code below allows to perform complex data section on []api.Egress
+
+type EgressCell osmpolicyv1alpha1.Egress
+
+func (self EgressCell) GetProperty(name dataselect.PropertyName) dataselect.ComparableValue {
+	switch name {
+	case dataselect.NameProperty:
+		return dataselect.StdComparableString(self.ObjectMeta.Name)
+	case dataselect.CreationTimestampProperty:
+		return dataselect.StdComparableTime(self.ObjectMeta.CreationTimestamp.Time)
+	case dataselect.NamespaceProperty:
+		return dataselect.StdComparableString(self.ObjectMeta.Namespace)
+	default:
+		// if name is not supported then just return a constant dummy value, sort will have no effect.
+		return nil
+	}
+}
+
+func toCells(std []osmpolicyv1alpha1.Egress) []dataselect.DataCell {
+	cells := make([]dataselect.DataCell, len(std))
+	for i := range std {
+		cells[i] = EgressCell(std[i])
+	}
+	return cells
+}
+
+func fromCells(cells []dataselect.DataCell) []osmpolicyv1alpha1.Egress {
+	std := make([]osmpolicyv1alpha1.Egress, len(cells))
+	for i := range std {
+		std[i] = osmpolicyv1alpha1.Egress(cells[i].(EgressCell))
+	}
+	return std
+}
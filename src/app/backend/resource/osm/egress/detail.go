@@ -0,0 +1,80 @@
+package egress
+
+import (
+	"context"
+	"log"
+
+	osmpolicyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	osmpolicyclientset "github.com/openservicemesh/osm/pkg/gen/client/policy/clientset/versioned"
+	smiaccessv1alpha3 "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/access/v1alpha3"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/smi/traffictarget"
+)
+
+// EgressDetail is an egress policy with its sources resolved into workloads.
+type EgressDetail struct {
+	// Extends list item structure.
+	Egress `json:",inline"`
+
+	// Sources are the service accounts the policy applies to with the workloads that run as them.
+	Sources []traffictarget.Identity `json:"sources"`
+
+	// Hosts, IP ranges and ports the sources may access.
+	Hosts       []string                     `json:"hosts"`
+	IPAddresses []string                     `json:"ipAddresses"`
+	Ports       []osmpolicyv1alpha1.PortSpec `json:"ports"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// GetEgressDetail returns an egress policy with its sources resolved into workloads.
+func GetEgressDetail(osmPolicyClient osmpolicyclientset.Interface, client kubernetes.Interface,
+	namespace, name string) (*EgressDetail, error) {
+	log.Printf("Getting details of %s egress in %s namespace", name, namespace)
+
+	egress, err := osmPolicyClient.PolicyV1alpha1().Egresses(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return toEgressDetail(client, egress)
+}
+
+func toEgressDetail(client kubernetes.Interface, egress *osmpolicyv1alpha1.Egress) (*EgressDetail, error) {
+	subjects := make([]smiaccessv1alpha3.IdentityBindingSubject, 0, len(egress.Spec.Sources))
+	for _, source := range egress.Spec.Sources {
+		subjects = append(subjects, smiaccessv1alpha3.IdentityBindingSubject{
+			Kind:      source.Kind,
+			Name:      source.Name,
+			Namespace: source.Namespace,
+		})
+	}
+
+	sources, nonCriticalErrors, err := traffictarget.ResolveIdentities(client, subjects, egress.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	detail := &EgressDetail{
+		Egress:      toEgress(egress),
+		Sources:     sources,
+		Hosts:       egress.Spec.Hosts,
+		IPAddresses: egress.Spec.IPAddresses,
+		Ports:       egress.Spec.Ports,
+		Errors:      nonCriticalErrors,
+	}
+	if detail.Hosts == nil {
+		detail.Hosts = make([]string, 0)
+	}
+	if detail.IPAddresses == nil {
+		detail.IPAddresses = make([]string, 0)
+	}
+	if detail.Ports == nil {
+		detail.Ports = make([]osmpolicyv1alpha1.PortSpec, 0)
+	}
+
+	return detail, nil
+}
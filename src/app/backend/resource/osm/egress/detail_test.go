@@ -0,0 +1,103 @@
+package egress
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	osmpolicyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	osmpolicyfake "github.com/openservicemesh/osm/pkg/gen/client/policy/clientset/versioned/fake"
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+)
+
+func newEgressSpec() osmpolicyv1alpha1.EgressSpec {
+	return osmpolicyv1alpha1.EgressSpec{
+		Sources:     []osmpolicyv1alpha1.EgressSourceSpec{{Kind: "ServiceAccount", Name: "curl", Namespace: "curl"}},
+		Hosts:       []string{"httpbin.org"},
+		IPAddresses: []string{"10.0.0.0/8"},
+		Ports:       []osmpolicyv1alpha1.PortSpec{{Number: 443, Protocol: "https"}},
+	}
+}
+
+func newK8sClient() *fake.Clientset {
+	return fake.NewSimpleClientset(
+		&v1.ServiceAccount{ObjectMeta: metaV1.ObjectMeta{Name: "curl", Namespace: "curl"}},
+		&v1.Pod{
+			ObjectMeta: metaV1.ObjectMeta{Name: "curl-54ccc6954c", Namespace: "curl"},
+			Spec:       v1.PodSpec{ServiceAccountName: "curl"},
+		},
+	)
+}
+
+func TestEgressLifecycle(t *testing.T) {
+	osmPolicyClient := osmpolicyfake.NewSimpleClientset()
+	k8sClient := newK8sClient()
+
+	created, err := CreateEgress(osmPolicyClient, k8sClient, "curl", &EgressSpec{Name: "httpbin-443", Spec: newEgressSpec()})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(created.Sources) != 1 || !created.Sources[0].Found || !reflect.DeepEqual(created.Sources[0].Pods, []string{"curl-54ccc6954c"}) {
+		t.Errorf("expected curl source resolved to its pod, but got %#v", created.Sources)
+	}
+
+	spec := newEgressSpec()
+	spec.Hosts = append(spec.Hosts, "github.com")
+	if _, err := UpdateEgress(osmPolicyClient, k8sClient, "curl", "httpbin-443", &EgressUpdate{Spec: spec}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	detail, err := GetEgressDetail(osmPolicyClient, k8sClient, "curl", "httpbin-443")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if !reflect.DeepEqual(detail.Hosts, []string{"httpbin.org", "github.com"}) || !reflect.DeepEqual(detail.IPAddresses, []string{"10.0.0.0/8"}) ||
+		!reflect.DeepEqual(detail.Ports, spec.Ports) {
+		t.Errorf("expected updated hosts, IP ranges and ports, but got %#v", detail)
+	}
+
+	list, err := GetEgressList(osmPolicyClient, common.NewNamespaceQuery(nil), dataselect.NoDataSelect)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if list.ListMeta.TotalItems != 1 || list.Egresses[0].ObjectMeta.Name != "httpbin-443" {
+		t.Errorf("expected a single httpbin-443 egress, but got %#v", list)
+	}
+}
+
+func TestEgressRejected(t *testing.T) {
+	osmPolicyClient := osmpolicyfake.NewSimpleClientset()
+	k8sClient := newK8sClient()
+
+	invalid := osmpolicyv1alpha1.EgressSpec{
+		Sources:     []osmpolicyv1alpha1.EgressSourceSpec{{Kind: "Pod", Name: "curl"}},
+		IPAddresses: []string{"10.0.0.0"},
+		Ports:       []osmpolicyv1alpha1.PortSpec{{Number: 70000, Protocol: "udp"}},
+	}
+	_, err := CreateEgress(osmPolicyClient, k8sClient, "curl", &EgressSpec{Name: "invalid", Spec: invalid})
+	if statusError, ok := err.(*k8serrors.StatusError); !ok || statusError.ErrStatus.Code != http.StatusBadRequest {
+		t.Errorf("expected invalid egress to be rejected, but got %v", err)
+	}
+
+	if problems := validateEgressSpec(&invalid); len(problems) != 5 {
+		t.Errorf("expected five problems, but got %#v", problems)
+	}
+
+	if _, err := CreateEgress(osmPolicyClient, k8sClient, "curl", &EgressSpec{Name: "httpbin", Spec: newEgressSpec()}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	_, err = UpdateEgress(osmPolicyClient, k8sClient, "curl", "httpbin", &EgressUpdate{ResourceVersion: "stale", Spec: newEgressSpec()})
+	if statusError, ok := err.(*k8serrors.StatusError); !ok || statusError.ErrStatus.Code != http.StatusConflict {
+		t.Errorf("expected stale update to conflict, but got %v", err)
+	}
+}
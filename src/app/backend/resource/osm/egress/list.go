@@ -0,0 +1,84 @@
+package egress
+
+import (
+	"log"
+
+	osmpolicyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	osmpolicyclientset "github.com/openservicemesh/osm/pkg/gen/client/policy/clientset/versioned"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+)
+
+// Egress is a representation of an egress policy.
+type Egress struct {
+	ObjectMeta api.ObjectMeta `json:"objectMeta"`
+	TypeMeta   api.TypeMeta   `json:"typeMeta"`
+	// Spec is the Egress policy specification.
+	Spec osmpolicyv1alpha1.EgressSpec `json:"spec"`
+}
+
+// EgressList contains a list of Egresses in the cluster.
+type EgressList struct {
+	ListMeta api.ListMeta `json:"listMeta"`
+
+	// Unordered list of egresses.
+	Egresses []Egress `json:"egresses"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// GetEgressList returns a list of all Egresses in the cluster.
+func GetEgressList(osmPolicyClient osmpolicyclientset.Interface, nsQuery *common.NamespaceQuery,
+	dsQuery *dataselect.DataSelectQuery) (*EgressList, error) {
+	log.Print("Getting list of all egresses in the cluster")
+
+	channels := &common.ResourceChannels{
+		EgressList: common.GetEgressListChannel(osmPolicyClient, nsQuery, 1),
+	}
+
+	return GetEgressListFromChannels(channels, dsQuery)
+}
+
+// GetEgressListFromChannels returns a list of all Egresses in the cluster.
+func GetEgressListFromChannels(channels *common.ResourceChannels,
+	dsQuery *dataselect.DataSelectQuery) (*EgressList, error) {
+	egresses := <-channels.EgressList.List
+	err := <-channels.EgressList.Error
+	nonCriticalErrors, criticalError := errors.HandleError(err)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	return CreateEgressList(egresses.Items, nonCriticalErrors, dsQuery), nil
+}
+
+func toEgress(egress *osmpolicyv1alpha1.Egress) Egress {
+	return Egress{
+		ObjectMeta: api.NewObjectMeta(egress.ObjectMeta),
+		TypeMeta:   api.NewTypeMeta(api.ResourceKindEgress),
+		Spec:       egress.Spec,
+	}
+}
+
+// CreateEgressList returns paginated egress list based on given egress array and pagination query.
+func CreateEgressList(egresses []osmpolicyv1alpha1.Egress, nonCriticalErrors []error, dsQuery *dataselect.DataSelectQuery) *EgressList {
+	egressList := &EgressList{
+		Egresses: make([]Egress, 0),
+		ListMeta: api.ListMeta{TotalItems: len(egresses)},
+		Errors:   nonCriticalErrors,
+	}
+
+	egressCells, filteredTotal := dataselect.GenericDataSelectWithFilter(toCells(egresses), dsQuery)
+	egresses = fromCells(egressCells)
+	egressList.ListMeta = api.ListMeta{TotalItems: filteredTotal}
+
+	for i := range egresses {
+		egressList.Egresses = append(egressList.Egresses, toEgress(&egresses[i]))
+	}
+
+	return egressList
+}
@@ -0,0 +1,165 @@
+package egress
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+
+	osmpolicyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	osmpolicyclientset "github.com/openservicemesh/osm/pkg/gen/client/policy/clientset/versioned"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+)
+
+const (
+	serviceAccountKind         = "ServiceAccount"
+	httpRouteGroupKind         = "HTTPRouteGroup"
+	upstreamTrafficSettingKind = "UpstreamTrafficSetting"
+
+	smiSpecsAPIGroup  = "specs.smi-spec.io/v1alpha4"
+	osmPolicyAPIGroup = "policy.openservicemesh.io/v1alpha1"
+)
+
+// protocols are the port protocols supported by egress policies.
+var protocols = map[string]bool{"http": true, "https": true, "tcp": true, "tcp-server-first": true}
+
+// EgressSpec is an egress policy to create.
+type EgressSpec struct {
+	Name   string            `json:"name"`
+	Labels map[string]string `json:"labels"`
+
+	Spec osmpolicyv1alpha1.EgressSpec `json:"spec"`
+}
+
+// EgressUpdate is a change of the spec of an egress policy.
+type EgressUpdate struct {
+	// ResourceVersion is the version of the egress the change was made on. When set, the change is
+	// rejected if the egress has changed since.
+	ResourceVersion string `json:"resourceVersion"`
+
+	// Spec is the complete new egress spec.
+	Spec osmpolicyv1alpha1.EgressSpec `json:"spec"`
+}
+
+// CreateEgress creates an egress policy in the given namespace.
+func CreateEgress(osmPolicyClient osmpolicyclientset.Interface, client kubernetes.Interface, namespace string,
+	spec *EgressSpec) (*EgressDetail, error) {
+	log.Printf("Creating %s egress in %s namespace", spec.Name, namespace)
+
+	problems := validation.IsDNS1123Subdomain(spec.Name)
+	problems = append(problems, validateEgressSpec(&spec.Spec)...)
+	if len(problems) > 0 {
+		return nil, errors.NewBadRequest(strings.Join(problems, "; "))
+	}
+
+	egress := &osmpolicyv1alpha1.Egress{
+		ObjectMeta: metaV1.ObjectMeta{Name: spec.Name, Namespace: namespace, Labels: spec.Labels},
+		Spec:       spec.Spec,
+	}
+
+	created, err := osmPolicyClient.PolicyV1alpha1().Egresses(namespace).Create(context.TODO(), egress, metaV1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return toEgressDetail(client, created)
+}
+
+// UpdateEgress replaces the spec of an egress policy.
+func UpdateEgress(osmPolicyClient osmpolicyclientset.Interface, client kubernetes.Interface, namespace, name string,
+	update *EgressUpdate) (*EgressDetail, error) {
+	log.Printf("Updating %s egress in %s namespace", name, namespace)
+
+	egress, err := osmPolicyClient.PolicyV1alpha1().Egresses(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(update.ResourceVersion) > 0 && update.ResourceVersion != egress.ResourceVersion {
+		return nil, errors.NewGenericResponse(http.StatusConflict, fmt.Sprintf(
+			"egress %s in namespace %s was changed since it was read (resourceVersion %s, current %s), reload it and retry",
+			name, namespace, update.ResourceVersion, egress.ResourceVersion))
+	}
+
+	if problems := validateEgressSpec(&update.Spec); len(problems) > 0 {
+		return nil, errors.NewBadRequest(strings.Join(problems, "; "))
+	}
+
+	egress.Spec = update.Spec
+	updated, err := osmPolicyClient.PolicyV1alpha1().Egresses(namespace).Update(context.TODO(), egress, metaV1.UpdateOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return toEgressDetail(client, updated)
+}
+
+// validateEgressSpec checks the sources, ports, hosts, IP ranges and matches of an egress policy.
+func validateEgressSpec(spec *osmpolicyv1alpha1.EgressSpec) []string {
+	problems := make([]string, 0)
+
+	if len(spec.Sources) == 0 {
+		problems = append(problems, "at least one source is required")
+	}
+	for i, source := range spec.Sources {
+		if source.Kind != serviceAccountKind {
+			problems = append(problems, fmt.Sprintf("source %d must be of kind %s, got %q", i, serviceAccountKind, source.Kind))
+		}
+		if len(source.Name) == 0 || len(source.Namespace) == 0 {
+			problems = append(problems, fmt.Sprintf("source %d must have a name and a namespace", i))
+		}
+	}
+
+	if len(spec.Ports) == 0 {
+		problems = append(problems, "at least one port is required")
+	}
+	for _, port := range spec.Ports {
+		if port.Number < 1 || port.Number > 65535 {
+			problems = append(problems, fmt.Sprintf("port %d is out of range", port.Number))
+		}
+		if !protocols[strings.ToLower(port.Protocol)] {
+			problems = append(problems, fmt.Sprintf("port %d has unsupported protocol %q", port.Number, port.Protocol))
+		}
+	}
+
+	for _, host := range spec.Hosts {
+		if len(strings.TrimSpace(host)) == 0 {
+			problems = append(problems, "hosts must not be empty")
+		}
+	}
+
+	for _, ipAddress := range spec.IPAddresses {
+		if _, _, err := net.ParseCIDR(ipAddress); err != nil {
+			problems = append(problems, fmt.Sprintf("IP address range %q is not a valid CIDR", ipAddress))
+		}
+	}
+
+	upstreamTrafficSettings := 0
+	for _, match := range spec.Matches {
+		apiGroup := ""
+		if match.APIGroup != nil {
+			apiGroup = *match.APIGroup
+		}
+
+		switch {
+		case apiGroup == smiSpecsAPIGroup && match.Kind == httpRouteGroupKind:
+		case apiGroup == osmPolicyAPIGroup && match.Kind == upstreamTrafficSettingKind:
+			upstreamTrafficSettings++
+		default:
+			problems = append(problems, fmt.Sprintf("match %q must be a %s of %s or an %s of %s, got %s of %q",
+				match.Name, httpRouteGroupKind, smiSpecsAPIGroup, upstreamTrafficSettingKind, osmPolicyAPIGroup,
+				match.Kind, apiGroup))
+		}
+	}
+	if upstreamTrafficSettings > 1 {
+		problems = append(problems, "at most one UpstreamTrafficSetting match is allowed")
+	}
+
+	return problems
+}
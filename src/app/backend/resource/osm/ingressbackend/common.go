@@ -0,0 +1,41 @@
+package ingressbackend
+
+import (
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+
+	osmpolicyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+)
+
+// The code below allows to perform complex data section on []api.IngressBackend
+
+type IngressBackendCell osmpolicyv1alpha1.IngressBackend
+
+func (self IngressBackendCell) GetProperty(name dataselect.PropertyName) dataselect.ComparableValue {
+	switch name {
+	case dataselect.NameProperty:
+		return dataselect.StdComparableString(self.ObjectMeta.Name)
+	case dataselect.CreationTimestampProperty:
+		return dataselect.StdComparableTime(self.ObjectMeta.CreationTimestamp.Time)
+	case dataselect.NamespaceProperty:
+		return dataselect.StdComparableString(self.ObjectMeta.Namespace)
+	default:
+		// if name is not supported then just return a constant dummy value, sort will have no effect.
+		return nil
+	}
+}
+
+func toCells(std []osmpolicyv1alpha1.IngressBackend) []dataselect.DataCell {
+	cells := make([]dataselect.DataCell, len(std))
+	for i := range std {
+		cells[i] = IngressBackendCell(std[i])
+	}
+	return cells
+}
+
+func fromCells(cells []dataselect.DataCell) []osmpolicyv1alpha1.IngressBackend {
+	std := make([]osmpolicyv1alpha1.IngressBackend, len(cells))
+	for i := range std {
+		std[i] = osmpolicyv1alpha1.IngressBackend(cells[i].(IngressBackendCell))
+	}
+	return std
+}
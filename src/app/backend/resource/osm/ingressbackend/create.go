@@ -0,0 +1,110 @@
+package ingressbackend
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"strings"
+
+	osmpolicyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	osmpolicyclientset "github.com/openservicemesh/osm/pkg/gen/client/policy/clientset/versioned"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+)
+
+// IngressBackendSpec is an ingress backend policy to create.
+type IngressBackendSpec struct {
+	Name   string            `json:"name"`
+	Labels map[string]string `json:"labels"`
+
+	Spec osmpolicyv1alpha1.IngressBackendSpec `json:"spec"`
+}
+
+// CreateIngressBackend creates an ingress backend policy in the given namespace.
+func CreateIngressBackend(osmPolicyClient osmpolicyclientset.Interface, client kubernetes.Interface, namespace string,
+	spec *IngressBackendSpec) (*IngressBackendDetail, error) {
+	log.Printf("Creating %s ingress backend in %s namespace", spec.Name, namespace)
+
+	problems := validation.IsDNS1123Subdomain(spec.Name)
+	problems = append(problems, validateIngressBackendSpec(&spec.Spec)...)
+	if len(problems) > 0 {
+		return nil, errors.NewBadRequest(strings.Join(problems, "; "))
+	}
+
+	ingressBackend := &osmpolicyv1alpha1.IngressBackend{
+		ObjectMeta: metaV1.ObjectMeta{Name: spec.Name, Namespace: namespace, Labels: spec.Labels},
+		Spec:       spec.Spec,
+	}
+
+	created, err := osmPolicyClient.PolicyV1alpha1().IngressBackends(namespace).Create(context.TODO(), ingressBackend, metaV1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return toIngressBackendDetail(client, created)
+}
+
+// validateIngressBackendSpec checks the backend ports, TLS settings and source kinds of an ingress backend.
+func validateIngressBackendSpec(spec *osmpolicyv1alpha1.IngressBackendSpec) []string {
+	problems := make([]string, 0)
+
+	authenticatedSourceFound := false
+	for _, source := range spec.Sources {
+		if source.Kind == osmpolicyv1alpha1.KindAuthenticatedPrincipal {
+			authenticatedSourceFound = true
+		}
+	}
+
+	if len(spec.Backends) == 0 {
+		problems = append(problems, "at least one backend is required")
+	}
+	for i, backend := range spec.Backends {
+		if len(backend.Name) == 0 {
+			problems = append(problems, fmt.Sprintf("backend %d must have a name", i))
+		}
+		if backend.Port.Number < 1 || backend.Port.Number > 65535 {
+			problems = append(problems, fmt.Sprintf("backend %d port %d is out of range", i, backend.Port.Number))
+		}
+
+		switch strings.ToLower(backend.Port.Protocol) {
+		case "http":
+		case "https":
+			// The webhook requires an authenticated principal when client certificate validation is skipped.
+			if backend.TLS.SkipClientCertValidation && !authenticatedSourceFound {
+				problems = append(problems, fmt.Sprintf("backend %d skips client certificate validation and requires an %s source",
+					i, osmpolicyv1alpha1.KindAuthenticatedPrincipal))
+			}
+		default:
+			problems = append(problems, fmt.Sprintf("backend %d port protocol must be http or https, got %q", i, backend.Port.Protocol))
+		}
+	}
+
+	if len(spec.Sources) == 0 {
+		problems = append(problems, "at least one source is required")
+	}
+	for i, source := range spec.Sources {
+		switch source.Kind {
+		case osmpolicyv1alpha1.KindService:
+			if len(source.Name) == 0 || len(source.Namespace) == 0 {
+				problems = append(problems, fmt.Sprintf("source %d must have a name and a namespace", i))
+			}
+		case osmpolicyv1alpha1.KindAuthenticatedPrincipal:
+			if len(source.Name) == 0 {
+				problems = append(problems, fmt.Sprintf("source %d must have a name", i))
+			}
+		case osmpolicyv1alpha1.KindIPRange:
+			if _, _, err := net.ParseCIDR(source.Name); err != nil {
+				problems = append(problems, fmt.Sprintf("source %d IP range %q is not a valid CIDR", i, source.Name))
+			}
+		default:
+			problems = append(problems, fmt.Sprintf("source %d must be of kind %s, %s or %s, got %q", i,
+				osmpolicyv1alpha1.KindService, osmpolicyv1alpha1.KindAuthenticatedPrincipal, osmpolicyv1alpha1.KindIPRange, source.Kind))
+		}
+	}
+
+	return problems
+}
@@ -0,0 +1,305 @@
+package ingressbackend
+
+import (
+	"context"
+	"log"
+	"net"
+
+	osmpolicyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	osmpolicyclientset "github.com/openservicemesh/osm/pkg/gen/client/policy/clientset/versioned"
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/ingress"
+)
+
+// IngressBackendDetail is an ingress backend policy cross-referenced with the Kubernetes ingresses
+// and services it applies to and the ingress controller pods its sources match.
+type IngressBackendDetail struct {
+	// Extends list item structure.
+	IngressBackend `json:",inline"`
+
+	// Backends of the policy with the objects that route to them.
+	Backends []Backend `json:"backends"`
+
+	// Sources of the policy with the running pods they match.
+	Sources []Source `json:"sources"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// Backend is a backend of an ingress backend policy.
+type Backend struct {
+	Name string                     `json:"name"`
+	Port osmpolicyv1alpha1.PortSpec `json:"port"`
+	TLS  osmpolicyv1alpha1.TLSSpec  `json:"tls"`
+
+	// ServiceFound tells whether the backend service exists in the namespace of the policy.
+	ServiceFound bool `json:"serviceFound"`
+
+	// PortFound tells whether the backend service exposes the backend port as a port or target port.
+	PortFound bool `json:"portFound"`
+
+	// Ingresses are the names of the Kubernetes ingresses that route to the backend service.
+	Ingresses []string `json:"ingresses"`
+
+	// SourceMatched is false when the policy has Service or IPRange sources, but none of them
+	// matches a running ingress controller pod, in which case no ingress traffic can reach the
+	// backend. AuthenticatedPrincipal sources are not checked.
+	SourceMatched bool `json:"sourceMatched"`
+}
+
+// Source is a source of an ingress backend policy.
+type Source struct {
+	osmpolicyv1alpha1.IngressSourceSpec `json:",inline"`
+
+	// Found tells whether a Service source exists. It is always true for other kinds.
+	Found bool `json:"found"`
+
+	// Pods are the running pods the source matches, as namespace/name. Service sources match the
+	// pods of the service, IPRange sources the ingress controller pods serving the ingresses of the
+	// backends. AuthenticatedPrincipal sources match no pods.
+	Pods []string `json:"pods"`
+}
+
+// GetIngressBackendDetail returns an ingress backend policy cross-referenced with ingresses,
+// services and ingress controller pods.
+func GetIngressBackendDetail(osmPolicyClient osmpolicyclientset.Interface, client kubernetes.Interface,
+	namespace, name string) (*IngressBackendDetail, error) {
+	log.Printf("Getting details of %s ingress backend in %s namespace", name, namespace)
+
+	ingressBackend, err := osmPolicyClient.PolicyV1alpha1().IngressBackends(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return toIngressBackendDetail(client, ingressBackend)
+}
+
+func toIngressBackendDetail(client kubernetes.Interface, ingressBackend *osmpolicyv1alpha1.IngressBackend) (*IngressBackendDetail, error) {
+	detail := &IngressBackendDetail{
+		IngressBackend: toIngressBackend(ingressBackend),
+		Backends:       make([]Backend, 0),
+		Sources:        make([]Source, 0),
+		Errors:         make([]error, 0),
+	}
+
+	ingresses, err := client.NetworkingV1().Ingresses(ingressBackend.Namespace).List(context.TODO(), api.ListEverything)
+	nonCriticalErrors, criticalError := errors.HandleError(err)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+	detail.Errors = append(detail.Errors, nonCriticalErrors...)
+
+	backendIngresses := make(map[string][]string)
+	listedIngresses := make([]networkingv1.Ingress, 0)
+	if ingresses != nil {
+		for _, item := range ingresses.Items {
+			listed := false
+			for _, spec := range ingressBackend.Spec.Backends {
+				if len(ingress.FilterIngressByService([]networkingv1.Ingress{item}, spec.Name)) > 0 {
+					backendIngresses[spec.Name] = append(backendIngresses[spec.Name], item.Name)
+					listed = true
+				}
+			}
+			if listed {
+				listedIngresses = append(listedIngresses, item)
+			}
+		}
+	}
+
+	checked, sourceMatched := false, false
+	var controllerPods []v1.Pod
+	for _, spec := range ingressBackend.Spec.Sources {
+		source := Source{IngressSourceSpec: spec, Found: true, Pods: make([]string, 0)}
+
+		switch spec.Kind {
+		case osmpolicyv1alpha1.KindService:
+			pods, found, nonCriticalErrors, err := getServicePods(client, spec.Namespace, spec.Name)
+			if err != nil {
+				return nil, err
+			}
+			detail.Errors = append(detail.Errors, nonCriticalErrors...)
+			source.Found = found
+			source.Pods = podNames(pods)
+			checked = true
+		case osmpolicyv1alpha1.KindIPRange:
+			checked = true
+			_, ipNet, parseErr := net.ParseCIDR(spec.Name)
+			if parseErr != nil {
+				break
+			}
+
+			if controllerPods == nil {
+				pods, nonCriticalErrors, err := getIngressControllerPods(client, listedIngresses)
+				if err != nil {
+					return nil, err
+				}
+				detail.Errors = append(detail.Errors, nonCriticalErrors...)
+				controllerPods = pods
+			}
+
+			source.Pods = podNames(filterByIPRange(controllerPods, ipNet))
+		}
+
+		sourceMatched = sourceMatched || len(source.Pods) > 0
+		detail.Sources = append(detail.Sources, source)
+	}
+
+	for _, spec := range ingressBackend.Spec.Backends {
+		backend := Backend{
+			Name:          spec.Name,
+			Port:          spec.Port,
+			TLS:           spec.TLS,
+			Ingresses:     append(make([]string, 0), backendIngresses[spec.Name]...),
+			SourceMatched: sourceMatched || !checked,
+		}
+
+		service, err := client.CoreV1().Services(ingressBackend.Namespace).Get(context.TODO(), spec.Name, metaV1.GetOptions{})
+		if err == nil {
+			backend.ServiceFound = true
+			backend.PortFound = servicePortFound(service, spec.Port.Number)
+		} else if !k8serrors.IsNotFound(err) {
+			nonCriticalErrors, criticalError := errors.HandleError(err)
+			if criticalError != nil {
+				return nil, criticalError
+			}
+			detail.Errors = append(detail.Errors, nonCriticalErrors...)
+		}
+
+		detail.Backends = append(detail.Backends, backend)
+	}
+
+	return detail, nil
+}
+
+// getServicePods returns the running pods selected by a service and whether the service exists.
+func getServicePods(client kubernetes.Interface, namespace, name string) ([]v1.Pod, bool, []error, error) {
+	service, err := client.CoreV1().Services(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return nil, false, nil, nil
+	}
+	nonCriticalErrors, criticalError := errors.HandleError(err)
+	if err != nil || len(service.Spec.Selector) == 0 {
+		return nil, err == nil, nonCriticalErrors, criticalError
+	}
+
+	selector := labels.SelectorFromSet(service.Spec.Selector)
+	pods, err := client.CoreV1().Pods(namespace).List(context.TODO(), metaV1.ListOptions{LabelSelector: selector.String()})
+	nonCriticalErrors, criticalError = errors.HandleError(err)
+	if err != nil {
+		return nil, true, nonCriticalErrors, criticalError
+	}
+
+	return filterRunning(pods.Items), true, nil, nil
+}
+
+// getIngressControllerPods returns the running pods of the services that expose the load balancer
+// addresses the given ingresses report in their status, i.e. the ingress controllers serving them.
+func getIngressControllerPods(client kubernetes.Interface, ingresses []networkingv1.Ingress) ([]v1.Pod, []error, error) {
+	result := make([]v1.Pod, 0)
+
+	addresses := make(map[string]bool)
+	for _, item := range ingresses {
+		for _, loadBalancer := range item.Status.LoadBalancer.Ingress {
+			for _, address := range []string{loadBalancer.IP, loadBalancer.Hostname} {
+				if len(address) > 0 {
+					addresses[address] = true
+				}
+			}
+		}
+	}
+	if len(addresses) == 0 {
+		return result, nil, nil
+	}
+
+	services, err := client.CoreV1().Services(v1.NamespaceAll).List(context.TODO(), api.ListEverything)
+	nonCriticalErrors, criticalError := errors.HandleError(err)
+	if err != nil {
+		return result, nonCriticalErrors, criticalError
+	}
+
+	for _, service := range services.Items {
+		if !serviceExposes(&service, addresses) {
+			continue
+		}
+
+		pods, _, podErrors, err := getServicePods(client, service.Namespace, service.Name)
+		if err != nil {
+			return nil, nil, err
+		}
+		nonCriticalErrors = append(nonCriticalErrors, podErrors...)
+		result = append(result, pods...)
+	}
+
+	return result, nonCriticalErrors, nil
+}
+
+// serviceExposes tells whether a service is reachable on one of the given addresses.
+func serviceExposes(service *v1.Service, addresses map[string]bool) bool {
+	for _, loadBalancer := range service.Status.LoadBalancer.Ingress {
+		if addresses[loadBalancer.IP] || addresses[loadBalancer.Hostname] {
+			return true
+		}
+	}
+	for _, ip := range append([]string{service.Spec.ClusterIP}, service.Spec.ExternalIPs...) {
+		if addresses[ip] {
+			return true
+		}
+	}
+	return false
+}
+
+func filterRunning(pods []v1.Pod) []v1.Pod {
+	running := make([]v1.Pod, 0)
+	for _, pod := range pods {
+		if pod.Status.Phase == v1.PodRunning {
+			running = append(running, pod)
+		}
+	}
+	return running
+}
+
+func filterByIPRange(pods []v1.Pod, ipNet *net.IPNet) []v1.Pod {
+	matching := make([]v1.Pod, 0)
+	for _, pod := range pods {
+		podIPs := []string{pod.Status.PodIP}
+		for _, podIP := range pod.Status.PodIPs {
+			podIPs = append(podIPs, podIP.IP)
+		}
+
+		for _, podIP := range podIPs {
+			if ip := net.ParseIP(podIP); ip != nil && ipNet.Contains(ip) {
+				matching = append(matching, pod)
+				break
+			}
+		}
+	}
+	return matching
+}
+
+func podNames(pods []v1.Pod) []string {
+	names := make([]string, 0, len(pods))
+	for _, pod := range pods {
+		names = append(names, pod.Namespace+"/"+pod.Name)
+	}
+	return names
+}
+
+// servicePortFound tells whether the service exposes the given port, either directly or as the
+// target port of one of its ports.
+func servicePortFound(service *v1.Service, port int) bool {
+	for _, servicePort := range service.Spec.Ports {
+		if int(servicePort.Port) == port || servicePort.TargetPort.IntValue() == port {
+			return true
+		}
+	}
+	return false
+}
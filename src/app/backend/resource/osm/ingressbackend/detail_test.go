@@ -0,0 +1,216 @@
+package ingressbackend
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	osmpolicyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	osmpolicyfake "github.com/openservicemesh/osm/pkg/gen/client/policy/clientset/versioned/fake"
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+)
+
+func newK8sClient() *fake.Clientset {
+	controllerLabels := map[string]string{"app.kubernetes.io/name": "ingress-nginx"}
+	return fake.NewSimpleClientset(
+		&v1.Service{
+			ObjectMeta: metaV1.ObjectMeta{Name: "ingress-nginx-controller", Namespace: "ingress-nginx"},
+			Spec:       v1.ServiceSpec{Type: v1.ServiceTypeLoadBalancer, Selector: controllerLabels},
+			Status: v1.ServiceStatus{LoadBalancer: v1.LoadBalancerStatus{
+				Ingress: []v1.LoadBalancerIngress{{IP: "172.18.0.2"}},
+			}},
+		},
+		&v1.Pod{
+			ObjectMeta: metaV1.ObjectMeta{Name: "ingress-nginx-controller-7d9f", Namespace: "ingress-nginx", Labels: controllerLabels},
+			Status:     v1.PodStatus{Phase: v1.PodRunning, PodIP: "10.244.0.12"},
+		},
+		&v1.Pod{
+			ObjectMeta: metaV1.ObjectMeta{Name: "ingress-nginx-admission-create", Namespace: "ingress-nginx", Labels: controllerLabels},
+			Status:     v1.PodStatus{Phase: v1.PodSucceeded, PodIP: "10.244.0.13"},
+		},
+		&v1.Service{
+			ObjectMeta: metaV1.ObjectMeta{Name: "httpbin", Namespace: "httpbin"},
+			Spec: v1.ServiceSpec{
+				Selector: map[string]string{"app": "httpbin"},
+				Ports:    []v1.ServicePort{{Port: 14001, TargetPort: intstr.FromInt(14001)}},
+			},
+		},
+		&v1.Pod{
+			ObjectMeta: metaV1.ObjectMeta{Name: "httpbin-5f7c", Namespace: "httpbin", Labels: map[string]string{"app": "httpbin"}},
+			Status:     v1.PodStatus{Phase: v1.PodRunning, PodIP: "10.244.0.20"},
+		},
+		&networkingv1.Ingress{
+			ObjectMeta: metaV1.ObjectMeta{Name: "httpbin", Namespace: "httpbin"},
+			Spec: networkingv1.IngressSpec{Rules: []networkingv1.IngressRule{
+				{Host: "httpbin.example.com"},
+				{IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{
+					Paths: []networkingv1.HTTPIngressPath{{Backend: networkingv1.IngressBackend{
+						Service: &networkingv1.IngressServiceBackend{Name: "httpbin", Port: networkingv1.ServiceBackendPort{Number: 14001}},
+					}}},
+				}}},
+			}},
+			Status: networkingv1.IngressStatus{LoadBalancer: v1.LoadBalancerStatus{
+				Ingress: []v1.LoadBalancerIngress{{IP: "172.18.0.2"}},
+			}},
+		},
+	)
+}
+
+func newIngressBackendSpec(sources ...osmpolicyv1alpha1.IngressSourceSpec) osmpolicyv1alpha1.IngressBackendSpec {
+	return osmpolicyv1alpha1.IngressBackendSpec{
+		Backends: []osmpolicyv1alpha1.BackendSpec{
+			{Name: "httpbin", Port: osmpolicyv1alpha1.PortSpec{Number: 14001, Protocol: "http"}},
+			{Name: "missing", Port: osmpolicyv1alpha1.PortSpec{Number: 80, Protocol: "http"}},
+		},
+		Sources: sources,
+	}
+}
+
+func TestIngressBackendDetail(t *testing.T) {
+	osmPolicyClient := osmpolicyfake.NewSimpleClientset()
+	k8sClient := newK8sClient()
+
+	spec := newIngressBackendSpec(
+		osmpolicyv1alpha1.IngressSourceSpec{Kind: osmpolicyv1alpha1.KindService, Name: "ingress-nginx-controller", Namespace: "ingress-nginx"},
+		osmpolicyv1alpha1.IngressSourceSpec{Kind: osmpolicyv1alpha1.KindIPRange, Name: "10.244.0.0/16"},
+	)
+	detail, err := CreateIngressBackend(osmPolicyClient, k8sClient, "httpbin", &IngressBackendSpec{Name: "httpbin", Spec: spec})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expectedPods := []string{"ingress-nginx/ingress-nginx-controller-7d9f"}
+	for _, source := range detail.Sources {
+		if !source.Found || !reflect.DeepEqual(source.Pods, expectedPods) {
+			t.Errorf("expected %s source to match the running controller pod, but got %#v", source.Kind, source)
+		}
+	}
+
+	expectedBackends := []Backend{
+		{Name: "httpbin", Port: spec.Backends[0].Port, ServiceFound: true, PortFound: true, Ingresses: []string{"httpbin"}, SourceMatched: true},
+		{Name: "missing", Port: spec.Backends[1].Port, Ingresses: []string{}, SourceMatched: true},
+	}
+	if !reflect.DeepEqual(detail.Backends, expectedBackends) {
+		t.Errorf("expected backends %#v, but got %#v", expectedBackends, detail.Backends)
+	}
+
+	list, err := GetIngressBackendList(osmPolicyClient, common.NewNamespaceQuery(nil), dataselect.NoDataSelect)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if list.ListMeta.TotalItems != 1 || list.IngressBackends[0].ObjectMeta.Name != "httpbin" {
+		t.Errorf("expected a single httpbin ingress backend, but got %#v", list)
+	}
+}
+
+func TestIngressBackendUnmatchedSources(t *testing.T) {
+	osmPolicyClient := osmpolicyfake.NewSimpleClientset()
+	k8sClient := newK8sClient()
+
+	spec := newIngressBackendSpec(
+		osmpolicyv1alpha1.IngressSourceSpec{Kind: osmpolicyv1alpha1.KindService, Name: "traefik", Namespace: "traefik"},
+		osmpolicyv1alpha1.IngressSourceSpec{Kind: osmpolicyv1alpha1.KindIPRange, Name: "192.168.0.0/16"},
+	)
+	if _, err := CreateIngressBackend(osmPolicyClient, k8sClient, "httpbin", &IngressBackendSpec{Name: "httpbin", Spec: spec}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	detail, err := GetIngressBackendDetail(osmPolicyClient, k8sClient, "httpbin", "httpbin")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if detail.Sources[0].Found || len(detail.Sources[0].Pods) != 0 || len(detail.Sources[1].Pods) != 0 {
+		t.Errorf("expected sources to match no pods, but got %#v", detail.Sources)
+	}
+
+	for _, backend := range detail.Backends {
+		if backend.SourceMatched {
+			t.Errorf("expected backend %s to be marked as unmatched", backend.Name)
+		}
+	}
+}
+
+func TestIngressBackendIPRangeMatchesOnlyControllers(t *testing.T) {
+	osmPolicyClient := osmpolicyfake.NewSimpleClientset()
+	k8sClient := newK8sClient()
+
+	spec := newIngressBackendSpec(osmpolicyv1alpha1.IngressSourceSpec{Kind: osmpolicyv1alpha1.KindIPRange, Name: "10.0.0.0/8"})
+	detail, err := CreateIngressBackend(osmPolicyClient, k8sClient, "httpbin", &IngressBackendSpec{Name: "httpbin", Spec: spec})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expectedPods := []string{"ingress-nginx/ingress-nginx-controller-7d9f"}
+	if !reflect.DeepEqual(detail.Sources[0].Pods, expectedPods) {
+		t.Errorf("expected IPRange source to match only the controller pod, but got %v", detail.Sources[0].Pods)
+	}
+
+	// Without a load balancer address on the ingress, its controller is unknown.
+	ingress, _ := k8sClient.NetworkingV1().Ingresses("httpbin").Get(context.TODO(), "httpbin", metaV1.GetOptions{})
+	ingress.Status = networkingv1.IngressStatus{}
+	if _, err := k8sClient.NetworkingV1().Ingresses("httpbin").UpdateStatus(context.TODO(), ingress, metaV1.UpdateOptions{}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	detail, err = GetIngressBackendDetail(osmPolicyClient, k8sClient, "httpbin", "httpbin")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(detail.Sources[0].Pods) != 0 || detail.Backends[0].SourceMatched {
+		t.Errorf("expected IPRange source to match no pods, but got %#v", detail)
+	}
+}
+
+func TestIngressBackendAuthenticatedPrincipalSources(t *testing.T) {
+	spec := newIngressBackendSpec(osmpolicyv1alpha1.IngressSourceSpec{
+		Kind: osmpolicyv1alpha1.KindAuthenticatedPrincipal,
+		Name: "ingress-nginx.ingress-nginx.cluster.local",
+	})
+	detail, err := CreateIngressBackend(osmpolicyfake.NewSimpleClientset(), newK8sClient(), "httpbin",
+		&IngressBackendSpec{Name: "httpbin", Spec: spec})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for _, backend := range detail.Backends {
+		if !backend.SourceMatched {
+			t.Errorf("expected backend %s not to be checked against authenticated principals", backend.Name)
+		}
+	}
+}
+
+func TestIngressBackendRejected(t *testing.T) {
+	invalid := osmpolicyv1alpha1.IngressBackendSpec{
+		Backends: []osmpolicyv1alpha1.BackendSpec{
+			{Name: "httpbin", Port: osmpolicyv1alpha1.PortSpec{Number: 14001, Protocol: "grpc"}},
+			{Name: "httpbin-tls", Port: osmpolicyv1alpha1.PortSpec{Number: 443, Protocol: "https"},
+				TLS: osmpolicyv1alpha1.TLSSpec{SkipClientCertValidation: true}},
+		},
+		Sources: []osmpolicyv1alpha1.IngressSourceSpec{
+			{Kind: osmpolicyv1alpha1.KindService, Name: "ingress-nginx-controller"},
+			{Kind: osmpolicyv1alpha1.KindIPRange, Name: "10.244.0.12"},
+			{Kind: "Pod", Name: "ingress-nginx-controller-7d9f"},
+		},
+	}
+
+	_, err := CreateIngressBackend(osmpolicyfake.NewSimpleClientset(), newK8sClient(), "httpbin",
+		&IngressBackendSpec{Name: "httpbin", Spec: invalid})
+	if statusError, ok := err.(*k8serrors.StatusError); !ok || statusError.ErrStatus.Code != http.StatusBadRequest {
+		t.Errorf("expected invalid ingress backend to be rejected, but got %v", err)
+	}
+
+	if problems := validateIngressBackendSpec(&invalid); len(problems) != 5 {
+		t.Errorf("expected five problems, but got %#v", problems)
+	}
+}
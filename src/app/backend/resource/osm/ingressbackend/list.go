@@ -0,0 +1,87 @@
+package ingressbackend
+
+import (
+	"log"
+
+	osmpolicyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	osmpolicyclientset "github.com/openservicemesh/osm/pkg/gen/client/policy/clientset/versioned"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+)
+
+// IngressBackend is a representation of an ingress backend policy.
+type IngressBackend struct {
+	ObjectMeta api.ObjectMeta `json:"objectMeta"`
+	TypeMeta   api.TypeMeta   `json:"typeMeta"`
+	// Spec is the IngressBackend policy specification.
+	Spec osmpolicyv1alpha1.IngressBackendSpec `json:"spec"`
+	// Status is the status reported by the OSM controller.
+	Status osmpolicyv1alpha1.IngressBackendStatus `json:"status"`
+}
+
+// IngressBackendList contains a list of IngressBackends in the cluster.
+type IngressBackendList struct {
+	ListMeta api.ListMeta `json:"listMeta"`
+
+	// Unordered list of ingress backends.
+	IngressBackends []IngressBackend `json:"ingressBackends"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// GetIngressBackendList returns a list of all IngressBackends in the cluster.
+func GetIngressBackendList(osmPolicyClient osmpolicyclientset.Interface, nsQuery *common.NamespaceQuery,
+	dsQuery *dataselect.DataSelectQuery) (*IngressBackendList, error) {
+	log.Print("Getting list of all ingress backends in the cluster")
+
+	channels := &common.ResourceChannels{
+		IngressBackendList: common.GetIngressBackendListChannel(osmPolicyClient, nsQuery, 1),
+	}
+
+	return GetIngressBackendListFromChannels(channels, dsQuery)
+}
+
+// GetIngressBackendListFromChannels returns a list of all IngressBackends in the cluster.
+func GetIngressBackendListFromChannels(channels *common.ResourceChannels,
+	dsQuery *dataselect.DataSelectQuery) (*IngressBackendList, error) {
+	ingressBackends := <-channels.IngressBackendList.List
+	err := <-channels.IngressBackendList.Error
+	nonCriticalErrors, criticalError := errors.HandleError(err)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	return CreateIngressBackendList(ingressBackends.Items, nonCriticalErrors, dsQuery), nil
+}
+
+func toIngressBackend(ingressBackend *osmpolicyv1alpha1.IngressBackend) IngressBackend {
+	return IngressBackend{
+		ObjectMeta: api.NewObjectMeta(ingressBackend.ObjectMeta),
+		TypeMeta:   api.NewTypeMeta(api.ResourceKindIngressBackend),
+		Spec:       ingressBackend.Spec,
+		Status:     ingressBackend.Status,
+	}
+}
+
+// CreateIngressBackendList returns paginated ingress backend list based on given ingress backend array and pagination query.
+func CreateIngressBackendList(ingressBackends []osmpolicyv1alpha1.IngressBackend, nonCriticalErrors []error, dsQuery *dataselect.DataSelectQuery) *IngressBackendList {
+	ingressBackendList := &IngressBackendList{
+		IngressBackends: make([]IngressBackend, 0),
+		ListMeta:        api.ListMeta{TotalItems: len(ingressBackends)},
+		Errors:          nonCriticalErrors,
+	}
+
+	ingressBackendCells, filteredTotal := dataselect.GenericDataSelectWithFilter(toCells(ingressBackends), dsQuery)
+	ingressBackends = fromCells(ingressBackendCells)
+	ingressBackendList.ListMeta = api.ListMeta{TotalItems: filteredTotal}
+
+	for i := range ingressBackends {
+		ingressBackendList.IngressBackends = append(ingressBackendList.IngressBackends, toIngressBackend(&ingressBackends[i]))
+	}
+
+	return ingressBackendList
+}
@@ -0,0 +1,229 @@
+package meshconfig
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/openservicemesh/osm/pkg/constants"
+	osmconfigclientset "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned"
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// defaultCertificateProvider is the certificate manager osm-controller uses when none is set.
+	defaultCertificateProvider = "tresor"
+
+	certificateProviderArg = "--certificate-manager"
+	caBundleSecretNameArg  = "--ca-bundle-secret-name"
+)
+
+// MeshCertificate is the certificate state of the mesh a MeshConfig belongs to.
+type MeshCertificate struct {
+	// Provider is the certificate manager of osm-controller, e.g. tresor, vault or cert-manager. It
+	// is empty when no osm-controller was found.
+	Provider string `json:"provider"`
+
+	// CABundleSecret is the name of the secret holding the CA bundle of the mesh.
+	CABundleSecret string `json:"caBundleSecret"`
+
+	// ServiceCertValidityDuration and CertKeyBitSize are the certificate settings of the MeshConfig.
+	ServiceCertValidityDuration string `json:"serviceCertValidityDuration"`
+	CertKeyBitSize              int    `json:"certKeyBitSize"`
+
+	// Certificates are the certificates of the CA bundle, in the order of the bundle.
+	Certificates []CertificateInfo `json:"certificates"`
+
+	// ExpiryWarningDays is the number of days before the expiry of a CA from which it is reported
+	// as expiring.
+	ExpiryWarningDays int `json:"expiryWarningDays"`
+
+	// Warnings are shown for expired or expiring certificates.
+	Warnings []string `json:"warnings"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// CertificateInfo describes a certificate of the CA bundle.
+type CertificateInfo struct {
+	Subject      string      `json:"subject"`
+	Issuer       string      `json:"issuer"`
+	SerialNumber string      `json:"serialNumber"`
+	IsCA         bool        `json:"isCA"`
+	NotBefore    metaV1.Time `json:"notBefore"`
+	NotAfter     metaV1.Time `json:"notAfter"`
+
+	// DaysUntilExpiry is negative when the certificate has expired.
+	DaysUntilExpiry int `json:"daysUntilExpiry"`
+
+	// KeyAlgorithm is the public key algorithm with its size or curve, e.g. RSA 2048 or ECDSA P-256.
+	KeyAlgorithm       string `json:"keyAlgorithm"`
+	SignatureAlgorithm string `json:"signatureAlgorithm"`
+
+	Expired  bool `json:"expired"`
+	Expiring bool `json:"expiring"`
+}
+
+// GetMeshCertificate returns the certificate state of the mesh of a MeshConfig. The provider and
+// the CA bundle secret are read from the arguments of the osm-controller in the namespace of the
+// MeshConfig.
+func GetMeshCertificate(osmConfigClient osmconfigclientset.Interface, client kubernetes.Interface,
+	namespace, name string, expiryWarningDays int) (*MeshCertificate, error) {
+	log.Printf("Getting certificate of %s meshconfig in %s namespace", name, namespace)
+
+	meshConfig, err := osmConfigClient.ConfigV1alpha2().MeshConfigs(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &MeshCertificate{
+		CABundleSecret:              constants.DefaultCABundleSecretName,
+		ServiceCertValidityDuration: meshConfig.Spec.Certificate.ServiceCertValidityDuration,
+		CertKeyBitSize:              meshConfig.Spec.Certificate.CertKeyBitSize,
+		Certificates:                make([]CertificateInfo, 0),
+		ExpiryWarningDays:           expiryWarningDays,
+		Warnings:                    make([]string, 0),
+		Errors:                      make([]error, 0),
+	}
+
+	deployments, err := client.AppsV1().Deployments(namespace).List(context.TODO(), metaV1.ListOptions{
+		LabelSelector: labels.Set{constants.AppLabel: constants.OSMControllerName}.String(),
+	})
+	nonCriticalErrors, criticalError := errors.HandleError(err)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+	result.Errors = append(result.Errors, nonCriticalErrors...)
+	if deployments != nil && len(deployments.Items) > 0 {
+		result.Provider = defaultCertificateProvider
+		for _, container := range deployments.Items[0].Spec.Template.Spec.Containers {
+			if provider := getArg(container.Args, certificateProviderArg); len(provider) > 0 {
+				result.Provider = provider
+			}
+			if secretName := getArg(container.Args, caBundleSecretNameArg); len(secretName) > 0 {
+				result.CABundleSecret = secretName
+			}
+		}
+	}
+
+	secret, err := client.CoreV1().Secrets(namespace).Get(context.TODO(), result.CABundleSecret, metaV1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("CA bundle secret %s not found in namespace %s",
+			result.CABundleSecret, namespace))
+		return result, nil
+	}
+	nonCriticalErrors, criticalError = errors.HandleError(err)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+	result.Errors = append(result.Errors, nonCriticalErrors...)
+	if secret == nil || err != nil {
+		return result, nil
+	}
+
+	certificates, err := parseCABundle(secret)
+	if err != nil {
+		return nil, errors.NewBadRequest(err.Error())
+	}
+
+	now := time.Now()
+	for _, certificate := range certificates {
+		info := toCertificateInfo(certificate, now, expiryWarningDays)
+		result.Certificates = append(result.Certificates, info)
+
+		switch {
+		case info.Expired:
+			result.Warnings = append(result.Warnings, fmt.Sprintf("Certificate %s expired on %s",
+				info.Subject, info.NotAfter.Format(time.RFC3339)))
+		case info.Expiring && info.IsCA:
+			result.Warnings = append(result.Warnings, fmt.Sprintf("Root CA %s expires in %d days, on %s",
+				info.Subject, info.DaysUntilExpiry, info.NotAfter.Format(time.RFC3339)))
+		}
+	}
+
+	return result, nil
+}
+
+// parseCABundle returns the certificates of the CA bundle of a secret.
+func parseCABundle(secret *v1.Secret) ([]*x509.Certificate, error) {
+	data, ok := secret.Data[constants.KubernetesOpaqueSecretCAKey]
+	if !ok {
+		return nil, fmt.Errorf("secret %s has no %s key", secret.Name, constants.KubernetesOpaqueSecretCAKey)
+	}
+
+	result := make([]*x509.Certificate, 0)
+	for block, rest := pem.Decode(data); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid certificate in secret %s: %s", secret.Name, err)
+		}
+		result = append(result, certificate)
+	}
+
+	if len(result) == 0 {
+		return nil, fmt.Errorf("secret %s has no certificate in %s", secret.Name, constants.KubernetesOpaqueSecretCAKey)
+	}
+	return result, nil
+}
+
+func toCertificateInfo(certificate *x509.Certificate, now time.Time, expiryWarningDays int) CertificateInfo {
+	daysUntilExpiry := int(math.Floor(certificate.NotAfter.Sub(now).Hours() / 24))
+	return CertificateInfo{
+		Subject:            certificate.Subject.String(),
+		Issuer:             certificate.Issuer.String(),
+		SerialNumber:       strings.ToUpper(hex.EncodeToString(certificate.SerialNumber.Bytes())),
+		IsCA:               certificate.IsCA,
+		NotBefore:          metaV1.NewTime(certificate.NotBefore),
+		NotAfter:           metaV1.NewTime(certificate.NotAfter),
+		DaysUntilExpiry:    daysUntilExpiry,
+		KeyAlgorithm:       getKeyAlgorithm(certificate),
+		SignatureAlgorithm: certificate.SignatureAlgorithm.String(),
+		Expired:            !now.Before(certificate.NotAfter),
+		Expiring:           now.Before(certificate.NotAfter) && daysUntilExpiry < expiryWarningDays,
+	}
+}
+
+func getKeyAlgorithm(certificate *x509.Certificate) string {
+	switch key := certificate.PublicKey.(type) {
+	case *rsa.PublicKey:
+		return fmt.Sprintf("RSA %d", key.N.BitLen())
+	case *ecdsa.PublicKey:
+		return fmt.Sprintf("ECDSA %s", key.Curve.Params().Name)
+	case ed25519.PublicKey:
+		return "Ed25519"
+	default:
+		return certificate.PublicKeyAlgorithm.String()
+	}
+}
+
+// getArg returns the value of a flag in container arguments, given as --flag=value or --flag value.
+func getArg(args []string, flag string) string {
+	for i, arg := range args {
+		if strings.HasPrefix(arg, flag+"=") {
+			return strings.TrimPrefix(arg, flag+"=")
+		}
+		if arg == flag && i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,116 @@
+package meshconfig
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	osmconfigv1alph2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	"github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned/fake"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+)
+
+func newCABundle(t *testing.T, notAfter time.Time) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(0x2a),
+		Subject:               pkix.Name{CommonName: "osm-ca.openservicemesh.io", Organization: []string{"Open Service Mesh"}},
+		NotBefore:             notAfter.Add(-365 * 24 * time.Hour),
+		NotAfter:              notAfter,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func newController(args ...string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metaV1.ObjectMeta{Name: "osm-controller", Namespace: "osm-system", Labels: map[string]string{"app": "osm-controller"}},
+		Spec: appsv1.DeploymentSpec{Template: v1.PodTemplateSpec{Spec: v1.PodSpec{
+			Containers: []v1.Container{{Name: "osm-controller", Args: args}},
+		}}},
+	}
+}
+
+func TestGetMeshCertificate(t *testing.T) {
+	meshConfig := newMeshConfig()
+	meshConfig.Spec.Certificate = osmconfigv1alph2.CertificateSpec{ServiceCertValidityDuration: "24h", CertKeyBitSize: 2048}
+	osmConfigClient := fake.NewSimpleClientset(meshConfig)
+
+	client := k8sfake.NewSimpleClientset(
+		newController("--mesh-name", "osm", "--ca-bundle-secret-name=mesh-ca", "--certificate-manager", "cert-manager"),
+		&v1.Secret{
+			ObjectMeta: metaV1.ObjectMeta{Name: "mesh-ca", Namespace: "osm-system"},
+			Data:       map[string][]byte{"ca.crt": newCABundle(t, time.Now().Add(10*24*time.Hour+time.Hour))},
+		},
+	)
+
+	result, err := GetMeshCertificate(osmConfigClient, client, "osm-system", "osm-mesh-config", 30)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if result.Provider != "cert-manager" || result.CABundleSecret != "mesh-ca" {
+		t.Errorf("expected the provider and secret of osm-controller, but got %s and %s", result.Provider, result.CABundleSecret)
+	}
+	if result.ServiceCertValidityDuration != "24h" || result.CertKeyBitSize != 2048 {
+		t.Errorf("expected the certificate settings of the meshconfig, but got %#v", result)
+	}
+
+	if len(result.Certificates) != 1 {
+		t.Fatalf("expected a certificate, but got %#v", result.Certificates)
+	}
+	certificate := result.Certificates[0]
+	if certificate.Subject != "CN=osm-ca.openservicemesh.io,O=Open Service Mesh" || certificate.Issuer != certificate.Subject {
+		t.Errorf("expected a self-signed root CA, but got subject %s and issuer %s", certificate.Subject, certificate.Issuer)
+	}
+	if certificate.KeyAlgorithm != "ECDSA P-256" || certificate.SerialNumber != "2A" || !certificate.IsCA {
+		t.Errorf("unexpected certificate %#v", certificate)
+	}
+	if certificate.DaysUntilExpiry != 10 || !certificate.Expiring || certificate.Expired {
+		t.Errorf("expected the root CA to expire in 10 days, but got %#v", certificate)
+	}
+	if len(result.Warnings) != 1 {
+		t.Errorf("expected an expiry warning, but got %v", result.Warnings)
+	}
+
+	result, err = GetMeshCertificate(osmConfigClient, client, "osm-system", "osm-mesh-config", 7)
+	if err != nil || len(result.Warnings) != 0 || result.Certificates[0].Expiring {
+		t.Errorf("expected no warning below the threshold, but got %v, %v", result.Warnings, err)
+	}
+}
+
+func TestGetMeshCertificateWithoutCABundle(t *testing.T) {
+	osmConfigClient := fake.NewSimpleClientset(newMeshConfig())
+	client := k8sfake.NewSimpleClientset(newController("--mesh-name", "osm"))
+
+	result, err := GetMeshCertificate(osmConfigClient, client, "osm-system", "osm-mesh-config", 30)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if result.Provider != "tresor" || result.CABundleSecret != "osm-ca-bundle" {
+		t.Errorf("expected the default provider and secret, but got %s and %s", result.Provider, result.CABundleSecret)
+	}
+	if len(result.Certificates) != 0 || len(result.Warnings) != 1 {
+		t.Errorf("expected a missing secret warning, but got %#v", result)
+	}
+}
@@ -0,0 +1,239 @@
+package meshconfig
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	osmconfigv1alph2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	osmconfigclientset "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned"
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	// HistoryConfigMapName is the name of the config map in the Dashboard namespace that stores the
+	// revisions of MeshConfig specs.
+	HistoryConfigMapName = "kubernetes-dashboard-meshconfig-history"
+
+	// maxMeshConfigRevisions is the number of revisions kept for a single MeshConfig, so that the
+	// history config map stays far below the size limit of config maps.
+	maxMeshConfigRevisions = 30
+)
+
+// MeshConfigRevision is a recorded spec of a MeshConfig. The first revision of a MeshConfig is
+// the spec it had before it was first updated through Dashboard, so it has no author and changes.
+type MeshConfigRevision struct {
+	Revision  int         `json:"revision"`
+	Author    string      `json:"author"`
+	Timestamp metaV1.Time `json:"timestamp"`
+
+	// RollbackOf is the revision this revision restored, if it was created by a rollback.
+	RollbackOf int `json:"rollbackOf,omitempty"`
+
+	// Changes are the fields changed compared to the previous revision.
+	Changes []FieldChange `json:"changes"`
+
+	// Spec is omitted in revision lists.
+	Spec *osmconfigv1alph2.MeshConfigSpec `json:"spec,omitempty"`
+}
+
+// MeshConfigRevisionList contains the revisions of a MeshConfig, oldest first.
+type MeshConfigRevisionList struct {
+	ListMeta  api.ListMeta         `json:"listMeta"`
+	Revisions []MeshConfigRevision `json:"revisions"`
+}
+
+// MeshConfigRollback is a request to restore a revision of a MeshConfig.
+type MeshConfigRollback struct {
+	// ResourceVersion is the version of the MeshConfig the rollback was requested on. The rollback
+	// is rejected when the MeshConfig has changed since.
+	ResourceVersion string `json:"resourceVersion"`
+}
+
+// MeshConfigHistory stores the revisions of MeshConfig specs in a config map.
+type MeshConfigHistory struct {
+	client    kubernetes.Interface
+	namespace string
+}
+
+// NewMeshConfigHistory creates a history kept in the config map of the given namespace.
+func NewMeshConfigHistory(client kubernetes.Interface, namespace string) *MeshConfigHistory {
+	return &MeshConfigHistory{client: client, namespace: namespace}
+}
+
+// GetMeshConfigRevisionList returns the recorded revisions of a MeshConfig without their specs.
+// The history is only shown to users that can read the MeshConfig.
+func GetMeshConfigRevisionList(osmConfigClient osmconfigclientset.Interface, history *MeshConfigHistory, namespace,
+	name string) (*MeshConfigRevisionList, error) {
+	revisions, err := history.readableRevisions(osmConfigClient, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &MeshConfigRevisionList{
+		ListMeta:  api.ListMeta{TotalItems: len(revisions)},
+		Revisions: make([]MeshConfigRevision, 0, len(revisions)),
+	}
+	for _, revision := range revisions {
+		revision.Spec = nil
+		result.Revisions = append(result.Revisions, revision)
+	}
+
+	return result, nil
+}
+
+// GetMeshConfigRevision returns a recorded revision of a MeshConfig including its spec.
+func GetMeshConfigRevision(osmConfigClient osmconfigclientset.Interface, history *MeshConfigHistory, namespace,
+	name string, revision int) (*MeshConfigRevision, error) {
+	revisions, err := history.readableRevisions(osmConfigClient, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range revisions {
+		if revisions[i].Revision == revision {
+			return &revisions[i], nil
+		}
+	}
+
+	return nil, errors.NewNotFound(fmt.Sprintf("revision %d of meshconfig %s in namespace %s not found", revision, name, namespace))
+}
+
+// RollbackMeshConfig applies the spec of a recorded revision to a MeshConfig. The rollback is
+// validated like any other update and recorded as a new revision.
+func RollbackMeshConfig(osmConfigClient osmconfigclientset.Interface, history *MeshConfigHistory, author, namespace,
+	name string, revision int, rollback *MeshConfigRollback) (*MeshConfigUpdateResult, error) {
+	log.Printf("Rolling back %s meshconfig in %s namespace to revision %d", name, namespace, revision)
+
+	target, err := GetMeshConfigRevision(osmConfigClient, history, namespace, name, revision)
+	if err != nil {
+		return nil, err
+	}
+
+	spec, err := json.Marshal(target.Spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return updateMeshConfig(osmConfigClient, history, author, namespace, name,
+		&MeshConfigUpdate{ResourceVersion: rollback.ResourceVersion, Spec: spec}, revision)
+}
+
+// record appends a revision of a MeshConfig to the history. The spec before the update is recorded
+// first when the MeshConfig has no history yet.
+func (h *MeshConfigHistory) record(author, namespace, name string, oldSpec, newSpec *osmconfigv1alph2.MeshConfigSpec,
+	changes []FieldChange, rollbackOf int) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		configMap, err := h.getOrCreateConfigMap()
+		if err != nil {
+			return err
+		}
+
+		revisions, err := decodeRevisions(configMap, namespace, name)
+		if err != nil {
+			return err
+		}
+
+		now := metaV1.NewTime(time.Now().UTC())
+		if len(revisions) == 0 {
+			revisions = append(revisions, MeshConfigRevision{
+				Revision:  1,
+				Timestamp: now,
+				Changes:   make([]FieldChange, 0),
+				Spec:      oldSpec,
+			})
+		}
+
+		latest := revisions[len(revisions)-1].Revision + 1
+		revisions = append(revisions, MeshConfigRevision{
+			Revision:   latest,
+			Author:     author,
+			Timestamp:  now,
+			RollbackOf: rollbackOf,
+			Changes:    changes,
+			Spec:       newSpec,
+		})
+
+		if len(revisions) > maxMeshConfigRevisions {
+			revisions = revisions[len(revisions)-maxMeshConfigRevisions:]
+		}
+
+		data, err := json.Marshal(revisions)
+		if err != nil {
+			return err
+		}
+
+		if configMap.Data == nil {
+			configMap.Data = make(map[string]string)
+		}
+		configMap.Data[historyKey(namespace, name)] = string(data)
+
+		_, err = h.client.CoreV1().ConfigMaps(h.namespace).Update(context.TODO(), configMap, metaV1.UpdateOptions{})
+		return err
+	})
+}
+
+// readableRevisions returns the revisions of a MeshConfig if the client can read the MeshConfig,
+// as the history config map itself is read with the privileges of Dashboard.
+func (h *MeshConfigHistory) readableRevisions(osmConfigClient osmconfigclientset.Interface, namespace,
+	name string) ([]MeshConfigRevision, error) {
+	_, err := osmConfigClient.ConfigV1alpha2().MeshConfigs(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return h.revisions(namespace, name)
+}
+
+func (h *MeshConfigHistory) revisions(namespace, name string) ([]MeshConfigRevision, error) {
+	configMap, err := h.client.CoreV1().ConfigMaps(h.namespace).Get(context.TODO(), HistoryConfigMapName, metaV1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return make([]MeshConfigRevision, 0), nil
+		}
+		return nil, err
+	}
+
+	return decodeRevisions(configMap, namespace, name)
+}
+
+func (h *MeshConfigHistory) getOrCreateConfigMap() (*v1.ConfigMap, error) {
+	configMap, err := h.client.CoreV1().ConfigMaps(h.namespace).Get(context.TODO(), HistoryConfigMapName, metaV1.GetOptions{})
+	if err == nil || !k8serrors.IsNotFound(err) {
+		return configMap, err
+	}
+
+	log.Printf("Creating %s config map in %s namespace", HistoryConfigMapName, h.namespace)
+	return h.client.CoreV1().ConfigMaps(h.namespace).Create(context.TODO(), &v1.ConfigMap{
+		ObjectMeta: metaV1.ObjectMeta{Name: HistoryConfigMapName, Namespace: h.namespace},
+	}, metaV1.CreateOptions{})
+}
+
+func decodeRevisions(configMap *v1.ConfigMap, namespace, name string) ([]MeshConfigRevision, error) {
+	revisions := make([]MeshConfigRevision, 0)
+
+	data, ok := configMap.Data[historyKey(namespace, name)]
+	if !ok {
+		return revisions, nil
+	}
+
+	if err := json.Unmarshal([]byte(data), &revisions); err != nil {
+		return nil, fmt.Errorf("history of meshconfig %s in namespace %s is corrupted: %w", name, namespace, err)
+	}
+
+	return revisions, nil
+}
+
+// historyKey returns the config map key of a MeshConfig. Namespace names cannot contain dots, so
+// the key is unambiguous.
+func historyKey(namespace, name string) string {
+	return namespace + "." + name
+}
@@ -0,0 +1,91 @@
+package meshconfig
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned/fake"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestMeshConfigHistory(t *testing.T) {
+	client := fake.NewSimpleClientset(newMeshConfig())
+	history := NewMeshConfigHistory(k8sfake.NewSimpleClientset(), "kubernetes-dashboard")
+
+	update := &MeshConfigUpdate{
+		ResourceVersion: "7",
+		Spec: json.RawMessage(`{"sidecar": {"logLevel": "debug"},
+			"traffic": {"enableEgress": true, "enablePermissiveTrafficPolicyMode": true}}`),
+	}
+	if _, err := UpdateMeshConfig(client, history, "alice", "osm-system", "osm-mesh-config", update); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	list, err := GetMeshConfigRevisionList(client, history, "osm-system", "osm-mesh-config")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if list.ListMeta.TotalItems != 2 {
+		t.Fatalf("expected baseline and updated revisions, but got %#v", list.Revisions)
+	}
+
+	latest := list.Revisions[1]
+	if latest.Revision != 2 || latest.Author != "alice" || len(latest.Changes) != 1 || latest.Spec != nil {
+		t.Errorf("expected revision 2 of alice with a single change and without spec, but got %#v", latest)
+	}
+
+	baseline, err := GetMeshConfigRevision(client, history, "osm-system", "osm-mesh-config", 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if baseline.Author != "" || baseline.Spec == nil || baseline.Spec.Sidecar.LogLevel != "error" {
+		t.Errorf("expected baseline revision with the original spec, but got %#v", baseline)
+	}
+
+	// The fake clientset does not bump resource versions, so the version stays the same.
+	result, err := RollbackMeshConfig(client, history, "bob", "osm-system", "osm-mesh-config", 1,
+		&MeshConfigRollback{ResourceVersion: "7"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if !result.Applied || result.MeshConfig.Spec.Sidecar.LogLevel != "error" {
+		t.Errorf("expected rollback to restore log level error, but got %#v", result)
+	}
+
+	rolledBack, err := GetMeshConfigRevision(client, history, "osm-system", "osm-mesh-config", 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if rolledBack.Author != "bob" || rolledBack.RollbackOf != 1 {
+		t.Errorf("expected revision 3 to be a rollback of bob to revision 1, but got %#v", rolledBack)
+	}
+}
+
+func TestGetMeshConfigRevisionNotFound(t *testing.T) {
+	client := fake.NewSimpleClientset(newMeshConfig())
+	history := NewMeshConfigHistory(k8sfake.NewSimpleClientset(), "kubernetes-dashboard")
+
+	_, err := GetMeshConfigRevision(client, history, "osm-system", "osm-mesh-config", 1)
+	statusError, ok := err.(*k8serrors.StatusError)
+	if !ok || statusError.ErrStatus.Code != http.StatusNotFound {
+		t.Errorf("expected not found error, but got %v", err)
+	}
+
+	_, err = GetMeshConfigRevisionList(client, history, "osm-system", "missing")
+	if !k8serrors.IsNotFound(err) {
+		t.Errorf("expected history of a missing meshconfig not to be readable, but got %v", err)
+	}
+
+	if _, err := history.client.CoreV1().ConfigMaps("kubernetes-dashboard").Get(context.TODO(),
+		HistoryConfigMapName, metaV1.GetOptions{}); !k8serrors.IsNotFound(err) {
+		t.Errorf("expected reads not to create the history config map, but got %v", err)
+	}
+}
@@ -0,0 +1,360 @@
+package meshconfig
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	osmconfigv1alph2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	osmconfigclientset "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var (
+	sidecarLogLevelPattern = regexp.MustCompile(`^(trace|debug|info|warning|warn|error|critical|off)$`)
+	osmLogLevelPattern     = regexp.MustCompile(`^(debug|info|warn|error|fatal|panic|disabled|trace)$`)
+	tlsVersionPattern      = regexp.MustCompile(`^(TLS_AUTO|TLSv1_0|TLSv1_1|TLSv1_2|TLSv1_3)$`)
+)
+
+// FieldChangeType describes how a field of a MeshConfig spec changes.
+type FieldChangeType string
+
+const (
+	FieldAdded    FieldChangeType = "added"
+	FieldRemoved  FieldChangeType = "removed"
+	FieldModified FieldChangeType = "modified"
+)
+
+// MeshConfigUpdate is a change of the spec of a MeshConfig.
+type MeshConfigUpdate struct {
+	// ResourceVersion is the version of the MeshConfig the change was made on. The update is
+	// rejected when the MeshConfig has changed since.
+	ResourceVersion string `json:"resourceVersion"`
+
+	// Spec is the complete new MeshConfig spec.
+	Spec json.RawMessage `json:"spec"`
+}
+
+// FieldChange is a single changed field of a MeshConfig spec, addressed by its dotted path.
+type FieldChange struct {
+	Path     string          `json:"path"`
+	Change   FieldChangeType `json:"change"`
+	OldValue interface{}     `json:"oldValue,omitempty"`
+	NewValue interface{}     `json:"newValue,omitempty"`
+}
+
+// MeshConfigUpdateResult describes an update of a MeshConfig.
+type MeshConfigUpdateResult struct {
+	// MeshConfig is the MeshConfig after the update, or the current MeshConfig when the update was
+	// not applied.
+	MeshConfig *MeshConfigDetail `json:"meshConfig"`
+
+	// Applied is true when the update was written to the cluster.
+	Applied bool `json:"applied"`
+
+	Changes []FieldChange `json:"changes"`
+
+	// Warnings describe changes that may disrupt the traffic of the mesh.
+	Warnings []string `json:"warnings"`
+}
+
+// meshConfigWarning flags a dangerous change of a MeshConfig spec.
+type meshConfigWarning struct {
+	applies func(oldSpec, newSpec *osmconfigv1alph2.MeshConfigSpec) bool
+	message string
+}
+
+var meshConfigWarnings = []meshConfigWarning{
+	{
+		applies: func(oldSpec, newSpec *osmconfigv1alph2.MeshConfigSpec) bool {
+			return oldSpec.Traffic.EnablePermissiveTrafficPolicyMode && !newSpec.Traffic.EnablePermissiveTrafficPolicyMode
+		},
+		message: "Turning off permissive traffic policy mode blocks all traffic that is not allowed by an SMI TrafficTarget.",
+	},
+	{
+		applies: func(oldSpec, newSpec *osmconfigv1alph2.MeshConfigSpec) bool {
+			return oldSpec.Traffic.EnableEgress && !newSpec.Traffic.EnableEgress
+		},
+		message: "Turning off egress blocks traffic to destinations outside the mesh that are not allowed by an Egress policy.",
+	},
+	{
+		applies: func(oldSpec, newSpec *osmconfigv1alph2.MeshConfigSpec) bool {
+			return oldSpec.FeatureFlags.EnableEgressPolicy && !newSpec.FeatureFlags.EnableEgressPolicy
+		},
+		message: "Turning off the egress policy feature ignores all existing Egress policies.",
+	},
+	{
+		applies: func(oldSpec, newSpec *osmconfigv1alph2.MeshConfigSpec) bool {
+			return !oldSpec.Traffic.InboundExternalAuthorization.FailureModeAllow &&
+				newSpec.Traffic.InboundExternalAuthorization.FailureModeAllow
+		},
+		message: "Allowing traffic when the external authorization service fails lets unauthorized requests through.",
+	},
+	{
+		applies: func(oldSpec, newSpec *osmconfigv1alph2.MeshConfigSpec) bool {
+			return !oldSpec.Observability.EnableDebugServer && newSpec.Observability.EnableDebugServer
+		},
+		message: "The debug server exposes the internal state of the control plane.",
+	},
+	{
+		applies: func(oldSpec, newSpec *osmconfigv1alph2.MeshConfigSpec) bool {
+			return oldSpec.Sidecar.SidecarImage != newSpec.Sidecar.SidecarImage ||
+				oldSpec.Sidecar.InitContainerImage != newSpec.Sidecar.InitContainerImage ||
+				oldSpec.Sidecar.EnablePrivilegedInitContainer != newSpec.Sidecar.EnablePrivilegedInitContainer ||
+				!reflect.DeepEqual(oldSpec.Traffic.OutboundIPRangeExclusionList, newSpec.Traffic.OutboundIPRangeExclusionList) ||
+				!reflect.DeepEqual(oldSpec.Traffic.OutboundPortExclusionList, newSpec.Traffic.OutboundPortExclusionList) ||
+				!reflect.DeepEqual(oldSpec.Traffic.InboundPortExclusionList, newSpec.Traffic.InboundPortExclusionList)
+		},
+		message: "Sidecar and traffic interception changes only apply to newly injected pods. Restart the workloads of the mesh to apply them.",
+	},
+	{
+		applies: func(oldSpec, newSpec *osmconfigv1alph2.MeshConfigSpec) bool {
+			return oldSpec.Certificate.ServiceCertValidityDuration != newSpec.Certificate.ServiceCertValidityDuration ||
+				oldSpec.Certificate.CertKeyBitSize != newSpec.Certificate.CertKeyBitSize
+		},
+		message: "Certificate changes apply when the service certificates are rotated next.",
+	},
+}
+
+// PreviewMeshConfigUpdate validates an update of a MeshConfig and returns the changed fields and
+// warnings without applying it.
+func PreviewMeshConfigUpdate(osmConfigClient osmconfigclientset.Interface, namespace, name string,
+	update *MeshConfigUpdate) (*MeshConfigUpdateResult, error) {
+	current, newSpec, err := prepareMeshConfigUpdate(osmConfigClient, namespace, name, update)
+	if err != nil {
+		return nil, err
+	}
+
+	return toMeshConfigUpdateResult(current, &current.Spec, newSpec, false)
+}
+
+// UpdateMeshConfig validates and applies an update of a MeshConfig. The applied spec is recorded
+// in the history as a revision of the author, unless history is nil.
+func UpdateMeshConfig(osmConfigClient osmconfigclientset.Interface, history *MeshConfigHistory, author, namespace,
+	name string, update *MeshConfigUpdate) (*MeshConfigUpdateResult, error) {
+	log.Printf("Updating %s meshconfig in %s namespace", name, namespace)
+	return updateMeshConfig(osmConfigClient, history, author, namespace, name, update, 0)
+}
+
+func updateMeshConfig(osmConfigClient osmconfigclientset.Interface, history *MeshConfigHistory, author, namespace,
+	name string, update *MeshConfigUpdate, rollbackOf int) (*MeshConfigUpdateResult, error) {
+
+	current, newSpec, err := prepareMeshConfigUpdate(osmConfigClient, namespace, name, update)
+	if err != nil {
+		return nil, err
+	}
+
+	oldSpec := current.Spec.DeepCopy()
+	current.Spec = *newSpec
+
+	updated, err := osmConfigClient.ConfigV1alpha2().MeshConfigs(namespace).Update(context.TODO(), current, metaV1.UpdateOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("Successfully updated %s meshconfig in %s namespace", name, namespace)
+	result, err := toMeshConfigUpdateResult(updated, oldSpec, newSpec, true)
+	if err != nil || history == nil {
+		return result, err
+	}
+
+	// The update is already applied, so a failure to record it is only reported as a warning.
+	if err := history.record(author, namespace, name, oldSpec, newSpec, result.Changes, rollbackOf); err != nil {
+		log.Printf("Failed to record revision of %s meshconfig in %s namespace: %s", name, namespace, err)
+		result.Warnings = append(result.Warnings, fmt.Sprintf("The update was applied, but could not be recorded in the revision history: %s", err))
+	}
+
+	return result, nil
+}
+
+// prepareMeshConfigUpdate reads the current MeshConfig, checks that it was not changed since the
+// update was made and decodes the new spec.
+func prepareMeshConfigUpdate(osmConfigClient osmconfigclientset.Interface, namespace, name string,
+	update *MeshConfigUpdate) (*osmconfigv1alph2.MeshConfig, *osmconfigv1alph2.MeshConfigSpec, error) {
+	current, err := osmConfigClient.ConfigV1alpha2().MeshConfigs(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(update.ResourceVersion) == 0 {
+		return nil, nil, errors.NewBadRequest("resourceVersion is required")
+	}
+
+	if update.ResourceVersion != current.ResourceVersion {
+		return nil, nil, errors.NewGenericResponse(http.StatusConflict, fmt.Sprintf(
+			"meshconfig %s in namespace %s was changed since it was read (resourceVersion %s, current %s), reload it and retry",
+			name, namespace, update.ResourceVersion, current.ResourceVersion))
+	}
+
+	newSpec, err := decodeMeshConfigSpec(update.Spec)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return current, newSpec, nil
+}
+
+// decodeMeshConfigSpec decodes a MeshConfig spec, rejecting unknown fields and invalid values.
+func decodeMeshConfigSpec(raw json.RawMessage) (*osmconfigv1alph2.MeshConfigSpec, error) {
+	if len(bytes.TrimSpace(raw)) == 0 {
+		return nil, errors.NewBadRequest("spec is required")
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+
+	spec := new(osmconfigv1alph2.MeshConfigSpec)
+	if err := decoder.Decode(spec); err != nil {
+		return nil, errors.NewBadRequest(fmt.Sprintf("invalid meshconfig spec: %s", err))
+	}
+
+	if problems := validateMeshConfigSpec(spec); len(problems) > 0 {
+		return nil, errors.NewBadRequest(fmt.Sprintf("invalid meshconfig spec: %s", strings.Join(problems, "; ")))
+	}
+
+	return spec, nil
+}
+
+// validateMeshConfigSpec returns the fields of a MeshConfig spec that hold invalid values.
+func validateMeshConfigSpec(spec *osmconfigv1alph2.MeshConfigSpec) []string {
+	var problems []string
+
+	matches := func(path, value string, pattern *regexp.Regexp) {
+		if len(value) > 0 && !pattern.MatchString(value) {
+			problems = append(problems, fmt.Sprintf("%s: %q must match %s", path, value, pattern))
+		}
+	}
+	duration := func(path, value string) {
+		if len(value) == 0 {
+			return
+		}
+		if _, err := time.ParseDuration(value); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %s", path, err))
+		}
+	}
+	ports := func(path string, values []int) {
+		for _, port := range values {
+			if port < 1 || port > 65535 {
+				problems = append(problems, fmt.Sprintf("%s: %d is not a valid port", path, port))
+			}
+		}
+	}
+	cidrs := func(path string, values []string) {
+		for _, value := range values {
+			if _, _, err := net.ParseCIDR(value); err != nil {
+				problems = append(problems, fmt.Sprintf("%s: %q is not a valid CIDR", path, value))
+			}
+		}
+	}
+
+	matches("sidecar.logLevel", spec.Sidecar.LogLevel, sidecarLogLevelPattern)
+	matches("sidecar.tlsMinProtocolVersion", spec.Sidecar.TLSMinProtocolVersion, tlsVersionPattern)
+	matches("sidecar.tlsMaxProtocolVersion", spec.Sidecar.TLSMaxProtocolVersion, tlsVersionPattern)
+	matches("observability.osmLogLevel", spec.Observability.OSMLogLevel, osmLogLevelPattern)
+
+	duration("sidecar.configResyncInterval", spec.Sidecar.ConfigResyncInterval)
+	duration("traffic.inboundExternalAuthorization.timeout", spec.Traffic.InboundExternalAuthorization.Timeout)
+	duration("certificate.serviceCertValidityDuration", spec.Certificate.ServiceCertValidityDuration)
+	if spec.Certificate.IngressGateway != nil {
+		duration("certificate.ingressGateway.validityDuration", spec.Certificate.IngressGateway.ValidityDuration)
+	}
+
+	ports("traffic.outboundPortExclusionList", spec.Traffic.OutboundPortExclusionList)
+	ports("traffic.inboundPortExclusionList", spec.Traffic.InboundPortExclusionList)
+	cidrs("traffic.outboundIPRangeExclusionList", spec.Traffic.OutboundIPRangeExclusionList)
+	cidrs("traffic.outboundIPRangeInclusionList", spec.Traffic.OutboundIPRangeInclusionList)
+
+	if spec.Sidecar.MaxDataPlaneConnections < 0 {
+		problems = append(problems, "sidecar.maxDataPlaneConnections: must not be negative")
+	}
+	if spec.Certificate.CertKeyBitSize < 0 {
+		problems = append(problems, "certificate.certKeyBitSize: must not be negative")
+	}
+	if spec.Observability.Tracing.Enable && spec.Observability.Tracing.Port <= 0 {
+		problems = append(problems, "observability.tracing.port: must be set when tracing is enabled")
+	}
+
+	return problems
+}
+
+func toMeshConfigUpdateResult(meshConfig *osmconfigv1alph2.MeshConfig, oldSpec, newSpec *osmconfigv1alph2.MeshConfigSpec,
+	applied bool) (*MeshConfigUpdateResult, error) {
+	changes, err := diffMeshConfigSpecs(oldSpec, newSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	warnings := make([]string, 0)
+	for _, warning := range meshConfigWarnings {
+		if warning.applies(oldSpec, newSpec) {
+			warnings = append(warnings, warning.message)
+		}
+	}
+
+	return &MeshConfigUpdateResult{
+		MeshConfig: getMeshConfigDetail(meshConfig),
+		Applied:    applied,
+		Changes:    changes,
+		Warnings:   warnings,
+	}, nil
+}
+
+// diffMeshConfigSpecs returns the fields that differ between two MeshConfig specs, sorted by path.
+func diffMeshConfigSpecs(oldSpec, newSpec *osmconfigv1alph2.MeshConfigSpec) ([]FieldChange, error) {
+	oldFields, err := flattenSpec(oldSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	newFields, err := flattenSpec(newSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]FieldChange, 0)
+	for path, oldValue := range oldFields {
+		newValue, ok := newFields[path]
+		switch {
+		case !ok:
+			changes = append(changes, FieldChange{Path: path, Change: FieldRemoved, OldValue: oldValue})
+		case !reflect.DeepEqual(oldValue, newValue):
+			changes = append(changes, FieldChange{Path: path, Change: FieldModified, OldValue: oldValue, NewValue: newValue})
+		}
+	}
+
+	for path, newValue := range newFields {
+		if _, ok := oldFields[path]; !ok {
+			changes = append(changes, FieldChange{Path: path, Change: FieldAdded, NewValue: newValue})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes, nil
+}
+
+// flattenSpec returns the leaves of the JSON representation of a spec keyed by their dotted path.
+// Lists are treated as leaves.
+func flattenSpec(spec *osmconfigv1alph2.MeshConfigSpec) (map[string]interface{}, error) {
+	raw, err := json.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	var document map[string]interface{}
+	if err := json.Unmarshal(raw, &document); err != nil {
+		return nil, err
+	}
+
+	return common.FlattenValues(document), nil
+}
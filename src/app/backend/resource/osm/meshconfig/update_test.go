@@ -0,0 +1,132 @@
+package meshconfig
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+
+	osmconfigv1alph2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	"github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned/fake"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newMeshConfig() *osmconfigv1alph2.MeshConfig {
+	return &osmconfigv1alph2.MeshConfig{
+		ObjectMeta: metaV1.ObjectMeta{Name: "osm-mesh-config", Namespace: "osm-system", ResourceVersion: "7"},
+		Spec: osmconfigv1alph2.MeshConfigSpec{
+			Sidecar: osmconfigv1alph2.SidecarSpec{LogLevel: "error"},
+			Traffic: osmconfigv1alph2.TrafficSpec{EnablePermissiveTrafficPolicyMode: true, EnableEgress: true},
+		},
+	}
+}
+
+func TestPreviewMeshConfigUpdate(t *testing.T) {
+	client := fake.NewSimpleClientset(newMeshConfig())
+
+	update := &MeshConfigUpdate{
+		ResourceVersion: "7",
+		Spec:            json.RawMessage(`{"sidecar": {"logLevel": "debug"}, "traffic": {"enableEgress": true}}`),
+	}
+
+	result, err := PreviewMeshConfigUpdate(client, "osm-system", "osm-mesh-config", update)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []FieldChange{
+		{Path: "sidecar.logLevel", Change: FieldModified, OldValue: "error", NewValue: "debug"},
+		{Path: "traffic.enablePermissiveTrafficPolicyMode", Change: FieldModified, OldValue: true, NewValue: false},
+	}
+	if !reflect.DeepEqual(result.Changes, expected) {
+		t.Errorf("expected %#v, but got %#v", expected, result.Changes)
+	}
+
+	if len(result.Warnings) != 1 {
+		t.Errorf("expected a permissive mode warning, but got %#v", result.Warnings)
+	}
+
+	if result.Applied {
+		t.Error("expected preview not to be applied")
+	}
+
+	current, _ := client.ConfigV1alpha2().MeshConfigs("osm-system").Get(context.TODO(), "osm-mesh-config", metaV1.GetOptions{})
+	if current.Spec.Sidecar.LogLevel != "error" {
+		t.Errorf("expected preview not to change the meshconfig, but got log level %s", current.Spec.Sidecar.LogLevel)
+	}
+}
+
+func TestUpdateMeshConfig(t *testing.T) {
+	client := fake.NewSimpleClientset(newMeshConfig())
+
+	update := &MeshConfigUpdate{
+		ResourceVersion: "7",
+		Spec: json.RawMessage(`{"sidecar": {"logLevel": "debug"},
+			"traffic": {"enableEgress": true, "enablePermissiveTrafficPolicyMode": true}}`),
+	}
+
+	result, err := UpdateMeshConfig(client, nil, "", "osm-system", "osm-mesh-config", update)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if !result.Applied || len(result.Warnings) != 0 || len(result.Changes) != 1 {
+		t.Errorf("expected a single applied change without warnings, but got %#v", result)
+	}
+
+	current, _ := client.ConfigV1alpha2().MeshConfigs("osm-system").Get(context.TODO(), "osm-mesh-config", metaV1.GetOptions{})
+	if current.Spec.Sidecar.LogLevel != "debug" {
+		t.Errorf("expected log level debug, but got %s", current.Spec.Sidecar.LogLevel)
+	}
+}
+
+func TestUpdateMeshConfigRejected(t *testing.T) {
+	cases := []struct {
+		info   string
+		update *MeshConfigUpdate
+		code   int32
+	}{
+		{
+			"stale resource version",
+			&MeshConfigUpdate{ResourceVersion: "6", Spec: json.RawMessage(`{}`)},
+			http.StatusConflict,
+		},
+		{
+			"unknown field",
+			&MeshConfigUpdate{ResourceVersion: "7", Spec: json.RawMessage(`{"sidecar": {"logLevl": "debug"}}`)},
+			http.StatusBadRequest,
+		},
+		{
+			"wrong type",
+			&MeshConfigUpdate{ResourceVersion: "7", Spec: json.RawMessage(`{"traffic": {"enableEgress": "yes"}}`)},
+			http.StatusBadRequest,
+		},
+		{
+			"invalid value",
+			&MeshConfigUpdate{ResourceVersion: "7", Spec: json.RawMessage(`{"traffic": {"inboundPortExclusionList": [70000]}}`)},
+			http.StatusBadRequest,
+		},
+		{
+			"invalid duration",
+			&MeshConfigUpdate{ResourceVersion: "7", Spec: json.RawMessage(`{"certificate": {"serviceCertValidityDuration": "1 day"}}`)},
+			http.StatusBadRequest,
+		},
+	}
+
+	for _, c := range cases {
+		client := fake.NewSimpleClientset(newMeshConfig())
+
+		_, err := UpdateMeshConfig(client, nil, "", "osm-system", "osm-mesh-config", c.update)
+		statusError, ok := err.(*k8serrors.StatusError)
+		if !ok {
+			t.Errorf("%s: expected status error, but got %v", c.info, err)
+			continue
+		}
+
+		if statusError.ErrStatus.Code != c.code {
+			t.Errorf("%s: expected code %d, but got %d", c.info, c.code, statusError.ErrStatus.Code)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package multiclusterservice
+
+import (
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+
+	osmconfigv1alph2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+)
+
+// The code below allows to perform complex data section on []api.MultiClusterService
+
+type MultiClusterServiceCell osmconfigv1alph2.MultiClusterService
+
+func (self MultiClusterServiceCell) GetProperty(name dataselect.PropertyName) dataselect.ComparableValue {
+	switch name {
+	case dataselect.NameProperty:
+		return dataselect.StdComparableString(self.ObjectMeta.Name)
+	case dataselect.CreationTimestampProperty:
+		return dataselect.StdComparableTime(self.ObjectMeta.CreationTimestamp.Time)
+	case dataselect.NamespaceProperty:
+		return dataselect.StdComparableString(self.ObjectMeta.Namespace)
+	default:
+		// if name is not supported then just return a constant dummy value, sort will have no effect.
+		return nil
+	}
+}
+
+func toCells(std []osmconfigv1alph2.MultiClusterService) []dataselect.DataCell {
+	cells := make([]dataselect.DataCell, len(std))
+	for i := range std {
+		cells[i] = MultiClusterServiceCell(std[i])
+	}
+	return cells
+}
+
+func fromCells(cells []dataselect.DataCell) []osmconfigv1alph2.MultiClusterService {
+	std := make([]osmconfigv1alph2.MultiClusterService, len(cells))
+	for i := range std {
+		std[i] = osmconfigv1alph2.MultiClusterService(cells[i].(MultiClusterServiceCell))
+	}
+	return std
+}
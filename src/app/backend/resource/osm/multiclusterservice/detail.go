@@ -0,0 +1,88 @@
+package multiclusterservice
+
+import (
+	"context"
+	"log"
+
+	osmconfigv1alph2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	osmconfigclientset "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned"
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+)
+
+// MultiClusterServiceDetail is a multi cluster service with the clusters, ports and service
+// account it is exported with.
+type MultiClusterServiceDetail struct {
+	// Extends list item structure.
+	MultiClusterService `json:",inline"`
+
+	// Clusters the service is exported from.
+	Clusters []osmconfigv1alph2.ClusterSpec `json:"clusters"`
+
+	// Ports the service is exported on.
+	Ports []osmconfigv1alph2.PortSpec `json:"ports"`
+
+	// ServiceAccount the service runs as in the other clusters.
+	ServiceAccount string `json:"serviceAccount"`
+
+	// ServiceAccountFound tells whether the service account also exists in the namespace locally.
+	ServiceAccountFound bool `json:"serviceAccountFound"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// GetMultiClusterServiceDetail returns a multi cluster service linked to its local service.
+func GetMultiClusterServiceDetail(osmConfigClient osmconfigclientset.Interface, client kubernetes.Interface,
+	namespace, name string) (*MultiClusterServiceDetail, error) {
+	log.Printf("Getting details of %s multi cluster service in %s namespace", name, namespace)
+
+	multiClusterService, err := osmConfigClient.ConfigV1alpha2().MultiClusterServices(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	nonCriticalErrors := make([]error, 0)
+
+	services := make([]v1.Service, 0)
+	service, err := client.CoreV1().Services(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+	if err == nil {
+		services = append(services, *service)
+	} else if !k8serrors.IsNotFound(err) {
+		nonCriticalErrors, err = errors.AppendError(err, nonCriticalErrors)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	detail := &MultiClusterServiceDetail{
+		MultiClusterService: toMultiClusterService(multiClusterService, services),
+		Clusters:            multiClusterService.Spec.Clusters,
+		Ports:               multiClusterService.Spec.Ports,
+		ServiceAccount:      multiClusterService.Spec.ServiceAccount,
+	}
+	if detail.Clusters == nil {
+		detail.Clusters = make([]osmconfigv1alph2.ClusterSpec, 0)
+	}
+	if detail.Ports == nil {
+		detail.Ports = make([]osmconfigv1alph2.PortSpec, 0)
+	}
+
+	if len(detail.ServiceAccount) > 0 {
+		_, err := client.CoreV1().ServiceAccounts(namespace).Get(context.TODO(), detail.ServiceAccount, metaV1.GetOptions{})
+		detail.ServiceAccountFound = err == nil
+		if err != nil && !k8serrors.IsNotFound(err) {
+			nonCriticalErrors, err = errors.AppendError(err, nonCriticalErrors)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	detail.Errors = nonCriticalErrors
+	return detail, nil
+}
@@ -0,0 +1,86 @@
+package multiclusterservice
+
+import (
+	"reflect"
+	"testing"
+
+	osmconfigv1alph2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	osmconfigfake "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned/fake"
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+)
+
+func newClients() (*osmconfigfake.Clientset, *fake.Clientset) {
+	osmConfigClient := osmconfigfake.NewSimpleClientset(
+		&osmconfigv1alph2.MultiClusterService{
+			ObjectMeta: metaV1.ObjectMeta{Name: "bookstore", Namespace: "bookstore"},
+			Spec: osmconfigv1alph2.MultiClusterServiceSpec{
+				Clusters:       []osmconfigv1alph2.ClusterSpec{{Name: "alpha", Address: "10.0.0.1:443", Weight: 100}},
+				ServiceAccount: "bookstore",
+				Ports:          []osmconfigv1alph2.PortSpec{{Port: 14001, Protocol: "http"}},
+			},
+		},
+		&osmconfigv1alph2.MultiClusterService{
+			ObjectMeta: metaV1.ObjectMeta{Name: "bookwarehouse", Namespace: "bookstore"},
+		},
+	)
+	k8sClient := fake.NewSimpleClientset(
+		&v1.Service{ObjectMeta: metaV1.ObjectMeta{Name: "bookstore", Namespace: "bookstore"}},
+		&v1.Service{ObjectMeta: metaV1.ObjectMeta{Name: "bookwarehouse", Namespace: "bookwarehouse"}},
+	)
+	return osmConfigClient, k8sClient
+}
+
+func TestGetMultiClusterServiceList(t *testing.T) {
+	osmConfigClient, k8sClient := newClients()
+
+	list, err := GetMultiClusterServiceList(osmConfigClient, k8sClient, common.NewNamespaceQuery(nil), dataselect.NoDataSelect)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if list.ListMeta.TotalItems != 2 {
+		t.Fatalf("expected two multi cluster services, but got %#v", list)
+	}
+
+	for _, item := range list.MultiClusterServices {
+		expected := ""
+		if item.ObjectMeta.Name == "bookstore" {
+			expected = "bookstore"
+		}
+		if item.LocalService != expected {
+			t.Errorf("expected %s to link to local service %q, but got %q", item.ObjectMeta.Name, expected, item.LocalService)
+		}
+	}
+}
+
+func TestGetMultiClusterServiceDetail(t *testing.T) {
+	osmConfigClient, k8sClient := newClients()
+
+	detail, err := GetMultiClusterServiceDetail(osmConfigClient, k8sClient, "bookstore", "bookstore")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if detail.LocalService != "bookstore" || detail.ServiceAccount != "bookstore" || detail.ServiceAccountFound {
+		t.Errorf("expected local bookstore service without a local service account, but got %#v", detail)
+	}
+
+	if !reflect.DeepEqual(detail.Clusters, []osmconfigv1alph2.ClusterSpec{{Name: "alpha", Address: "10.0.0.1:443", Weight: 100}}) ||
+		!reflect.DeepEqual(detail.Ports, []osmconfigv1alph2.PortSpec{{Port: 14001, Protocol: "http"}}) {
+		t.Errorf("expected exported clusters and ports, but got %#v", detail)
+	}
+
+	detail, err = GetMultiClusterServiceDetail(osmConfigClient, k8sClient, "bookstore", "bookwarehouse")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if detail.LocalService != "" || len(detail.Clusters) != 0 || len(detail.Ports) != 0 {
+		t.Errorf("expected no local service, clusters or ports, but got %#v", detail)
+	}
+}
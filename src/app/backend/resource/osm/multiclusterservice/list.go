@@ -0,0 +1,114 @@
+package multiclusterservice
+
+import (
+	"log"
+
+	osmconfigv1alph2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	osmconfigclientset "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+)
+
+// MultiClusterService is a representation of a service exported from other clusters.
+type MultiClusterService struct {
+	ObjectMeta api.ObjectMeta `json:"objectMeta"`
+	TypeMeta   api.TypeMeta   `json:"typeMeta"`
+	// Spec is the MultiClusterService specification.
+	Spec osmconfigv1alph2.MultiClusterServiceSpec `json:"spec"`
+	// LocalService is the name of the Service of the same name in the namespace, or empty if the
+	// service only exists in other clusters.
+	LocalService string `json:"localService"`
+}
+
+// MultiClusterServiceList contains a list of MultiClusterServices in the cluster.
+type MultiClusterServiceList struct {
+	ListMeta api.ListMeta `json:"listMeta"`
+
+	// Unordered list of multi cluster services.
+	MultiClusterServices []MultiClusterService `json:"multiClusterServices"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// GetMultiClusterServiceList returns a list of all MultiClusterServices in the cluster.
+func GetMultiClusterServiceList(osmConfigClient osmconfigclientset.Interface, client kubernetes.Interface,
+	nsQuery *common.NamespaceQuery, dsQuery *dataselect.DataSelectQuery) (*MultiClusterServiceList, error) {
+	log.Print("Getting list of all multi cluster services in the cluster")
+
+	channels := &common.ResourceChannels{
+		MultiClusterServiceList: common.GetMultiClusterServiceListChannel(osmConfigClient, nsQuery, 1),
+		ServiceList:             common.GetServiceListChannel(client, nsQuery, 1),
+	}
+
+	return GetMultiClusterServiceListFromChannels(channels, dsQuery)
+}
+
+// GetMultiClusterServiceListFromChannels returns a list of all MultiClusterServices in the cluster.
+func GetMultiClusterServiceListFromChannels(channels *common.ResourceChannels,
+	dsQuery *dataselect.DataSelectQuery) (*MultiClusterServiceList, error) {
+	multiClusterServices := <-channels.MultiClusterServiceList.List
+	err := <-channels.MultiClusterServiceList.Error
+	nonCriticalErrors, criticalError := errors.HandleError(err)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	services := <-channels.ServiceList.List
+	err = <-channels.ServiceList.Error
+	nonCriticalErrors, criticalError = errors.AppendError(err, nonCriticalErrors)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	var serviceItems []v1.Service
+	if services != nil {
+		serviceItems = services.Items
+	}
+
+	return CreateMultiClusterServiceList(multiClusterServices.Items, serviceItems, nonCriticalErrors, dsQuery), nil
+}
+
+func toMultiClusterService(multiClusterService *osmconfigv1alph2.MultiClusterService, services []v1.Service) MultiClusterService {
+	result := MultiClusterService{
+		ObjectMeta: api.NewObjectMeta(multiClusterService.ObjectMeta),
+		TypeMeta:   api.NewTypeMeta(api.ResourceKindMultiClusterService),
+		Spec:       multiClusterService.Spec,
+	}
+
+	for _, service := range services {
+		if service.Namespace == multiClusterService.Namespace && service.Name == multiClusterService.Name {
+			result.LocalService = service.Name
+			break
+		}
+	}
+
+	return result
+}
+
+// CreateMultiClusterServiceList returns paginated multi cluster service list based on given multi
+// cluster service array and pagination query.
+func CreateMultiClusterServiceList(multiClusterServices []osmconfigv1alph2.MultiClusterService, services []v1.Service,
+	nonCriticalErrors []error, dsQuery *dataselect.DataSelectQuery) *MultiClusterServiceList {
+	multiClusterServiceList := &MultiClusterServiceList{
+		MultiClusterServices: make([]MultiClusterService, 0),
+		ListMeta:             api.ListMeta{TotalItems: len(multiClusterServices)},
+		Errors:               nonCriticalErrors,
+	}
+
+	multiClusterServiceCells, filteredTotal := dataselect.GenericDataSelectWithFilter(toCells(multiClusterServices), dsQuery)
+	multiClusterServices = fromCells(multiClusterServiceCells)
+	multiClusterServiceList.ListMeta = api.ListMeta{TotalItems: filteredTotal}
+
+	for i := range multiClusterServices {
+		multiClusterServiceList.MultiClusterServices = append(multiClusterServiceList.MultiClusterServices,
+			toMultiClusterService(&multiClusterServices[i], services))
+	}
+
+	return multiClusterServiceList
+}
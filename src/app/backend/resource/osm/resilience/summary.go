@@ -0,0 +1,134 @@
+package resilience
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	osmpolicyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	osmpolicyclientset "github.com/openservicemesh/osm/pkg/gen/client/policy/clientset/versioned"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/osm/retry"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/osm/upstreamtrafficsetting"
+)
+
+// ServiceResilience lists the connection, circuit breaking and retry policies that apply to a
+// service.
+type ServiceResilience struct {
+	Namespace string `json:"namespace"`
+	Service   string `json:"service"`
+
+	// Host is the fully qualified host upstream traffic settings are matched against.
+	Host string `json:"host"`
+
+	// UpstreamTrafficSettings are the settings for traffic to the service.
+	UpstreamTrafficSettings []upstreamtrafficsetting.UpstreamTrafficSetting `json:"upstreamTrafficSettings"`
+
+	// Retries are the retry policies for requests to the service.
+	Retries []retry.Retry `json:"retries"`
+
+	// Conflicts describe policies of the same kind that apply to the same traffic, of which OSM
+	// applies only one.
+	Conflicts []string `json:"conflicts"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// GetServiceResilience returns the upstream traffic settings and retry policies that apply to a
+// service, along with the conflicts between them.
+func GetServiceResilience(osmPolicyClient osmpolicyclientset.Interface, client kubernetes.Interface,
+	namespace, name string) (*ServiceResilience, error) {
+	log.Printf("Getting resilience summary of %s service in %s namespace", name, namespace)
+
+	if _, err := client.CoreV1().Services(namespace).Get(context.TODO(), name, metaV1.GetOptions{}); err != nil {
+		return nil, err
+	}
+
+	channels := &common.ResourceChannels{
+		// Upstream traffic settings only apply to services of their own namespace.
+		UpstreamTrafficSettingList: common.GetUpstreamTrafficSettingListChannel(osmPolicyClient,
+			common.NewSameNamespaceQuery(namespace), 1),
+		// Retry policies apply to their destinations wherever they are.
+		RetryList: common.GetRetryListChannel(osmPolicyClient, common.NewNamespaceQuery(nil), 1),
+	}
+
+	host := upstreamtrafficsetting.ServiceHost(namespace, name)
+	summary := &ServiceResilience{
+		Namespace:               namespace,
+		Service:                 name,
+		Host:                    host,
+		UpstreamTrafficSettings: make([]upstreamtrafficsetting.UpstreamTrafficSetting, 0),
+		Retries:                 make([]retry.Retry, 0),
+		Conflicts:               make([]string, 0),
+		Errors:                  make([]error, 0),
+	}
+
+	upstreamTrafficSettings := <-channels.UpstreamTrafficSettingList.List
+	err := <-channels.UpstreamTrafficSettingList.Error
+	nonCriticalErrors, criticalError := errors.HandleError(err)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+	summary.Errors = append(summary.Errors, nonCriticalErrors...)
+
+	if err == nil {
+		matching := upstreamtrafficsetting.FilterByHost(upstreamTrafficSettings.Items, namespace, host)
+		summary.UpstreamTrafficSettings = upstreamtrafficsetting.CreateUpstreamTrafficSettingList(matching, nil,
+			dataselect.NoDataSelect).UpstreamTrafficSettings
+
+		names := make([]string, 0, len(matching))
+		for _, item := range matching {
+			names = append(names, item.Name)
+		}
+		if len(names) > 1 {
+			sort.Strings(names)
+			summary.Conflicts = append(summary.Conflicts, fmt.Sprintf(
+				"upstream traffic settings %s all apply to host %s", strings.Join(names, ", "), host))
+		}
+	}
+
+	retries := <-channels.RetryList.List
+	err = <-channels.RetryList.Error
+	nonCriticalErrors, criticalError = errors.HandleError(err)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+	summary.Errors = append(summary.Errors, nonCriticalErrors...)
+
+	if err == nil {
+		matching := make([]osmpolicyv1alpha1.Retry, 0)
+		bySource := make(map[string][]string)
+		for _, item := range retries.Items {
+			if !retry.AppliesTo(&item, namespace, name) {
+				continue
+			}
+			matching = append(matching, item)
+			source := retry.SourceKey(&item)
+			bySource[source] = append(bySource[source], item.Namespace+"/"+item.Name)
+		}
+		summary.Retries = retry.CreateRetryList(matching, nil, dataselect.NoDataSelect).Retries
+
+		sources := make([]string, 0, len(bySource))
+		for source := range bySource {
+			sources = append(sources, source)
+		}
+		sort.Strings(sources)
+		for _, source := range sources {
+			if names := bySource[source]; len(names) > 1 {
+				sort.Strings(names)
+				summary.Conflicts = append(summary.Conflicts, fmt.Sprintf(
+					"retry policies %s all apply to requests from service account %s", strings.Join(names, ", "), source))
+			}
+		}
+	}
+
+	return summary, nil
+}
@@ -0,0 +1,64 @@
+package resilience
+
+import (
+	"reflect"
+	"testing"
+
+	osmpolicyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	osmpolicyfake "github.com/openservicemesh/osm/pkg/gen/client/policy/clientset/versioned/fake"
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newUpstreamTrafficSetting(name, namespace, host string) *osmpolicyv1alpha1.UpstreamTrafficSetting {
+	return &osmpolicyv1alpha1.UpstreamTrafficSetting{
+		ObjectMeta: metaV1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec:       osmpolicyv1alpha1.UpstreamTrafficSettingSpec{Host: host},
+	}
+}
+
+func newRetry(name, namespace, source, destination string) *osmpolicyv1alpha1.Retry {
+	return &osmpolicyv1alpha1.Retry{
+		ObjectMeta: metaV1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: osmpolicyv1alpha1.RetrySpec{
+			Source:       osmpolicyv1alpha1.RetrySrcDstSpec{Kind: "ServiceAccount", Name: source, Namespace: source},
+			Destinations: []osmpolicyv1alpha1.RetrySrcDstSpec{{Kind: "Service", Name: destination, Namespace: "httpbin"}},
+		},
+	}
+}
+
+func TestGetServiceResilience(t *testing.T) {
+	osmPolicyClient := osmpolicyfake.NewSimpleClientset(
+		newUpstreamTrafficSetting("httpbin", "httpbin", "httpbin"),
+		newUpstreamTrafficSetting("httpbin-fqdn", "httpbin", "httpbin.httpbin.svc.cluster.local"),
+		newUpstreamTrafficSetting("other-namespace", "curl", "httpbin.httpbin.svc.cluster.local"),
+		newUpstreamTrafficSetting("other-host", "httpbin", "other"),
+		newRetry("curl", "curl", "curl", "httpbin"),
+		newRetry("curl-again", "httpbin", "curl", "httpbin"),
+		newRetry("bookbuyer", "bookbuyer", "bookbuyer", "httpbin"),
+		newRetry("curl-other", "curl", "curl", "other"),
+	)
+	k8sClient := fake.NewSimpleClientset(&v1.Service{ObjectMeta: metaV1.ObjectMeta{Name: "httpbin", Namespace: "httpbin"}})
+
+	summary, err := GetServiceResilience(osmPolicyClient, k8sClient, "httpbin", "httpbin")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(summary.UpstreamTrafficSettings) != 2 || len(summary.Retries) != 3 {
+		t.Errorf("expected two upstream traffic settings and three retries, but got %#v", summary)
+	}
+
+	expected := []string{
+		"upstream traffic settings httpbin, httpbin-fqdn all apply to host httpbin.httpbin.svc.cluster.local",
+		"retry policies curl/curl, httpbin/curl-again all apply to requests from service account curl/curl",
+	}
+	if !reflect.DeepEqual(summary.Conflicts, expected) {
+		t.Errorf("expected conflicts %v, but got %v", expected, summary.Conflicts)
+	}
+
+	if _, err := GetServiceResilience(osmPolicyClient, k8sClient, "httpbin", "missing"); err == nil {
+		t.Error("expected an error for a missing service")
+	}
+}
@@ -0,0 +1,140 @@
+package retry
+
+import (
+	"context"
+	"log"
+
+	osmpolicyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	osmpolicyclientset "github.com/openservicemesh/osm/pkg/gen/client/policy/clientset/versioned"
+	smiaccessv1alpha3 "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/access/v1alpha3"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/smi/traffictarget"
+)
+
+// RetryDetail is a retry policy with its source resolved into workloads and its destinations into
+// services.
+type RetryDetail struct {
+	// Extends list item structure.
+	Retry `json:",inline"`
+
+	// Source is the service account the policy applies to with the workloads that run as it.
+	Source *traffictarget.Identity `json:"source"`
+
+	// Destinations are the services requests to which are retried.
+	Destinations []Destination `json:"destinations"`
+
+	// Conflicts are the namespaced names of the other retry policies for the same source and one of
+	// the same destinations.
+	Conflicts []string `json:"conflicts"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// Destination is a destination of a retry policy.
+type Destination struct {
+	osmpolicyv1alpha1.RetrySrcDstSpec `json:",inline"`
+
+	// Found tells whether the destination service exists.
+	Found bool `json:"found"`
+}
+
+// AppliesTo tells whether a retry policy retries requests to the given service.
+func AppliesTo(retry *osmpolicyv1alpha1.Retry, namespace, service string) bool {
+	for _, destination := range retry.Spec.Destinations {
+		if destination.Kind == serviceKind && destination.Namespace == namespace && destination.Name == service {
+			return true
+		}
+	}
+	return false
+}
+
+// SourceKey returns the namespaced name of the service account a retry policy applies to.
+func SourceKey(retry *osmpolicyv1alpha1.Retry) string {
+	return retry.Spec.Source.Namespace + "/" + retry.Spec.Source.Name
+}
+
+// GetRetryDetail returns a retry policy with its source and destinations resolved.
+func GetRetryDetail(osmPolicyClient osmpolicyclientset.Interface, client kubernetes.Interface,
+	namespace, name string) (*RetryDetail, error) {
+	log.Printf("Getting details of %s retry in %s namespace", name, namespace)
+
+	retry, err := osmPolicyClient.PolicyV1alpha1().Retries(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return toRetryDetail(osmPolicyClient, client, retry)
+}
+
+func toRetryDetail(osmPolicyClient osmpolicyclientset.Interface, client kubernetes.Interface,
+	retry *osmpolicyv1alpha1.Retry) (*RetryDetail, error) {
+	subjects := []smiaccessv1alpha3.IdentityBindingSubject{{
+		Kind:      retry.Spec.Source.Kind,
+		Name:      retry.Spec.Source.Name,
+		Namespace: retry.Spec.Source.Namespace,
+	}}
+	sources, nonCriticalErrors, err := traffictarget.ResolveIdentities(client, subjects, retry.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	detail := &RetryDetail{
+		Retry:        toRetry(retry),
+		Destinations: make([]Destination, 0),
+		Conflicts:    make([]string, 0),
+		Errors:       nonCriticalErrors,
+	}
+	if detail.Errors == nil {
+		detail.Errors = make([]error, 0)
+	}
+	if len(sources) > 0 {
+		detail.Source = &sources[0]
+	}
+
+	for _, spec := range retry.Spec.Destinations {
+		destination := Destination{RetrySrcDstSpec: spec}
+		if spec.Kind == serviceKind {
+			_, err := client.CoreV1().Services(spec.Namespace).Get(context.TODO(), spec.Name, metaV1.GetOptions{})
+			destination.Found = err == nil
+			if err != nil && !k8serrors.IsNotFound(err) {
+				nonCriticalErrors, criticalError := errors.HandleError(err)
+				if criticalError != nil {
+					return nil, criticalError
+				}
+				detail.Errors = append(detail.Errors, nonCriticalErrors...)
+			}
+		}
+		detail.Destinations = append(detail.Destinations, destination)
+	}
+
+	// Retry policies apply to their source wherever they are, so look for conflicts in all namespaces.
+	retries, err := osmPolicyClient.PolicyV1alpha1().Retries(metaV1.NamespaceAll).List(context.TODO(), api.ListEverything)
+	nonCriticalErrors, criticalError := errors.HandleError(err)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+	detail.Errors = append(detail.Errors, nonCriticalErrors...)
+
+	if err == nil {
+		for _, other := range retries.Items {
+			if other.Namespace == retry.Namespace && other.Name == retry.Name || SourceKey(&other) != SourceKey(retry) {
+				continue
+			}
+
+			for _, destination := range retry.Spec.Destinations {
+				if AppliesTo(&other, destination.Namespace, destination.Name) {
+					detail.Conflicts = append(detail.Conflicts, other.Namespace+"/"+other.Name)
+					break
+				}
+			}
+		}
+	}
+
+	return detail, nil
+}
@@ -0,0 +1,85 @@
+package retry
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	osmpolicyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	osmpolicyfake "github.com/openservicemesh/osm/pkg/gen/client/policy/clientset/versioned/fake"
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newRetrySpec(destinations ...string) osmpolicyv1alpha1.RetrySpec {
+	spec := osmpolicyv1alpha1.RetrySpec{
+		Source:      osmpolicyv1alpha1.RetrySrcDstSpec{Kind: "ServiceAccount", Name: "curl", Namespace: "curl"},
+		RetryPolicy: osmpolicyv1alpha1.RetryPolicySpec{RetryOn: "5xx", PerTryTimeout: "1s", NumRetries: 5},
+	}
+	for _, destination := range destinations {
+		spec.Destinations = append(spec.Destinations,
+			osmpolicyv1alpha1.RetrySrcDstSpec{Kind: "Service", Name: destination, Namespace: "httpbin"})
+	}
+	return spec
+}
+
+func TestRetryLifecycle(t *testing.T) {
+	osmPolicyClient := osmpolicyfake.NewSimpleClientset(
+		&osmpolicyv1alpha1.Retry{ObjectMeta: metaV1.ObjectMeta{Name: "curl-httpbin", Namespace: "httpbin"}, Spec: newRetrySpec("httpbin")},
+		&osmpolicyv1alpha1.Retry{ObjectMeta: metaV1.ObjectMeta{Name: "curl-other", Namespace: "curl"}, Spec: newRetrySpec("other")},
+	)
+	k8sClient := fake.NewSimpleClientset(
+		&v1.ServiceAccount{ObjectMeta: metaV1.ObjectMeta{Name: "curl", Namespace: "curl"}},
+		&v1.Service{ObjectMeta: metaV1.ObjectMeta{Name: "httpbin", Namespace: "httpbin"}},
+	)
+
+	created, err := CreateRetry(osmPolicyClient, k8sClient, "curl", &RetrySpec{Name: "curl", Spec: newRetrySpec("httpbin", "missing")})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if created.Source == nil || !created.Source.Found {
+		t.Errorf("expected curl source to be resolved, but got %#v", created.Source)
+	}
+
+	if len(created.Destinations) != 2 || !created.Destinations[0].Found || created.Destinations[1].Found {
+		t.Errorf("expected only the httpbin destination to be found, but got %#v", created.Destinations)
+	}
+
+	if !reflect.DeepEqual(created.Conflicts, []string{"httpbin/curl-httpbin"}) {
+		t.Errorf("expected conflict with httpbin/curl-httpbin, but got %v", created.Conflicts)
+	}
+
+	_, err = UpdateRetry(osmPolicyClient, k8sClient, "curl", "curl", &RetryUpdate{ResourceVersion: "stale", Spec: newRetrySpec("httpbin")})
+	if statusError, ok := err.(*k8serrors.StatusError); !ok || statusError.ErrStatus.Code != http.StatusConflict {
+		t.Errorf("expected stale update to conflict, but got %v", err)
+	}
+
+	updated, err := UpdateRetry(osmPolicyClient, k8sClient, "curl", "curl", &RetryUpdate{Spec: newRetrySpec("missing")})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(updated.Conflicts) != 0 {
+		t.Errorf("expected no conflicts after update, but got %v", updated.Conflicts)
+	}
+}
+
+func TestRetryRejected(t *testing.T) {
+	invalid := osmpolicyv1alpha1.RetrySpec{
+		Source:       osmpolicyv1alpha1.RetrySrcDstSpec{Kind: "Pod", Name: "curl", Namespace: "curl"},
+		Destinations: []osmpolicyv1alpha1.RetrySrcDstSpec{{Kind: "Service", Name: "httpbin"}},
+		RetryPolicy:  osmpolicyv1alpha1.RetryPolicySpec{NumRetries: -1, PerTryTimeout: "1", RetryBackoffBaseInterval: "10ms"},
+	}
+
+	_, err := CreateRetry(osmpolicyfake.NewSimpleClientset(), fake.NewSimpleClientset(), "curl", &RetrySpec{Name: "curl", Spec: invalid})
+	if statusError, ok := err.(*k8serrors.StatusError); !ok || statusError.ErrStatus.Code != http.StatusBadRequest {
+		t.Errorf("expected invalid retry to be rejected, but got %v", err)
+	}
+
+	if problems := validateRetrySpec(&invalid); len(problems) != 5 {
+		t.Errorf("expected five problems, but got %#v", problems)
+	}
+}
@@ -0,0 +1,84 @@
+package retry
+
+import (
+	"log"
+
+	osmpolicyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	osmpolicyclientset "github.com/openservicemesh/osm/pkg/gen/client/policy/clientset/versioned"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+)
+
+// Retry is a representation of a retry policy.
+type Retry struct {
+	ObjectMeta api.ObjectMeta `json:"objectMeta"`
+	TypeMeta   api.TypeMeta   `json:"typeMeta"`
+	// Spec is the Retry policy specification.
+	Spec osmpolicyv1alpha1.RetrySpec `json:"spec"`
+}
+
+// RetryList contains a list of Retries in the cluster.
+type RetryList struct {
+	ListMeta api.ListMeta `json:"listMeta"`
+
+	// Unordered list of retries.
+	Retries []Retry `json:"retries"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// GetRetryList returns a list of all Retries in the cluster.
+func GetRetryList(osmPolicyClient osmpolicyclientset.Interface, nsQuery *common.NamespaceQuery,
+	dsQuery *dataselect.DataSelectQuery) (*RetryList, error) {
+	log.Print("Getting list of all retries in the cluster")
+
+	channels := &common.ResourceChannels{
+		RetryList: common.GetRetryListChannel(osmPolicyClient, nsQuery, 1),
+	}
+
+	return GetRetryListFromChannels(channels, dsQuery)
+}
+
+// GetRetryListFromChannels returns a list of all Retries in the cluster.
+func GetRetryListFromChannels(channels *common.ResourceChannels,
+	dsQuery *dataselect.DataSelectQuery) (*RetryList, error) {
+	retries := <-channels.RetryList.List
+	err := <-channels.RetryList.Error
+	nonCriticalErrors, criticalError := errors.HandleError(err)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	return CreateRetryList(retries.Items, nonCriticalErrors, dsQuery), nil
+}
+
+func toRetry(retry *osmpolicyv1alpha1.Retry) Retry {
+	return Retry{
+		ObjectMeta: api.NewObjectMeta(retry.ObjectMeta),
+		TypeMeta:   api.NewTypeMeta(api.ResourceKindRetry),
+		Spec:       retry.Spec,
+	}
+}
+
+// CreateRetryList returns paginated retry list based on given retry array and pagination query.
+func CreateRetryList(retries []osmpolicyv1alpha1.Retry, nonCriticalErrors []error, dsQuery *dataselect.DataSelectQuery) *RetryList {
+	retryList := &RetryList{
+		Retries:  make([]Retry, 0),
+		ListMeta: api.ListMeta{TotalItems: len(retries)},
+		Errors:   nonCriticalErrors,
+	}
+
+	retryCells, filteredTotal := dataselect.GenericDataSelectWithFilter(toCells(retries), dsQuery)
+	retries = fromCells(retryCells)
+	retryList.ListMeta = api.ListMeta{TotalItems: filteredTotal}
+
+	for i := range retries {
+		retryList.Retries = append(retryList.Retries, toRetry(&retries[i]))
+	}
+
+	return retryList
+}
@@ -0,0 +1,141 @@
+package retry
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	osmpolicyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	osmpolicyclientset "github.com/openservicemesh/osm/pkg/gen/client/policy/clientset/versioned"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+)
+
+const (
+	serviceAccountKind = "ServiceAccount"
+	serviceKind        = "Service"
+)
+
+// RetrySpec is a retry policy to create.
+type RetrySpec struct {
+	Name   string            `json:"name"`
+	Labels map[string]string `json:"labels"`
+
+	Spec osmpolicyv1alpha1.RetrySpec `json:"spec"`
+}
+
+// RetryUpdate is a change of the spec of a retry policy.
+type RetryUpdate struct {
+	// ResourceVersion is the version of the retry the change was made on. When set, the change is
+	// rejected if the retry has changed since.
+	ResourceVersion string `json:"resourceVersion"`
+
+	// Spec is the complete new retry spec.
+	Spec osmpolicyv1alpha1.RetrySpec `json:"spec"`
+}
+
+// CreateRetry creates a retry policy in the given namespace.
+func CreateRetry(osmPolicyClient osmpolicyclientset.Interface, client kubernetes.Interface, namespace string,
+	spec *RetrySpec) (*RetryDetail, error) {
+	log.Printf("Creating %s retry in %s namespace", spec.Name, namespace)
+
+	problems := validation.IsDNS1123Subdomain(spec.Name)
+	problems = append(problems, validateRetrySpec(&spec.Spec)...)
+	if len(problems) > 0 {
+		return nil, errors.NewBadRequest(strings.Join(problems, "; "))
+	}
+
+	retry := &osmpolicyv1alpha1.Retry{
+		ObjectMeta: metaV1.ObjectMeta{Name: spec.Name, Namespace: namespace, Labels: spec.Labels},
+		Spec:       spec.Spec,
+	}
+
+	created, err := osmPolicyClient.PolicyV1alpha1().Retries(namespace).Create(context.TODO(), retry, metaV1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return toRetryDetail(osmPolicyClient, client, created)
+}
+
+// UpdateRetry replaces the spec of a retry policy.
+func UpdateRetry(osmPolicyClient osmpolicyclientset.Interface, client kubernetes.Interface, namespace, name string,
+	update *RetryUpdate) (*RetryDetail, error) {
+	log.Printf("Updating %s retry in %s namespace", name, namespace)
+
+	retry, err := osmPolicyClient.PolicyV1alpha1().Retries(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(update.ResourceVersion) > 0 && update.ResourceVersion != retry.ResourceVersion {
+		return nil, errors.NewGenericResponse(http.StatusConflict, fmt.Sprintf(
+			"retry %s in namespace %s was changed since it was read (resourceVersion %s, current %s), reload it and retry",
+			name, namespace, update.ResourceVersion, retry.ResourceVersion))
+	}
+
+	if problems := validateRetrySpec(&update.Spec); len(problems) > 0 {
+		return nil, errors.NewBadRequest(strings.Join(problems, "; "))
+	}
+
+	retry.Spec = update.Spec
+	updated, err := osmPolicyClient.PolicyV1alpha1().Retries(namespace).Update(context.TODO(), retry, metaV1.UpdateOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return toRetryDetail(osmPolicyClient, client, updated)
+}
+
+// validateRetrySpec checks the fields a retry policy needs to take effect. OSM only matches
+// ServiceAccount sources and Service destinations.
+func validateRetrySpec(spec *osmpolicyv1alpha1.RetrySpec) []string {
+	problems := make([]string, 0)
+
+	if spec.Source.Kind != serviceAccountKind {
+		problems = append(problems, fmt.Sprintf("source must be of kind %s, got %q", serviceAccountKind, spec.Source.Kind))
+	}
+	if len(spec.Source.Name) == 0 || len(spec.Source.Namespace) == 0 {
+		problems = append(problems, "source must have a name and a namespace")
+	}
+
+	if len(spec.Destinations) == 0 {
+		problems = append(problems, "at least one destination is required")
+	}
+	for i, destination := range spec.Destinations {
+		if destination.Kind != serviceKind {
+			problems = append(problems, fmt.Sprintf("destination %d must be of kind %s, got %q", i, serviceKind, destination.Kind))
+		}
+		if len(destination.Name) == 0 || len(destination.Namespace) == 0 {
+			problems = append(problems, fmt.Sprintf("destination %d must have a name and a namespace", i))
+		}
+	}
+
+	policy := spec.RetryPolicy
+	if len(strings.TrimSpace(policy.RetryOn)) == 0 {
+		problems = append(problems, "retryOn is required")
+	}
+	if policy.NumRetries < 0 {
+		problems = append(problems, fmt.Sprintf("numRetries must not be negative, got %d", policy.NumRetries))
+	}
+	problems = append(problems, validateDuration("perTryTimeout", policy.PerTryTimeout)...)
+	problems = append(problems, validateDuration("retryBackoffInterval", policy.RetryBackoffBaseInterval)...)
+
+	return problems
+}
+
+func validateDuration(field, value string) []string {
+	if len(value) == 0 {
+		return nil
+	}
+	if duration, err := time.ParseDuration(value); err != nil || duration <= 0 {
+		return []string{fmt.Sprintf("%s must be a positive duration, got %q", field, value)}
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+package sidecar
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+)
+
+// ProxyConfigFormat tells how the output of the sidecar admin interface is returned.
+type ProxyConfigFormat string
+
+const (
+	// ProxyConfigFormatJSON is used when the sidecar answered with valid JSON.
+	ProxyConfigFormatJSON ProxyConfigFormat = "json"
+
+	// ProxyConfigFormatText is used for any other output.
+	ProxyConfigFormatText ProxyConfigFormat = "text"
+)
+
+// proxyConfigTimeout bounds the port-forward and the request to the sidecar admin interface.
+const proxyConfigTimeout = 30 * time.Second
+
+// proxyConfigViews maps the supported views to the query sent to the sidecar admin interface.
+var proxyConfigViews = map[string]string{
+	"config_dump": "config_dump",
+	"clusters":    "clusters?format=json",
+	"listeners":   "listeners?format=json",
+	"stats":       "stats?format=json",
+}
+
+// ProxyConfig is the output of the admin interface of the sidecar proxy of a pod.
+type ProxyConfig struct {
+	Namespace string `json:"namespace"`
+	Pod       string `json:"pod"`
+
+	// View is the admin view the output was read from, e.g. config_dump.
+	View string `json:"view"`
+
+	// Format tells whether the output is in Data or in Text.
+	Format ProxyConfigFormat `json:"format"`
+
+	// Data is the output when the sidecar answered with JSON.
+	Data json.RawMessage `json:"data,omitempty"`
+
+	// Text is the raw output when the sidecar did not answer with JSON.
+	Text string `json:"text,omitempty"`
+}
+
+// adminGetter reads a query from the admin interface of the sidecar of a running pod.
+type adminGetter func(pod *v1.Pod, query string) ([]byte, error)
+
+// GetProxyConfig returns a view of the admin interface of the sidecar proxy of a pod. The admin
+// interface only listens on the loopback address of the pod, so it is reached through a
+// port-forward opened with the credentials of the given config, the same way `osm proxy get` does.
+func GetProxyConfig(client kubernetes.Interface, config *rest.Config, namespace, name, view string) (
+	*ProxyConfig, error) {
+	return getProxyConfig(client, namespace, name, view, func(pod *v1.Pod, query string) ([]byte, error) {
+		return getAdminOutput(client, config, pod, query)
+	})
+}
+
+func getProxyConfig(client kubernetes.Interface, namespace, name, view string, get adminGetter) (
+	*ProxyConfig, error) {
+	log.Printf("Getting %s of the sidecar of pod %s in %s namespace", view, name, namespace)
+
+	query, ok := proxyConfigViews[view]
+	if !ok {
+		return nil, errors.NewBadRequest(fmt.Sprintf("unknown sidecar view %q, expected one of %s",
+			view, strings.Join(ProxyConfigViews(), ", ")))
+	}
+
+	pod, err := client.CoreV1().Pods(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	if !HasSidecar(pod) {
+		return nil, errors.NewBadRequest(fmt.Sprintf("pod %s/%s does not run a sidecar proxy", namespace, name))
+	}
+	if pod.Status.Phase != v1.PodRunning {
+		return nil, errors.NewBadRequest(fmt.Sprintf("pod %s/%s is %s, its sidecar proxy can only be read while it is running",
+			namespace, name, pod.Status.Phase))
+	}
+
+	output, err := get(pod, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return toProxyConfig(pod, view, output), nil
+}
+
+// ProxyConfigViews returns the supported views of the sidecar admin interface.
+func ProxyConfigViews() []string {
+	views := make([]string, 0, len(proxyConfigViews))
+	for view := range proxyConfigViews {
+		views = append(views, view)
+	}
+	sort.Strings(views)
+	return views
+}
+
+func toProxyConfig(pod *v1.Pod, view string, output []byte) *ProxyConfig {
+	result := &ProxyConfig{Namespace: pod.Namespace, Pod: pod.Name, View: view}
+	if trimmed := strings.TrimSpace(string(output)); len(trimmed) > 0 && json.Valid([]byte(trimmed)) {
+		result.Format = ProxyConfigFormatJSON
+		result.Data = json.RawMessage(trimmed)
+	} else {
+		result.Format = ProxyConfigFormatText
+		result.Text = string(output)
+	}
+	return result
+}
+
+// getAdminOutput forwards a random local port to the sidecar admin port of the pod and reads the
+// query from it.
+func getAdminOutput(client kubernetes.Interface, config *rest.Config, pod *v1.Pod, query string) ([]byte, error) {
+	transport, upgrader, err := spdy.RoundTripperFor(config)
+	if err != nil {
+		return nil, err
+	}
+
+	url := client.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(pod.Namespace).
+		Name(pod.Name).
+		SubResource("portforward").
+		URL()
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, url)
+
+	stopChan, readyChan := make(chan struct{}), make(chan struct{})
+	forwarder, err := portforward.NewOnAddresses(dialer, []string{"127.0.0.1"},
+		[]string{fmt.Sprintf("0:%d", constants.SidecarAdminPort)}, stopChan, readyChan, ioutil.Discard, ioutil.Discard)
+	if err != nil {
+		return nil, err
+	}
+
+	forwardErr := make(chan error, 1)
+	go func() { forwardErr <- forwarder.ForwardPorts() }()
+	defer close(stopChan)
+
+	select {
+	case <-readyChan:
+	case err := <-forwardErr:
+		return nil, fmt.Errorf("could not forward the sidecar admin port of pod %s/%s: %v", pod.Namespace, pod.Name, err)
+	case <-time.After(proxyConfigTimeout):
+		return nil, errors.NewGenericResponse(http.StatusGatewayTimeout,
+			fmt.Sprintf("timed out forwarding the sidecar admin port of pod %s/%s", pod.Namespace, pod.Name))
+	}
+
+	ports, err := forwarder.GetPorts()
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient := &http.Client{Timeout: proxyConfigTimeout}
+	response, err := httpClient.Get(fmt.Sprintf("http://127.0.0.1:%d/%s", ports[0].Local, query))
+	if err != nil {
+		return nil, fmt.Errorf("could not read %s from the sidecar of pod %s/%s: %v", query, pod.Namespace, pod.Name, err)
+	}
+	defer response.Body.Close()
+
+	output, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, errors.NewGenericResponse(http.StatusBadGateway, fmt.Sprintf("sidecar of pod %s/%s answered %s with %d: %s",
+			pod.Namespace, pod.Name, query, response.StatusCode, strings.TrimSpace(string(output))))
+	}
+
+	return output, nil
+}
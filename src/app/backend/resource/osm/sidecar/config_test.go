@@ -0,0 +1,61 @@
+package sidecar
+
+import (
+	"net/http"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetProxyConfig(t *testing.T) {
+	running := newPod("bookstore-1", "flomesh/pipy:0.50.0-25")
+	running.Status.Phase = v1.PodRunning
+	pending := newPod("bookstore-2", "flomesh/pipy:0.50.0-25")
+	pending.Status.Phase = v1.PodPending
+	plain := newPod("bookstore-3", "")
+	plain.Status.Phase = v1.PodRunning
+	client := fake.NewSimpleClientset(&running, &pending, &plain)
+
+	outputs := map[string]string{
+		"config_dump":          `{"Spec":{"Traffic":{}}}` + "\n",
+		"clusters?format=json": "bookstore/bookstore-v1|8080\n",
+	}
+	var queries []string
+	get := func(pod *v1.Pod, query string) ([]byte, error) {
+		queries = append(queries, query)
+		return []byte(outputs[query]), nil
+	}
+
+	config, err := getProxyConfig(client, "bookstore", "bookstore-1", "config_dump", get)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if config.Format != ProxyConfigFormatJSON || string(config.Data) != `{"Spec":{"Traffic":{}}}` || len(config.Text) > 0 {
+		t.Errorf("expected the config dump as JSON, but got %#v", config)
+	}
+
+	config, err = getProxyConfig(client, "bookstore", "bookstore-1", "clusters", get)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if config.Format != ProxyConfigFormatText || config.Text != outputs["clusters?format=json"] || config.Data != nil {
+		t.Errorf("expected the clusters as text, but got %#v", config)
+	}
+
+	for _, c := range []struct{ pod, view string }{
+		{"bookstore-1", "routes"},
+		{"bookstore-2", "stats"},
+		{"bookstore-3", "stats"},
+	} {
+		_, err := getProxyConfig(client, "bookstore", c.pod, c.view, get)
+		if statusError, ok := err.(*k8serrors.StatusError); !ok || statusError.ErrStatus.Code != http.StatusBadRequest {
+			t.Errorf("expected %s of pod %s to be rejected with %d, but got %v", c.view, c.pod, http.StatusBadRequest, err)
+		}
+	}
+
+	if len(queries) != 2 {
+		t.Errorf("expected the sidecar to be queried only for valid requests, but got %v", queries)
+	}
+}
@@ -0,0 +1,257 @@
+package sidecar
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	osmconfigv1alph2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	"github.com/openservicemesh/osm/pkg/constants"
+	osmconfigclientset "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned"
+	apps "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// MeshNameLabel is the label of the control plane deployments that holds the mesh name.
+	MeshNameLabel = "meshName"
+
+	// defaultSidecarImageEnv is the variable of the injector that holds the image it injects when
+	// the MeshConfig does not set one.
+	defaultSidecarImageEnv = "OSM_DEFAULT_SIDECAR_IMAGE"
+)
+
+// ControlPlane is the control plane of a mesh.
+type ControlPlane struct {
+	Mesh      string `json:"mesh"`
+	Namespace string `json:"namespace"`
+
+	// Version is the version of the osm-controller deployment.
+	Version string `json:"version"`
+
+	// SidecarImage is the image the injector adds to new pods.
+	SidecarImage string `json:"sidecarImage"`
+}
+
+// Meshes knows the mesh of the namespaces it was read for and the control plane of every mesh.
+type Meshes struct {
+	namespaces    map[string]string
+	controlPlanes map[string]ControlPlane
+}
+
+// SidecarNamespaces returns the namespaces of the pods that have a sidecar.
+func SidecarNamespaces(pods []v1.Pod) []string {
+	result := make([]string, 0)
+	for i := range pods {
+		if HasSidecar(&pods[i]) {
+			result = append(result, pods[i].Namespace)
+		}
+	}
+	return result
+}
+
+// GetMeshes returns the meshes of the given namespaces, typically the namespaces of the shown pods
+// that have a sidecar. Nothing is read when no namespace is given. Namespaces and control planes
+// the user is not allowed to read are treated as not enrolled, as the mesh only decorates the
+// resources shown.
+func GetMeshes(osmConfigClient osmconfigclientset.Interface, client kubernetes.Interface,
+	namespaces []string) (*Meshes, error) {
+	meshes := &Meshes{
+		namespaces:    make(map[string]string),
+		controlPlanes: make(map[string]ControlPlane),
+	}
+
+	enrolled := false
+	for _, name := range namespaces {
+		if _, ok := meshes.namespaces[name]; ok || len(name) == 0 {
+			continue
+		}
+
+		namespace, err := client.CoreV1().Namespaces().Get(context.TODO(), name, metaV1.GetOptions{})
+		if err != nil {
+			if !isIgnoredMeshError(err) {
+				return nil, err
+			}
+			meshes.namespaces[name] = ""
+			continue
+		}
+		meshes.namespaces[name] = namespace.Labels[constants.OSMKubeResourceMonitorAnnotation]
+		enrolled = enrolled || len(meshes.namespaces[name]) > 0
+	}
+	if !enrolled {
+		return meshes, nil
+	}
+
+	result, err := getControlPlanes(osmConfigClient, client)
+	if err != nil {
+		return nil, err
+	}
+	meshes.controlPlanes = result
+	return meshes, nil
+}
+
+// getControlPlanes returns the control planes of the cluster the user is allowed to read.
+func getControlPlanes(osmConfigClient osmconfigclientset.Interface,
+	client kubernetes.Interface) (map[string]ControlPlane, error) {
+	log.Print("Getting control planes of the meshes in the cluster")
+	result := make(map[string]ControlPlane)
+
+	meshed, err := labels.NewRequirement(MeshNameLabel, selection.Exists, nil)
+	if err != nil {
+		return nil, err
+	}
+	deployments, err := client.AppsV1().Deployments(v1.NamespaceAll).List(context.TODO(), metaV1.ListOptions{
+		LabelSelector: labels.NewSelector().Add(*meshed).String(),
+	})
+	if err != nil {
+		if !isIgnoredMeshError(err) {
+			return nil, err
+		}
+		return result, nil
+	}
+
+	// The MeshConfig CRD is missing when no mesh was ever installed, which is not an error here.
+	meshConfigs, err := osmConfigClient.ConfigV1alpha2().MeshConfigs(v1.NamespaceAll).List(context.TODO(), metaV1.ListOptions{})
+	if err != nil {
+		if !isIgnoredMeshError(err) {
+			return nil, err
+		}
+		meshConfigs = &osmconfigv1alph2.MeshConfigList{}
+	}
+
+	for _, deployment := range deployments.Items {
+		if deployment.Labels[constants.AppLabel] != constants.OSMControllerName {
+			continue
+		}
+
+		controlPlane := ControlPlane{
+			Mesh:      deployment.Labels[MeshNameLabel],
+			Namespace: deployment.Namespace,
+			Version:   deployment.Labels[constants.OSMAppVersionLabelKey],
+		}
+		if len(controlPlane.Version) == 0 {
+			if container := getContainer(&deployment, constants.OSMControllerName); container != nil {
+				controlPlane.Version = ImageVersion(container.Image)
+			}
+		}
+
+		for i := range meshConfigs.Items {
+			if meshConfigs.Items[i].Namespace == deployment.Namespace && meshConfigs.Items[i].Name == constants.OSMMeshConfig {
+				controlPlane.SidecarImage = getSidecarImage(&meshConfigs.Items[i].Spec.Sidecar)
+			}
+		}
+		if len(controlPlane.SidecarImage) == 0 {
+			controlPlane.SidecarImage = getDefaultSidecarImage(deployments.Items, deployment.Namespace)
+		}
+
+		result[controlPlane.Mesh] = controlPlane
+	}
+
+	return result, nil
+}
+
+// isIgnoredMeshError tells whether an error of the mesh lookup leaves the resources undecorated
+// instead of failing the request.
+func isIgnoredMeshError(err error) bool {
+	return k8serrors.IsNotFound(err) || k8serrors.IsForbidden(err) || k8serrors.IsUnauthorized(err)
+}
+
+// Mesh returns the mesh that monitors a namespace, empty if it is not enrolled.
+func (m *Meshes) Mesh(namespace string) string {
+	return m.namespaces[namespace]
+}
+
+// ControlPlane returns the control plane of a mesh.
+func (m *Meshes) ControlPlane(mesh string) (ControlPlane, bool) {
+	controlPlane, ok := m.controlPlanes[mesh]
+	return controlPlane, ok
+}
+
+// ResolvePod fills the mesh fields of the sidecar of a pod in the given namespace.
+func (m *Meshes) ResolvePod(status *ProxyStatus, namespace string) {
+	status.Mesh = m.Mesh(namespace)
+	controlPlane, ok := m.ControlPlane(status.Mesh)
+	if !ok {
+		return
+	}
+
+	status.MeshVersion = controlPlane.Version
+	status.CurrentImage = controlPlane.SidecarImage
+	status.UpToDate = status.Injected && len(status.CurrentImage) > 0 && status.Image == status.CurrentImage
+}
+
+// ResolveWorkload fills the mesh fields of the sidecars of a workload in the given namespace.
+func (m *Meshes) ResolveWorkload(sidecars *WorkloadSidecars, namespace string) {
+	sidecars.Mesh = m.Mesh(namespace)
+	controlPlane, ok := m.ControlPlane(sidecars.Mesh)
+	if !ok {
+		return
+	}
+
+	sidecars.MeshVersion = controlPlane.Version
+	sidecars.CurrentImage = controlPlane.SidecarImage
+	if len(sidecars.CurrentImage) == 0 {
+		return
+	}
+
+	sidecars.Outdated = 0
+	for _, proxy := range sidecars.Proxies {
+		if proxy.Image != sidecars.CurrentImage {
+			sidecars.Outdated += proxy.Pods
+		}
+	}
+	sidecars.UpToDate = sidecars.Outdated == 0
+}
+
+// getSidecarImage returns the image the injector uses for the given sidecar settings, in the same
+// way the OSM configurator does.
+func getSidecarImage(spec *osmconfigv1alph2.SidecarSpec) string {
+	if len(spec.SidecarImage) > 0 {
+		return spec.SidecarImage
+	}
+
+	for _, driver := range spec.SidecarDrivers {
+		if strings.EqualFold(driver.SidecarName, spec.SidecarClass) {
+			return driver.SidecarImage
+		}
+	}
+
+	return ""
+}
+
+// getDefaultSidecarImage returns the image the injector of the given namespace falls back to.
+func getDefaultSidecarImage(deployments []apps.Deployment, namespace string) string {
+	for i := range deployments {
+		if deployments[i].Namespace != namespace || deployments[i].Labels[constants.AppLabel] != constants.OSMInjectorName {
+			continue
+		}
+
+		if container := getContainer(&deployments[i], constants.OSMInjectorName); container != nil {
+			for _, env := range container.Env {
+				if env.Name == defaultSidecarImageEnv {
+					return env.Value
+				}
+			}
+		}
+	}
+
+	return ""
+}
+
+func getContainer(deployment *apps.Deployment, name string) *v1.Container {
+	containers := deployment.Spec.Template.Spec.Containers
+	for i := range containers {
+		if containers[i].Name == name {
+			return &containers[i]
+		}
+	}
+	if len(containers) > 0 {
+		return &containers[0]
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+package sidecar
+
+import (
+	"reflect"
+	"testing"
+
+	osmconfigv1alph2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	"github.com/openservicemesh/osm/pkg/constants"
+	osmconfigfake "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned/fake"
+	apps "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+func newPod(name, image string) v1.Pod {
+	pod := v1.Pod{
+		ObjectMeta: metaV1.ObjectMeta{Name: name, Namespace: "bookstore"},
+		Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "bookstore", Image: "bookstore:v1"}}},
+	}
+	if len(image) > 0 {
+		pod.Spec.Containers = append(pod.Spec.Containers, v1.Container{Name: constants.SidecarContainerName, Image: image})
+	}
+	return pod
+}
+
+func TestImageVersion(t *testing.T) {
+	cases := map[string]string{
+		"flomesh/pipy:0.50.0-25":                "0.50.0-25",
+		"localhost:5000/flomesh/pipy":           "latest",
+		"localhost:5000/flomesh/pipy:1.1.0":     "1.1.0",
+		"flomesh/pipy@sha256:0123":              "sha256:0123",
+		"flomesh/pipy:0.50.0@sha256:0123":       "0.50.0",
+		"envoyproxy/envoy-alpine:v1.19.3":       "v1.19.3",
+		"docker.io/envoyproxy/envoy-alpine:1.2": "1.2",
+	}
+
+	for image, expected := range cases {
+		if actual := ImageVersion(image); actual != expected {
+			t.Errorf("ImageVersion(%q) == %q, expected %q", image, actual, expected)
+		}
+	}
+}
+
+func TestGetMeshes(t *testing.T) {
+	osmConfigClient := osmconfigfake.NewSimpleClientset(&osmconfigv1alph2.MeshConfig{
+		ObjectMeta: metaV1.ObjectMeta{Name: constants.OSMMeshConfig, Namespace: "osm-system"},
+		Spec: osmconfigv1alph2.MeshConfigSpec{Sidecar: osmconfigv1alph2.SidecarSpec{
+			SidecarClass:   "pipy",
+			SidecarDrivers: []osmconfigv1alph2.SidecarDriverSpec{{SidecarName: "pipy", SidecarImage: "flomesh/pipy:0.50.0-25"}},
+		}},
+	})
+	client := fake.NewSimpleClientset(
+		&v1.Namespace{ObjectMeta: metaV1.ObjectMeta{Name: "bookstore",
+			Labels: map[string]string{constants.OSMKubeResourceMonitorAnnotation: "osm"}}},
+		&v1.Namespace{ObjectMeta: metaV1.ObjectMeta{Name: "default"}},
+		&apps.Deployment{ObjectMeta: metaV1.ObjectMeta{Name: "osm-controller", Namespace: "osm-system", Labels: map[string]string{
+			constants.AppLabel: constants.OSMControllerName, MeshNameLabel: "osm", constants.OSMAppVersionLabelKey: "1.1.0"}}},
+	)
+
+	meshes, err := GetMeshes(osmConfigClient, client, []string{"bookstore", "default", "bookstore"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := ControlPlane{Mesh: "osm", Namespace: "osm-system", Version: "1.1.0", SidecarImage: "flomesh/pipy:0.50.0-25"}
+	if controlPlane, ok := meshes.ControlPlane("osm"); !ok || controlPlane != expected {
+		t.Errorf("expected control plane %#v, but got %#v", expected, controlPlane)
+	}
+
+	pod := newPod("bookstore-1", "flomesh/pipy:0.50.0-25")
+	status := GetProxyStatus(&pod)
+	meshes.ResolvePod(&status, "bookstore")
+	if !status.Injected || status.Mesh != "osm" || status.Version != "0.50.0-25" || !status.UpToDate {
+		t.Errorf("expected up to date sidecar in mesh osm, but got %#v", status)
+	}
+
+	sidecars := GetWorkloadSidecars([]v1.Pod{
+		newPod("bookstore-1", "flomesh/pipy:0.50.0-25"),
+		newPod("bookstore-2", "flomesh/pipy:0.40.0"),
+		newPod("bookstore-3", "flomesh/pipy:0.40.0"),
+		newPod("bookstore-4", ""),
+	})
+	meshes.ResolveWorkload(&sidecars, "bookstore")
+	expectedProxies := []ProxyVersion{
+		{Image: "flomesh/pipy:0.40.0", Version: "0.40.0", Pods: 2},
+		{Image: "flomesh/pipy:0.50.0-25", Version: "0.50.0-25", Pods: 1},
+	}
+	if sidecars.Injected != 3 || sidecars.Outdated != 2 || sidecars.UpToDate || !sidecars.Drifted() ||
+		!reflect.DeepEqual(sidecars.Proxies, expectedProxies) {
+		t.Errorf("expected two outdated sidecars, but got %#v", sidecars)
+	}
+
+	sidecars = GetWorkloadSidecars([]v1.Pod{newPod("default-1", "flomesh/pipy:0.40.0")})
+	meshes.ResolveWorkload(&sidecars, "default")
+	if sidecars.Mesh != "" || sidecars.Drifted() {
+		t.Errorf("expected workload outside of a mesh not to drift, but got %#v", sidecars)
+	}
+}
+
+func TestGetMeshesWithoutSidecars(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	namespaces := SidecarNamespaces([]v1.Pod{newPod("bookstore-1", "")})
+
+	meshes, err := GetMeshes(osmconfigfake.NewSimpleClientset(), client, namespaces)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(namespaces) != 0 || len(client.Actions()) != 0 {
+		t.Errorf("expected no lookup for pods without sidecar, but got %v", client.Actions())
+	}
+	if mesh := meshes.Mesh("bookstore"); mesh != "" {
+		t.Errorf("expected no mesh, but got %s", mesh)
+	}
+}
+
+func TestGetMeshesForbidden(t *testing.T) {
+	client := fake.NewSimpleClientset(&v1.Namespace{ObjectMeta: metaV1.ObjectMeta{Name: "bookstore",
+		Labels: map[string]string{constants.OSMKubeResourceMonitorAnnotation: "osm"}}})
+	client.PrependReactor("*", "*", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		if action.GetResource().Resource == "namespaces" && action.GetNamespace() == "" {
+			if get, ok := action.(clienttesting.GetAction); ok && get.GetName() == "bookstore" {
+				return false, nil, nil
+			}
+		}
+		return true, nil, k8serrors.NewForbidden(schema.GroupResource{Resource: action.GetResource().Resource}, "", nil)
+	})
+
+	meshes, err := GetMeshes(osmconfigfake.NewSimpleClientset(), client, []string{"bookstore", "bookbuyer"})
+	if err != nil {
+		t.Fatalf("expected forbidden errors to be dropped, but got %s", err)
+	}
+	if mesh := meshes.Mesh("bookstore"); mesh != "osm" {
+		t.Errorf("expected mesh osm, but got %q", mesh)
+	}
+	if _, ok := meshes.ControlPlane("osm"); ok {
+		t.Error("expected no control plane without access to deployments")
+	}
+}
+
+func TestGetMeshesReadsControlPlanesAsUser(t *testing.T) {
+	objects := []runtime.Object{
+		&v1.Namespace{ObjectMeta: metaV1.ObjectMeta{Name: "bookstore",
+			Labels: map[string]string{constants.OSMKubeResourceMonitorAnnotation: "osm"}}},
+		&apps.Deployment{ObjectMeta: metaV1.ObjectMeta{Name: "osm-controller", Namespace: "osm-system", Labels: map[string]string{
+			constants.AppLabel: constants.OSMControllerName, MeshNameLabel: "osm", constants.OSMAppVersionLabelKey: "1.1.0"}}},
+	}
+
+	admin := fake.NewSimpleClientset(objects...)
+	if meshes, err := GetMeshes(osmconfigfake.NewSimpleClientset(), admin, []string{"bookstore"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	} else if _, ok := meshes.ControlPlane("osm"); !ok {
+		t.Error("expected the control plane of mesh osm")
+	}
+
+	user := fake.NewSimpleClientset(objects...)
+	user.PrependReactor("list", "deployments", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		return true, nil, k8serrors.NewForbidden(schema.GroupResource{Group: "apps", Resource: "deployments"}, "", nil)
+	})
+	meshes, err := GetMeshes(osmconfigfake.NewSimpleClientset(), user, []string{"bookstore"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, ok := meshes.ControlPlane("osm"); ok {
+		t.Error("expected no control plane for a user who cannot list deployments")
+	}
+}
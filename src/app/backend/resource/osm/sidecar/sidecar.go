@@ -0,0 +1,135 @@
+package sidecar
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	v1 "k8s.io/api/core/v1"
+)
+
+// ProxyStatus describes the sidecar proxy of a pod and whether it matches the proxy the mesh of
+// the pod currently injects.
+type ProxyStatus struct {
+	// Injected tells whether the pod runs a sidecar proxy.
+	Injected bool `json:"injected"`
+
+	// Image and Version are the image of the sidecar container and its tag.
+	Image   string `json:"image,omitempty"`
+	Version string `json:"version,omitempty"`
+
+	// Mesh is the mesh that monitors the namespace of the pod.
+	Mesh string `json:"mesh,omitempty"`
+
+	// MeshVersion is the version of the control plane of the mesh.
+	MeshVersion string `json:"meshVersion,omitempty"`
+
+	// CurrentImage is the sidecar image the mesh injects into new pods.
+	CurrentImage string `json:"currentImage,omitempty"`
+
+	// UpToDate tells whether the pod runs the sidecar image the mesh currently injects. It is false
+	// when the current image of the mesh is not known.
+	UpToDate bool `json:"upToDate"`
+}
+
+// ProxyVersion is a sidecar image that runs in some of the pods of a workload.
+type ProxyVersion struct {
+	Image   string `json:"image"`
+	Version string `json:"version"`
+	Pods    int32  `json:"pods"`
+}
+
+// WorkloadSidecars aggregates the sidecar proxies of the pods of a workload.
+type WorkloadSidecars struct {
+	// Injected counts the pods of the workload that run a sidecar.
+	Injected int32 `json:"injected"`
+
+	// Proxies are the sidecar images the pods run, ordered by image.
+	Proxies []ProxyVersion `json:"proxies,omitempty"`
+
+	// Mesh is the mesh that monitors the namespace of the workload.
+	Mesh string `json:"mesh,omitempty"`
+
+	// MeshVersion is the version of the control plane of the mesh.
+	MeshVersion string `json:"meshVersion,omitempty"`
+
+	// CurrentImage is the sidecar image the mesh injects into new pods.
+	CurrentImage string `json:"currentImage,omitempty"`
+
+	// Outdated counts the pods that run a sidecar image other than the current one.
+	Outdated int32 `json:"outdated"`
+
+	// UpToDate tells whether every pod with a sidecar runs the current image. It is false when the
+	// current image of the mesh is not known.
+	UpToDate bool `json:"upToDate"`
+}
+
+// Drifted tells whether some pods of the workload still run a sidecar the mesh no longer injects.
+func (w *WorkloadSidecars) Drifted() bool {
+	return len(w.CurrentImage) > 0 && w.Outdated > 0
+}
+
+// GetProxyStatus returns the sidecar proxy of a pod. The mesh fields are filled by Meshes.ResolvePod.
+func GetProxyStatus(pod *v1.Pod) ProxyStatus {
+	container := getSidecarContainer(pod)
+	if container == nil {
+		return ProxyStatus{}
+	}
+
+	return ProxyStatus{
+		Injected: true,
+		Image:    container.Image,
+		Version:  ImageVersion(container.Image),
+	}
+}
+
+// GetWorkloadSidecars returns the sidecar proxies of the pods of a workload. The mesh fields are
+// filled by Meshes.ResolveWorkload.
+func GetWorkloadSidecars(pods []v1.Pod) WorkloadSidecars {
+	result := WorkloadSidecars{}
+	byImage := make(map[string]int32)
+	for i := range pods {
+		if container := getSidecarContainer(&pods[i]); container != nil {
+			result.Injected++
+			byImage[container.Image]++
+		}
+	}
+
+	for image, count := range byImage {
+		result.Proxies = append(result.Proxies, ProxyVersion{Image: image, Version: ImageVersion(image), Pods: count})
+	}
+	sort.Slice(result.Proxies, func(i, j int) bool { return result.Proxies[i].Image < result.Proxies[j].Image })
+
+	return result
+}
+
+// HasSidecar tells whether a pod runs a sidecar proxy.
+func HasSidecar(pod *v1.Pod) bool {
+	return getSidecarContainer(pod) != nil
+}
+
+// ImageVersion returns the tag of an image, or its digest when it has no tag.
+func ImageVersion(image string) string {
+	if i := strings.Index(image, "@"); i >= 0 {
+		if tagged := image[:i]; strings.LastIndex(tagged, ":") > strings.LastIndex(tagged, "/") {
+			return ImageVersion(tagged)
+		}
+		return image[i+1:]
+	}
+
+	// A colon before the last slash separates the registry host from its port.
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		return image[i+1:]
+	}
+
+	return "latest"
+}
+
+func getSidecarContainer(pod *v1.Pod) *v1.Container {
+	for i := range pod.Spec.Containers {
+		if pod.Spec.Containers[i].Name == constants.SidecarContainerName {
+			return &pod.Spec.Containers[i]
+		}
+	}
+	return nil
+}
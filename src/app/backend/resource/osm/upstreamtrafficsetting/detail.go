@@ -0,0 +1,137 @@
+package upstreamtrafficsetting
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	osmpolicyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	osmpolicyclientset "github.com/openservicemesh/osm/pkg/gen/client/policy/clientset/versioned"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+)
+
+// clusterDomain is the domain OSM qualifies service names with.
+const clusterDomain = "svc.cluster.local"
+
+// UpstreamTrafficSettingDetail is an upstream traffic setting with the service it applies to and
+// the other settings that compete for the same host.
+type UpstreamTrafficSettingDetail struct {
+	// Extends list item structure.
+	UpstreamTrafficSetting `json:",inline"`
+
+	// Host is the fully qualified host the setting applies to.
+	Host string `json:"host"`
+
+	// Service is the name of the upstream service when the host is a service of the namespace of
+	// the setting, which is the only case OSM applies it in.
+	Service string `json:"service"`
+
+	// ServiceFound tells whether the upstream service exists.
+	ServiceFound bool `json:"serviceFound"`
+
+	// Conflicts are the names of the other settings in the namespace for the same host. OSM
+	// applies only one of them.
+	Conflicts []string `json:"conflicts"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// ServiceHost returns the fully qualified host of a service.
+func ServiceHost(namespace, name string) string {
+	return fmt.Sprintf("%s.%s.%s", name, namespace, clusterDomain)
+}
+
+// Host returns the fully qualified host an upstream traffic setting applies to. A bare service
+// name is qualified with the namespace of the setting.
+func Host(upstreamTrafficSetting *osmpolicyv1alpha1.UpstreamTrafficSetting) string {
+	host := strings.ToLower(upstreamTrafficSetting.Spec.Host)
+	if len(host) > 0 && !strings.Contains(host, ".") {
+		return ServiceHost(upstreamTrafficSetting.Namespace, host)
+	}
+	return host
+}
+
+// FilterByHost returns the upstream traffic settings of the namespace that apply to the given
+// fully qualified host.
+func FilterByHost(upstreamTrafficSettings []osmpolicyv1alpha1.UpstreamTrafficSetting, namespace,
+	host string) []osmpolicyv1alpha1.UpstreamTrafficSetting {
+	matching := make([]osmpolicyv1alpha1.UpstreamTrafficSetting, 0)
+	for _, upstreamTrafficSetting := range upstreamTrafficSettings {
+		if upstreamTrafficSetting.Namespace == namespace && Host(&upstreamTrafficSetting) == host {
+			matching = append(matching, upstreamTrafficSetting)
+		}
+	}
+	return matching
+}
+
+// serviceName returns the name of the service of the given namespace a fully qualified host
+// belongs to, or an empty string if it does not belong to one.
+func serviceName(namespace, host string) string {
+	name := strings.TrimSuffix(host, "."+namespace+"."+clusterDomain)
+	if name == host || strings.Contains(name, ".") {
+		return ""
+	}
+	return name
+}
+
+// GetUpstreamTrafficSettingDetail returns an upstream traffic setting with the service it applies
+// to and its conflicts.
+func GetUpstreamTrafficSettingDetail(osmPolicyClient osmpolicyclientset.Interface, client kubernetes.Interface,
+	namespace, name string) (*UpstreamTrafficSettingDetail, error) {
+	log.Printf("Getting details of %s upstream traffic setting in %s namespace", name, namespace)
+
+	upstreamTrafficSetting, err := osmPolicyClient.PolicyV1alpha1().UpstreamTrafficSettings(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return toUpstreamTrafficSettingDetail(osmPolicyClient, client, upstreamTrafficSetting)
+}
+
+func toUpstreamTrafficSettingDetail(osmPolicyClient osmpolicyclientset.Interface, client kubernetes.Interface,
+	upstreamTrafficSetting *osmpolicyv1alpha1.UpstreamTrafficSetting) (*UpstreamTrafficSettingDetail, error) {
+	host := Host(upstreamTrafficSetting)
+	detail := &UpstreamTrafficSettingDetail{
+		UpstreamTrafficSetting: toUpstreamTrafficSetting(upstreamTrafficSetting),
+		Host:                   host,
+		Service:                serviceName(upstreamTrafficSetting.Namespace, host),
+		Conflicts:              make([]string, 0),
+		Errors:                 make([]error, 0),
+	}
+
+	if len(detail.Service) > 0 {
+		_, err := client.CoreV1().Services(upstreamTrafficSetting.Namespace).Get(context.TODO(), detail.Service, metaV1.GetOptions{})
+		detail.ServiceFound = err == nil
+		if err != nil && !k8serrors.IsNotFound(err) {
+			nonCriticalErrors, criticalError := errors.HandleError(err)
+			if criticalError != nil {
+				return nil, criticalError
+			}
+			detail.Errors = append(detail.Errors, nonCriticalErrors...)
+		}
+	}
+
+	upstreamTrafficSettings, err := osmPolicyClient.PolicyV1alpha1().UpstreamTrafficSettings(upstreamTrafficSetting.Namespace).List(context.TODO(), api.ListEverything)
+	nonCriticalErrors, criticalError := errors.HandleError(err)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+	detail.Errors = append(detail.Errors, nonCriticalErrors...)
+
+	if err == nil {
+		for _, other := range FilterByHost(upstreamTrafficSettings.Items, upstreamTrafficSetting.Namespace, host) {
+			if other.Name != upstreamTrafficSetting.Name {
+				detail.Conflicts = append(detail.Conflicts, other.Name)
+			}
+		}
+	}
+
+	return detail, nil
+}
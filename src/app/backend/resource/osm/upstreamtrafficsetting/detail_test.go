@@ -0,0 +1,88 @@
+package upstreamtrafficsetting
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	osmpolicyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	osmpolicyfake "github.com/openservicemesh/osm/pkg/gen/client/policy/clientset/versioned/fake"
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newConnectionSettings(maxConnections uint32) *osmpolicyv1alpha1.ConnectionSettingsSpec {
+	return &osmpolicyv1alpha1.ConnectionSettingsSpec{
+		TCP: &osmpolicyv1alpha1.TCPConnectionSettings{MaxConnections: &maxConnections},
+	}
+}
+
+func TestUpstreamTrafficSettingLifecycle(t *testing.T) {
+	osmPolicyClient := osmpolicyfake.NewSimpleClientset(&osmpolicyv1alpha1.UpstreamTrafficSetting{
+		ObjectMeta: metaV1.ObjectMeta{Name: "httpbin-fqdn", Namespace: "httpbin"},
+		Spec:       osmpolicyv1alpha1.UpstreamTrafficSettingSpec{Host: "httpbin.httpbin.svc.cluster.local"},
+	})
+	k8sClient := fake.NewSimpleClientset(&v1.Service{ObjectMeta: metaV1.ObjectMeta{Name: "httpbin", Namespace: "httpbin"}})
+
+	spec := osmpolicyv1alpha1.UpstreamTrafficSettingSpec{Host: "httpbin", ConnectionSettings: newConnectionSettings(100)}
+	created, err := CreateUpstreamTrafficSetting(osmPolicyClient, k8sClient, "httpbin",
+		&UpstreamTrafficSettingSpec{Name: "httpbin", Spec: spec})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if created.Host != "httpbin.httpbin.svc.cluster.local" || created.Service != "httpbin" || !created.ServiceFound {
+		t.Errorf("expected setting to apply to the httpbin service, but got %#v", created)
+	}
+
+	if !reflect.DeepEqual(created.Conflicts, []string{"httpbin-fqdn"}) {
+		t.Errorf("expected conflict with httpbin-fqdn, but got %v", created.Conflicts)
+	}
+
+	spec.ConnectionSettings = newConnectionSettings(200)
+	updated, err := UpdateUpstreamTrafficSetting(osmPolicyClient, k8sClient, "httpbin", "httpbin",
+		&UpstreamTrafficSettingUpdate{Spec: spec})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if *updated.Spec.ConnectionSettings.TCP.MaxConnections != 200 {
+		t.Errorf("expected max connections to be updated to 200, but got %#v", updated.Spec.ConnectionSettings)
+	}
+
+	_, err = UpdateUpstreamTrafficSetting(osmPolicyClient, k8sClient, "httpbin", "httpbin",
+		&UpstreamTrafficSettingUpdate{ResourceVersion: "stale", Spec: spec})
+	if statusError, ok := err.(*k8serrors.StatusError); !ok || statusError.ErrStatus.Code != http.StatusConflict {
+		t.Errorf("expected stale update to conflict, but got %v", err)
+	}
+}
+
+func TestValidateUpstreamTrafficSettingSpec(t *testing.T) {
+	cases := []struct {
+		host     string
+		timeout  time.Duration
+		problems int
+	}{
+		{"httpbin", time.Second, 0},
+		{"httpbin.httpbin.svc.cluster.local", time.Second, 0},
+		{"", time.Second, 1},
+		{"httpbin.other.svc.cluster.local", time.Second, 1},
+		{"Httpbin_", -time.Second, 2},
+	}
+
+	for _, c := range cases {
+		spec := &osmpolicyv1alpha1.UpstreamTrafficSettingSpec{
+			Host: c.host,
+			ConnectionSettings: &osmpolicyv1alpha1.ConnectionSettingsSpec{
+				TCP: &osmpolicyv1alpha1.TCPConnectionSettings{ConnectTimeout: &metaV1.Duration{Duration: c.timeout}},
+			},
+		}
+
+		if problems := validateUpstreamTrafficSettingSpec("httpbin", spec); len(problems) != c.problems {
+			t.Errorf("expected %d problems for host %q, but got %#v", c.problems, c.host, problems)
+		}
+	}
+}
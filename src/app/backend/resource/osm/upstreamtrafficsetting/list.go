@@ -0,0 +1,87 @@
+package upstreamtrafficsetting
+
+import (
+	"log"
+
+	osmpolicyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	osmpolicyclientset "github.com/openservicemesh/osm/pkg/gen/client/policy/clientset/versioned"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+)
+
+// UpstreamTrafficSetting is a representation of an upstream traffic setting policy.
+type UpstreamTrafficSetting struct {
+	ObjectMeta api.ObjectMeta `json:"objectMeta"`
+	TypeMeta   api.TypeMeta   `json:"typeMeta"`
+	// Spec is the UpstreamTrafficSetting policy specification.
+	Spec osmpolicyv1alpha1.UpstreamTrafficSettingSpec `json:"spec"`
+	// Status is the status reported by the OSM controller.
+	Status osmpolicyv1alpha1.UpstreamTrafficSettingStatus `json:"status"`
+}
+
+// UpstreamTrafficSettingList contains a list of UpstreamTrafficSettings in the cluster.
+type UpstreamTrafficSettingList struct {
+	ListMeta api.ListMeta `json:"listMeta"`
+
+	// Unordered list of upstream traffic settings.
+	UpstreamTrafficSettings []UpstreamTrafficSetting `json:"upstreamTrafficSettings"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// GetUpstreamTrafficSettingList returns a list of all UpstreamTrafficSettings in the cluster.
+func GetUpstreamTrafficSettingList(osmPolicyClient osmpolicyclientset.Interface, nsQuery *common.NamespaceQuery,
+	dsQuery *dataselect.DataSelectQuery) (*UpstreamTrafficSettingList, error) {
+	log.Print("Getting list of all upstream traffic settings in the cluster")
+
+	channels := &common.ResourceChannels{
+		UpstreamTrafficSettingList: common.GetUpstreamTrafficSettingListChannel(osmPolicyClient, nsQuery, 1),
+	}
+
+	return GetUpstreamTrafficSettingListFromChannels(channels, dsQuery)
+}
+
+// GetUpstreamTrafficSettingListFromChannels returns a list of all UpstreamTrafficSettings in the cluster.
+func GetUpstreamTrafficSettingListFromChannels(channels *common.ResourceChannels,
+	dsQuery *dataselect.DataSelectQuery) (*UpstreamTrafficSettingList, error) {
+	upstreamTrafficSettings := <-channels.UpstreamTrafficSettingList.List
+	err := <-channels.UpstreamTrafficSettingList.Error
+	nonCriticalErrors, criticalError := errors.HandleError(err)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	return CreateUpstreamTrafficSettingList(upstreamTrafficSettings.Items, nonCriticalErrors, dsQuery), nil
+}
+
+func toUpstreamTrafficSetting(upstreamTrafficSetting *osmpolicyv1alpha1.UpstreamTrafficSetting) UpstreamTrafficSetting {
+	return UpstreamTrafficSetting{
+		ObjectMeta: api.NewObjectMeta(upstreamTrafficSetting.ObjectMeta),
+		TypeMeta:   api.NewTypeMeta(api.ResourceKindUpstreamTrafficSetting),
+		Spec:       upstreamTrafficSetting.Spec,
+		Status:     upstreamTrafficSetting.Status,
+	}
+}
+
+// CreateUpstreamTrafficSettingList returns paginated upstream traffic setting list based on given upstream traffic setting array and pagination query.
+func CreateUpstreamTrafficSettingList(upstreamTrafficSettings []osmpolicyv1alpha1.UpstreamTrafficSetting, nonCriticalErrors []error, dsQuery *dataselect.DataSelectQuery) *UpstreamTrafficSettingList {
+	upstreamTrafficSettingList := &UpstreamTrafficSettingList{
+		UpstreamTrafficSettings: make([]UpstreamTrafficSetting, 0),
+		ListMeta:                api.ListMeta{TotalItems: len(upstreamTrafficSettings)},
+		Errors:                  nonCriticalErrors,
+	}
+
+	upstreamTrafficSettingCells, filteredTotal := dataselect.GenericDataSelectWithFilter(toCells(upstreamTrafficSettings), dsQuery)
+	upstreamTrafficSettings = fromCells(upstreamTrafficSettingCells)
+	upstreamTrafficSettingList.ListMeta = api.ListMeta{TotalItems: filteredTotal}
+
+	for i := range upstreamTrafficSettings {
+		upstreamTrafficSettingList.UpstreamTrafficSettings = append(upstreamTrafficSettingList.UpstreamTrafficSettings, toUpstreamTrafficSetting(&upstreamTrafficSettings[i]))
+	}
+
+	return upstreamTrafficSettingList
+}
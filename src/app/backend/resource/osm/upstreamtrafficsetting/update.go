@@ -0,0 +1,120 @@
+package upstreamtrafficsetting
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	osmpolicyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	osmpolicyclientset "github.com/openservicemesh/osm/pkg/gen/client/policy/clientset/versioned"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+)
+
+// UpstreamTrafficSettingSpec is an upstream traffic setting to create.
+type UpstreamTrafficSettingSpec struct {
+	Name   string            `json:"name"`
+	Labels map[string]string `json:"labels"`
+
+	Spec osmpolicyv1alpha1.UpstreamTrafficSettingSpec `json:"spec"`
+}
+
+// UpstreamTrafficSettingUpdate is a change of the spec of an upstream traffic setting.
+type UpstreamTrafficSettingUpdate struct {
+	// ResourceVersion is the version of the setting the change was made on. When set, the change is
+	// rejected if the setting has changed since.
+	ResourceVersion string `json:"resourceVersion"`
+
+	// Spec is the complete new upstream traffic setting spec.
+	Spec osmpolicyv1alpha1.UpstreamTrafficSettingSpec `json:"spec"`
+}
+
+// CreateUpstreamTrafficSetting creates an upstream traffic setting in the given namespace.
+func CreateUpstreamTrafficSetting(osmPolicyClient osmpolicyclientset.Interface, client kubernetes.Interface,
+	namespace string, spec *UpstreamTrafficSettingSpec) (*UpstreamTrafficSettingDetail, error) {
+	log.Printf("Creating %s upstream traffic setting in %s namespace", spec.Name, namespace)
+
+	problems := validation.IsDNS1123Subdomain(spec.Name)
+	problems = append(problems, validateUpstreamTrafficSettingSpec(namespace, &spec.Spec)...)
+	if len(problems) > 0 {
+		return nil, errors.NewBadRequest(strings.Join(problems, "; "))
+	}
+
+	upstreamTrafficSetting := &osmpolicyv1alpha1.UpstreamTrafficSetting{
+		ObjectMeta: metaV1.ObjectMeta{Name: spec.Name, Namespace: namespace, Labels: spec.Labels},
+		Spec:       spec.Spec,
+	}
+
+	created, err := osmPolicyClient.PolicyV1alpha1().UpstreamTrafficSettings(namespace).Create(context.TODO(), upstreamTrafficSetting, metaV1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return toUpstreamTrafficSettingDetail(osmPolicyClient, client, created)
+}
+
+// UpdateUpstreamTrafficSetting replaces the spec of an upstream traffic setting.
+func UpdateUpstreamTrafficSetting(osmPolicyClient osmpolicyclientset.Interface, client kubernetes.Interface,
+	namespace, name string, update *UpstreamTrafficSettingUpdate) (*UpstreamTrafficSettingDetail, error) {
+	log.Printf("Updating %s upstream traffic setting in %s namespace", name, namespace)
+
+	upstreamTrafficSetting, err := osmPolicyClient.PolicyV1alpha1().UpstreamTrafficSettings(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(update.ResourceVersion) > 0 && update.ResourceVersion != upstreamTrafficSetting.ResourceVersion {
+		return nil, errors.NewGenericResponse(http.StatusConflict, fmt.Sprintf(
+			"upstream traffic setting %s in namespace %s was changed since it was read (resourceVersion %s, current %s), reload it and retry",
+			name, namespace, update.ResourceVersion, upstreamTrafficSetting.ResourceVersion))
+	}
+
+	if problems := validateUpstreamTrafficSettingSpec(namespace, &update.Spec); len(problems) > 0 {
+		return nil, errors.NewBadRequest(strings.Join(problems, "; "))
+	}
+
+	upstreamTrafficSetting.Spec = update.Spec
+	updated, err := osmPolicyClient.PolicyV1alpha1().UpstreamTrafficSettings(namespace).Update(context.TODO(), upstreamTrafficSetting, metaV1.UpdateOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return toUpstreamTrafficSettingDetail(osmPolicyClient, client, updated)
+}
+
+// validateUpstreamTrafficSettingSpec checks the fields an upstream traffic setting needs to take
+// effect. OSM only applies settings whose host is a service of their own namespace.
+func validateUpstreamTrafficSettingSpec(namespace string, spec *osmpolicyv1alpha1.UpstreamTrafficSettingSpec) []string {
+	problems := make([]string, 0)
+
+	if len(spec.Host) == 0 {
+		problems = append(problems, "host is required")
+	} else {
+		for _, problem := range validation.IsDNS1123Subdomain(spec.Host) {
+			problems = append(problems, fmt.Sprintf("host %q: %s", spec.Host, problem))
+		}
+
+		host := Host(&osmpolicyv1alpha1.UpstreamTrafficSetting{
+			ObjectMeta: metaV1.ObjectMeta{Namespace: namespace},
+			Spec:       *spec,
+		})
+		if len(serviceName(namespace, host)) == 0 {
+			problems = append(problems, fmt.Sprintf("host %q must be a service name or the %s FQDN of a service in namespace %s",
+				spec.Host, clusterDomain, namespace))
+		}
+	}
+
+	if spec.ConnectionSettings != nil && spec.ConnectionSettings.TCP != nil {
+		connectTimeout := spec.ConnectionSettings.TCP.ConnectTimeout
+		if connectTimeout != nil && connectTimeout.Duration <= 0 {
+			problems = append(problems, fmt.Sprintf("connect timeout must be positive, got %s", connectTimeout.Duration))
+		}
+	}
+
+	return problems
+}
@@ -36,7 +36,9 @@ import (
 	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/controller"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/osm/sidecar"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/persistentvolumeclaim"
+	osmconfigclientset "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned"
 )
 
 // PodDetail is a presentation layer view of Kubernetes Pod resource.
@@ -59,6 +61,9 @@ type PodDetail struct {
 	PersistentvolumeclaimList persistentvolumeclaim.PersistentVolumeClaimList `json:"persistentVolumeClaimList"`
 	SecurityContext           *v1.PodSecurityContext                          `json:"securityContext"`
 
+	// Sidecar is the sidecar proxy of the Pod.
+	Sidecar sidecar.ProxyStatus `json:"sidecar"`
+
 	// List of non-critical errors, that occurred during resource retrieval.
 	Errors []error `json:"errors"`
 }
@@ -127,8 +132,8 @@ type VolumeMount struct {
 }
 
 // GetPodDetail returns the details of a named Pod from a particular namespace.
-func GetPodDetail(client kubernetes.Interface, metricClient metricapi.MetricClient, namespace, name string) (
-	*PodDetail, error) {
+func GetPodDetail(osmConfigClient osmconfigclientset.Interface, client kubernetes.Interface,
+	metricClient metricapi.MetricClient, namespace, name string) (*PodDetail, error) {
 	log.Printf("Getting details of %s pod in %s namespace", name, namespace)
 
 	channels := &common.ResourceChannels{
@@ -178,8 +183,14 @@ func GetPodDetail(client kubernetes.Interface, metricClient metricapi.MetricClie
 		return nil, criticalError
 	}
 
+	meshes, err := sidecar.GetMeshes(osmConfigClient, client, sidecar.SidecarNamespaces([]v1.Pod{*pod}))
+	if err != nil {
+		return nil, err
+	}
+
 	podDetail := toPodDetail(pod, metrics, configMapList, secretList, podController,
 		eventList, persistentVolumeClaimList, nonCriticalErrors)
+	meshes.ResolvePod(&podDetail.Sidecar, pod.Namespace)
 	return &podDetail, nil
 }
 
@@ -296,6 +307,7 @@ func toPodDetail(pod *v1.Pod, metrics []metricapi.Metric, configMaps *v1.ConfigM
 		EventList:                 *events,
 		PersistentvolumeclaimList: *persistentVolumeClaimList,
 		SecurityContext:           pod.Spec.SecurityContext,
+		Sidecar:                   sidecar.GetProxyStatus(pod),
 		Errors:                    nonCriticalErrors,
 	}
 }
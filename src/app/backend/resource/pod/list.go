@@ -23,6 +23,8 @@ import (
 	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/event"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/osm/sidecar"
+	osmconfigclientset "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned"
 	v1 "k8s.io/api/core/v1"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8sClient "k8s.io/client-go/kubernetes"
@@ -72,6 +74,9 @@ type Pod struct {
 
 	// ContainerImages holds a list of the Pod images.
 	ContainerImages []string `json:"containerImages"`
+
+	// Sidecar is the sidecar proxy of the Pod.
+	Sidecar sidecar.ProxyStatus `json:"sidecar"`
 }
 
 var EmptyPodList = &PodList{
@@ -82,9 +87,9 @@ var EmptyPodList = &PodList{
 	},
 }
 
-// GetPodList returns a list of all Pods in the cluster.
-func GetPodList(client k8sClient.Interface, metricClient metricapi.MetricClient, nsQuery *common.NamespaceQuery,
-	dsQuery *dataselect.DataSelectQuery) (*PodList, error) {
+// GetPodList returns a list of all Pods in the cluster, with the mesh of their sidecars.
+func GetPodList(osmConfigClient osmconfigclientset.Interface, client k8sClient.Interface,
+	metricClient metricapi.MetricClient, nsQuery *common.NamespaceQuery, dsQuery *dataselect.DataSelectQuery) (*PodList, error) {
 	log.Print("Getting list of all pods in the cluster")
 
 	channels := &common.ResourceChannels{
@@ -92,7 +97,27 @@ func GetPodList(client k8sClient.Interface, metricClient metricapi.MetricClient,
 		EventList: common.GetEventListChannel(client, nsQuery, 1),
 	}
 
-	return GetPodListFromChannels(channels, dsQuery, metricClient)
+	podList, err := GetPodListFromChannels(channels, dsQuery, metricClient)
+	if err != nil {
+		return nil, err
+	}
+
+	namespaces := make([]string, 0)
+	for _, item := range podList.Pods {
+		if item.Sidecar.Injected {
+			namespaces = append(namespaces, item.ObjectMeta.Namespace)
+		}
+	}
+	meshes, err := sidecar.GetMeshes(osmConfigClient, client, namespaces)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range podList.Pods {
+		meshes.ResolvePod(&podList.Pods[i].Sidecar, podList.Pods[i].ObjectMeta.Namespace)
+	}
+
+	return podList, nil
 }
 
 // GetPodListFromChannels returns a list of all Pods in the cluster
@@ -161,6 +186,7 @@ func toPod(pod *v1.Pod, metrics *MetricsByPod, warnings []common.Event) Pod {
 		RestartCount:    getRestartCount(*pod),
 		NodeName:        pod.Spec.NodeName,
 		ContainerImages: common.GetContainerImages(&pod.Spec),
+		Sidecar:         sidecar.GetProxyStatus(pod),
 	}
 
 	if m, exists := metrics.MetricsMap[pod.UID]; exists {
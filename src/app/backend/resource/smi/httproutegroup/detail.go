@@ -0,0 +1,38 @@
+package httproutegroup
+
+import (
+	"context"
+	"log"
+
+	smispecsclientset "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/specs/clientset/versioned"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// HttpRouteGroupDetail is a route group with all of its matches.
+type HttpRouteGroupDetail struct {
+	// Extends list item structure.
+	HttpRouteGroup `json:",inline"`
+
+	// Problems are invalid regular expressions in matches. Invalid matches never fire.
+	Problems []string `json:"problems"`
+}
+
+// GetHttpRouteGroupDetail returns a route group with its matches and their validation problems.
+func GetHttpRouteGroupDetail(smiSpecsClient smispecsclientset.Interface, namespace, name string) (*HttpRouteGroupDetail, error) {
+	log.Printf("Getting details of %s http route group in %s namespace", name, namespace)
+
+	httpRouteGroup, err := smiSpecsClient.SpecsV1alpha4().HTTPRouteGroups(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	detail := &HttpRouteGroupDetail{
+		HttpRouteGroup: toHttpRouteGroup(httpRouteGroup),
+		Problems:       make([]string, 0),
+	}
+	for _, match := range httpRouteGroup.Spec.Matches {
+		detail.Problems = append(detail.Problems, ValidateMatch(match)...)
+	}
+
+	return detail, nil
+}
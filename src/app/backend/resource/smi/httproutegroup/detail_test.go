@@ -0,0 +1,102 @@
+package httproutegroup
+
+import (
+	"reflect"
+	"testing"
+
+	smispecsv1alpha4 "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/specs/v1alpha4"
+	smispecsfake "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/specs/clientset/versioned/fake"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newHttpRouteGroup() *smispecsv1alpha4.HTTPRouteGroup {
+	return &smispecsv1alpha4.HTTPRouteGroup{
+		ObjectMeta: metaV1.ObjectMeta{Name: "bookstore-service-routes", Namespace: "bookstore"},
+		Spec: smispecsv1alpha4.HTTPRouteGroupSpec{Matches: []smispecsv1alpha4.HTTPMatch{
+			{Name: "buy-a-book", PathRegex: ".*a-book.*new", Methods: []string{"GET"}, Headers: map[string]string{"user-agent": ".*-http-client/.*"}},
+			{Name: "books-bought", PathRegex: "/books-bought", Methods: []string{"*"}},
+			{Name: "broken", PathRegex: "/books/(", Headers: map[string]string{"x-version": "[v"}},
+		}},
+	}
+}
+
+func TestGetHttpRouteGroupDetail(t *testing.T) {
+	detail, err := GetHttpRouteGroupDetail(smispecsfake.NewSimpleClientset(newHttpRouteGroup()), "bookstore", "bookstore-service-routes")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expectedMatch := HTTPMatch{
+		Name:      "buy-a-book",
+		Methods:   []string{"GET"},
+		PathRegex: ".*a-book.*new",
+		Headers:   map[string]string{"user-agent": ".*-http-client/.*"},
+	}
+	if len(detail.Matches) != 3 || !reflect.DeepEqual(detail.Matches[0], expectedMatch) {
+		t.Errorf("expected three matches starting with %#v, but got %#v", expectedMatch, detail.Matches)
+	}
+
+	if len(detail.Problems) != 2 {
+		t.Errorf("expected invalid path and header regex problems, but got %#v", detail.Problems)
+	}
+}
+
+func TestTestHttpRouteGroup(t *testing.T) {
+	client := smispecsfake.NewSimpleClientset(newHttpRouteGroup())
+
+	cases := []struct {
+		info    string
+		request HttpRouteGroupTestRequest
+		matched []string
+	}{
+		{
+			"matching method, path and header",
+			HttpRouteGroupTestRequest{Method: "get", Path: "/buy-a-book/new", Headers: map[string]string{"User-Agent": "Go-http-client/1.1"}},
+			[]string{"buy-a-book"},
+		},
+		{
+			"missing header",
+			HttpRouteGroupTestRequest{Method: "GET", Path: "/buy-a-book/new"},
+			[]string{},
+		},
+		{
+			"wildcard method and full path match",
+			HttpRouteGroupTestRequest{Method: "POST", Path: "/books-bought"},
+			[]string{"books-bought"},
+		},
+		{
+			"partial path match",
+			HttpRouteGroupTestRequest{Method: "POST", Path: "/books-bought/1"},
+			[]string{},
+		},
+	}
+
+	for _, c := range cases {
+		result, err := TestHttpRouteGroup(client, "bookstore", "bookstore-service-routes", &c.request)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", c.info, err)
+		}
+
+		matched := make([]string, 0)
+		for _, match := range result.Matches {
+			if match.Matched {
+				matched = append(matched, match.Name)
+			}
+		}
+
+		if !reflect.DeepEqual(matched, c.matched) || result.Matched != (len(c.matched) > 0) {
+			t.Errorf("%s: expected matches %v, but got %#v", c.info, c.matched, result)
+		}
+
+		if len(result.Problems) != 2 || len(result.Matches[2].Problems) != 2 {
+			t.Errorf("%s: expected problems of the broken match, but got %#v", c.info, result.Problems)
+		}
+	}
+
+	result, _ := TestHttpRouteGroup(client, "bookstore", "bookstore-service-routes",
+		&HttpRouteGroupTestRequest{Method: "PUT", Path: "/buy-a-book/new", Headers: map[string]string{"user-agent": "curl"}})
+	buyBook := result.Matches[0]
+	if buyBook.MethodMatched || !buyBook.PathMatched || !reflect.DeepEqual(buyBook.UnmatchedHeaders, []string{"user-agent"}) {
+		t.Errorf("expected method and header mismatch, but got %#v", buyBook)
+	}
+}
@@ -16,6 +16,16 @@ import (
 type HttpRouteGroup struct {
 	ObjectMeta api.ObjectMeta `json:"objectMeta"`
 	TypeMeta   api.TypeMeta   `json:"typeMeta"`
+
+	Matches []HTTPMatch `json:"matches"`
+}
+
+// HTTPMatch is a match of a route group. Path and header values are regular expressions.
+type HTTPMatch struct {
+	Name      string            `json:"name"`
+	Methods   []string          `json:"methods"`
+	PathRegex string            `json:"pathRegex"`
+	Headers   map[string]string `json:"headers"`
 }
 
 // HttpRouteGroupList contains a list of services in the cluster.
@@ -55,10 +65,22 @@ func GetHttpRouteGroupListFromChannels(channels *common.ResourceChannels,
 }
 
 func toHttpRouteGroup(httpRouteGroup *smispecsv1alpha4.HTTPRouteGroup) HttpRouteGroup {
-	return HttpRouteGroup{
+	result := HttpRouteGroup{
 		ObjectMeta: api.NewObjectMeta(httpRouteGroup.ObjectMeta),
 		TypeMeta:   api.NewTypeMeta(api.ResourceKindHttpRouteGroup),
+		Matches:    make([]HTTPMatch, 0, len(httpRouteGroup.Spec.Matches)),
 	}
+
+	for _, match := range httpRouteGroup.Spec.Matches {
+		result.Matches = append(result.Matches, HTTPMatch{
+			Name:      match.Name,
+			Methods:   match.Methods,
+			PathRegex: match.PathRegex,
+			Headers:   match.Headers,
+		})
+	}
+
+	return result
 }
 
 // CreateHttpRouteGroupList returns paginated httpgroup list based on given httpgroup array and pagination query.
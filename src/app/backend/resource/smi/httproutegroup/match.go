@@ -0,0 +1,154 @@
+package httproutegroup
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+
+	smispecsv1alpha4 "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/specs/v1alpha4"
+	smispecsclientset "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/specs/clientset/versioned"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// wildcardMethod matches requests of any HTTP method.
+const wildcardMethod = "*"
+
+// HttpRouteGroupTestRequest is a sample HTTP request to test against the matches of a route group.
+type HttpRouteGroupTestRequest struct {
+	Method  string            `json:"method"`
+	Path    string            `json:"path"`
+	Headers map[string]string `json:"headers"`
+}
+
+// HttpRouteGroupTestResult tells which matches of a route group fire for a sample request.
+type HttpRouteGroupTestResult struct {
+	// Matched is true when at least one match fires.
+	Matched bool `json:"matched"`
+
+	Matches []MatchTestResult `json:"matches"`
+
+	// Problems are invalid regular expressions in matches.
+	Problems []string `json:"problems"`
+}
+
+// MatchTestResult is the outcome of testing a sample request against a single match.
+type MatchTestResult struct {
+	Name    string `json:"name"`
+	Matched bool   `json:"matched"`
+
+	MethodMatched bool `json:"methodMatched"`
+	PathMatched   bool `json:"pathMatched"`
+
+	// UnmatchedHeaders are the headers of the match that are missing or have other values.
+	UnmatchedHeaders []string `json:"unmatchedHeaders"`
+
+	// Problems are invalid regular expressions of this match.
+	Problems []string `json:"problems"`
+}
+
+// TestHttpRouteGroup tests a sample request against all matches of a route group.
+func TestHttpRouteGroup(smiSpecsClient smispecsclientset.Interface, namespace, name string,
+	request *HttpRouteGroupTestRequest) (*HttpRouteGroupTestResult, error) {
+	log.Printf("Testing request against %s http route group in %s namespace", name, namespace)
+
+	httpRouteGroup, err := smiSpecsClient.SpecsV1alpha4().HTTPRouteGroups(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &HttpRouteGroupTestResult{
+		Matches:  make([]MatchTestResult, 0, len(httpRouteGroup.Spec.Matches)),
+		Problems: make([]string, 0),
+	}
+	for _, match := range httpRouteGroup.Spec.Matches {
+		matchResult := TestMatch(match, request.Method, request.Path, request.Headers)
+		result.Matches = append(result.Matches, matchResult)
+		result.Matched = result.Matched || matchResult.Matched
+		result.Problems = append(result.Problems, matchResult.Problems...)
+	}
+
+	return result, nil
+}
+
+// TestMatch tests an HTTP request against a match. Path and header values are regular expressions
+// that must match the whole value, header names are case insensitive and a match without methods or
+// with the "*" method matches all methods. A match with an invalid expression never fires.
+func TestMatch(match smispecsv1alpha4.HTTPMatch, method, path string, headers map[string]string) MatchTestResult {
+	result := MatchTestResult{
+		Name:             match.Name,
+		MethodMatched:    matchesMethod(match.Methods, method),
+		PathMatched:      true,
+		UnmatchedHeaders: make([]string, 0),
+		Problems:         make([]string, 0),
+	}
+
+	if len(match.PathRegex) > 0 {
+		expression, err := compile(match.PathRegex)
+		if err != nil {
+			result.Problems = append(result.Problems, fmt.Sprintf("match %q has invalid path regex %q: %s", match.Name, match.PathRegex, err))
+		}
+		result.PathMatched = err == nil && expression.MatchString(path)
+	}
+
+	for _, name := range sortedHeaderNames(match.Headers) {
+		expression, err := compile(match.Headers[name])
+		if err != nil {
+			result.Problems = append(result.Problems, fmt.Sprintf("match %q has invalid regex %q for header %s: %s",
+				match.Name, match.Headers[name], name, err))
+		}
+
+		value, ok := headerValue(headers, name)
+		if err != nil || !ok || !expression.MatchString(value) {
+			result.UnmatchedHeaders = append(result.UnmatchedHeaders, name)
+		}
+	}
+
+	result.Matched = result.MethodMatched && result.PathMatched && len(result.UnmatchedHeaders) == 0
+	return result
+}
+
+// ValidateMatch returns the invalid regular expressions of a match.
+func ValidateMatch(match smispecsv1alpha4.HTTPMatch) []string {
+	return TestMatch(match, "", "", nil).Problems
+}
+
+func matchesMethod(methods []string, method string) bool {
+	if len(methods) == 0 {
+		return true
+	}
+
+	for _, m := range methods {
+		if m == wildcardMethod || strings.EqualFold(m, method) {
+			return true
+		}
+	}
+	return false
+}
+
+func compile(pattern string) (*regexp.Regexp, error) {
+	if _, err := regexp.Compile(pattern); err != nil {
+		return nil, err
+	}
+	return regexp.Compile("^(?:" + pattern + ")$")
+}
+
+func headerValue(headers map[string]string, name string) (string, bool) {
+	for header, value := range headers {
+		if strings.EqualFold(header, name) {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+func sortedHeaderNames(headers map[string]string) []string {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,272 @@
+package policy
+
+import (
+	"fmt"
+	"log"
+	"sort"
+
+	osmconfigv1alph2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	osmconfigclientset "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned"
+	smiaccessclientset "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/access/clientset/versioned"
+	smispecsclientset "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/specs/clientset/versioned"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+)
+
+// AccessMatrix tells for each pair of service identities whether the mesh allows traffic between
+// them. Identities are service accounts in namespace/name form.
+type AccessMatrix struct {
+	// Permissive is the permissive traffic setting of MeshConfig.
+	Permissive bool `json:"permissive"`
+
+	// MeshConfig is the namespace/name of the MeshConfig the permissive setting was read from.
+	MeshConfig string `json:"meshConfig"`
+
+	// Identities are sorted and index both rows (sources) and columns (destinations) of Matrix.
+	Identities []string       `json:"identities"`
+	Matrix     [][]AccessCell `json:"matrix"`
+
+	// Edges is the adjacency list of the pairs that are allowed by traffic targets. In permissive
+	// mode, all other pairs are allowed as well.
+	Edges []AccessEdge `json:"edges"`
+
+	// Warnings flag traffic target rules that allow no traffic.
+	Warnings []string `json:"warnings"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// AccessCell is the access from a source to a destination identity.
+type AccessCell struct {
+	Allowed bool `json:"allowed"`
+
+	// Permissive is true when access is only allowed because of permissive traffic mode.
+	Permissive bool `json:"permissive"`
+
+	// TrafficTargets are the namespace/name of the traffic targets that allow access.
+	TrafficTargets []string `json:"trafficTargets"`
+}
+
+// AccessEdge is an allowed source to destination pair with the rules that allow it.
+type AccessEdge struct {
+	Source      string       `json:"source"`
+	Destination string       `json:"destination"`
+	Rules       []AccessRule `json:"rules"`
+}
+
+// GetAccessMatrix returns the access matrix of all identities in the given namespaces. The permissive
+// setting is read from the given MeshConfig, or from the only MeshConfig when none is given.
+func GetAccessMatrix(smiAccessClient smiaccessclientset.Interface, smiSpecsClient smispecsclientset.Interface,
+	osmConfigClient osmconfigclientset.Interface, client kubernetes.Interface, nsQuery *common.NamespaceQuery,
+	meshConfigNamespace, meshConfigName string) (*AccessMatrix, error) {
+	log.Print("Getting access matrix of the mesh")
+
+	channels := &common.ResourceChannels{
+		TrafficTargetList:  common.GetTrafficTargetListChannel(smiAccessClient, nsQuery, 1),
+		HttpRouteGroupList: common.GetHttpRouteGroupListChannel(smiSpecsClient, nsQuery, 1),
+		TCPRouteList:       common.GetTCPRouteListChannel(smiSpecsClient, nsQuery, 1),
+		ServiceAccountList: common.GetServiceAccountListChannel(client, nsQuery, 1),
+		MeshConfigList:     common.GetMeshConfigListChannel(osmConfigClient, common.NewNamespaceQuery(nil), 1),
+	}
+
+	return GetAccessMatrixFromChannels(channels, meshConfigNamespace, meshConfigName)
+}
+
+// GetAccessMatrixFromChannels returns the access matrix computed from resources read from channels.
+func GetAccessMatrixFromChannels(channels *common.ResourceChannels, meshConfigNamespace,
+	meshConfigName string) (*AccessMatrix, error) {
+	policies, meshConfig, warnings, nonCriticalErrors, err := getPoliciesFromChannels(channels,
+		meshConfigNamespace, meshConfigName)
+	if err != nil {
+		return nil, err
+	}
+
+	serviceAccounts := <-channels.ServiceAccountList.List
+	err = <-channels.ServiceAccountList.Error
+	nonCriticalErrors, criticalError := errors.AppendError(err, nonCriticalErrors)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	identities := make([]string, 0)
+	if serviceAccounts != nil {
+		for _, serviceAccount := range serviceAccounts.Items {
+			identities = append(identities, objectKey(serviceAccount.Namespace, serviceAccount.Name))
+		}
+	}
+
+	matrix := computeAccessMatrix(policies, identities)
+	matrix.MeshConfig = meshConfig
+	matrix.Warnings = append(warnings, matrix.Warnings...)
+	matrix.Errors = nonCriticalErrors
+	return matrix, nil
+}
+
+// getPoliciesFromChannels reads traffic targets, routes and MeshConfigs from channels. It returns the
+// policies, the selected MeshConfig, warnings about its selection and non-critical errors.
+func getPoliciesFromChannels(channels *common.ResourceChannels, meshConfigNamespace,
+	meshConfigName string) (*Policies, string, []string, []error, error) {
+	trafficTargets := <-channels.TrafficTargetList.List
+	err := <-channels.TrafficTargetList.Error
+	nonCriticalErrors, criticalError := errors.HandleError(err)
+	if criticalError != nil {
+		return nil, "", nil, nil, criticalError
+	}
+
+	routeGroups := <-channels.HttpRouteGroupList.List
+	err = <-channels.HttpRouteGroupList.Error
+	nonCriticalErrors, criticalError = errors.AppendError(err, nonCriticalErrors)
+	if criticalError != nil {
+		return nil, "", nil, nil, criticalError
+	}
+
+	tcpRoutes := <-channels.TCPRouteList.List
+	err = <-channels.TCPRouteList.Error
+	nonCriticalErrors, criticalError = errors.AppendError(err, nonCriticalErrors)
+	if criticalError != nil {
+		return nil, "", nil, nil, criticalError
+	}
+
+	meshConfigs := <-channels.MeshConfigList.List
+	err = <-channels.MeshConfigList.Error
+	nonCriticalErrors, criticalError = errors.AppendError(err, nonCriticalErrors)
+	if criticalError != nil {
+		return nil, "", nil, nil, criticalError
+	}
+
+	var meshConfigItems []osmconfigv1alph2.MeshConfig
+	if meshConfigs != nil {
+		meshConfigItems = meshConfigs.Items
+	}
+	permissive, meshConfig, warnings := isPermissive(meshConfigItems, meshConfigNamespace, meshConfigName)
+
+	policies := &Policies{Permissive: permissive}
+	if trafficTargets != nil {
+		policies.TrafficTargets = trafficTargets.Items
+	}
+	if routeGroups != nil {
+		policies.HTTPRouteGroups = routeGroups.Items
+	}
+	if tcpRoutes != nil {
+		policies.TCPRoutes = tcpRoutes.Items
+	}
+
+	return policies, meshConfig, warnings, nonCriticalErrors, nil
+}
+
+// computeAccessMatrix computes the access between the given identities and all identities referenced
+// by traffic targets. A traffic target allows access only if at least one of its rules resolves to an
+// existing route match.
+func computeAccessMatrix(policies *Policies, identities []string) *AccessMatrix {
+	matrix := &AccessMatrix{
+		Permissive: policies.Permissive,
+		Edges:      make([]AccessEdge, 0),
+		Warnings:   make([]string, 0),
+	}
+
+	known := make(map[string]bool)
+	addIdentity := func(identity string) {
+		if !known[identity] {
+			known[identity] = true
+			matrix.Identities = append(matrix.Identities, identity)
+		}
+	}
+	matrix.Identities = make([]string, 0, len(identities))
+	for _, identity := range identities {
+		addIdentity(identity)
+	}
+
+	routes := newRouteIndex(policies)
+	edges := make(map[string]*AccessEdge)
+	for i := range policies.TrafficTargets {
+		trafficTarget := &policies.TrafficTargets[i]
+		if trafficTarget.Spec.Destination.Kind != serviceAccountKind {
+			matrix.Warnings = append(matrix.Warnings, fmt.Sprintf("traffictarget %s has destination of unsupported kind %q",
+				objectKey(trafficTarget.Namespace, trafficTarget.Name), trafficTarget.Spec.Destination.Kind))
+			continue
+		}
+
+		destination := identityKey(trafficTarget.Spec.Destination, trafficTarget.Namespace)
+		addIdentity(destination)
+
+		rules := make([]AccessRule, 0)
+		for _, trafficTargetRule := range trafficTarget.Spec.Rules {
+			rule, warning := routes.resolve(trafficTarget, trafficTargetRule)
+			if rule == nil {
+				matrix.Warnings = append(matrix.Warnings, warning)
+				continue
+			}
+			rules = append(rules, *rule)
+		}
+
+		for _, subject := range trafficTarget.Spec.Sources {
+			if subject.Kind != serviceAccountKind {
+				continue
+			}
+
+			source := identityKey(subject, trafficTarget.Namespace)
+			addIdentity(source)
+			if len(rules) == 0 {
+				continue
+			}
+
+			key := source + "|" + destination
+			edge, ok := edges[key]
+			if !ok {
+				edge = &AccessEdge{Source: source, Destination: destination, Rules: make([]AccessRule, 0)}
+				edges[key] = edge
+			}
+			edge.Rules = append(edge.Rules, rules...)
+		}
+	}
+
+	sort.Strings(matrix.Identities)
+	index := make(map[string]int, len(matrix.Identities))
+	matrix.Matrix = make([][]AccessCell, len(matrix.Identities))
+	for i, identity := range matrix.Identities {
+		index[identity] = i
+		matrix.Matrix[i] = make([]AccessCell, len(matrix.Identities))
+		for j := range matrix.Matrix[i] {
+			matrix.Matrix[i][j] = AccessCell{
+				Allowed:        policies.Permissive,
+				Permissive:     policies.Permissive,
+				TrafficTargets: make([]string, 0),
+			}
+		}
+	}
+
+	for _, edge := range edges {
+		matrix.Edges = append(matrix.Edges, *edge)
+
+		cell := &matrix.Matrix[index[edge.Source]][index[edge.Destination]]
+		cell.Allowed = true
+		cell.Permissive = false
+		for _, rule := range edge.Rules {
+			if !containsString(cell.TrafficTargets, rule.TrafficTarget) {
+				cell.TrafficTargets = append(cell.TrafficTargets, rule.TrafficTarget)
+			}
+		}
+		sort.Strings(cell.TrafficTargets)
+	}
+
+	sort.Slice(matrix.Edges, func(i, j int) bool {
+		if matrix.Edges[i].Source != matrix.Edges[j].Source {
+			return matrix.Edges[i].Source < matrix.Edges[j].Source
+		}
+		return matrix.Edges[i].Destination < matrix.Edges[j].Destination
+	})
+
+	return matrix
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,115 @@
+package policy
+
+import (
+	"reflect"
+	"testing"
+
+	osmconfigv1alph2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	osmconfigfake "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned/fake"
+	smiaccessv1alpha3 "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/access/v1alpha3"
+	smispecsv1alpha4 "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/specs/v1alpha4"
+	smiaccessfake "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/access/clientset/versioned/fake"
+	smispecsfake "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/specs/clientset/versioned/fake"
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+)
+
+func newTrafficTarget(name, destination string, sources []string, rules ...smiaccessv1alpha3.TrafficTargetRule) *smiaccessv1alpha3.TrafficTarget {
+	trafficTarget := &smiaccessv1alpha3.TrafficTarget{
+		ObjectMeta: metaV1.ObjectMeta{Name: name, Namespace: "bookstore"},
+		Spec: smiaccessv1alpha3.TrafficTargetSpec{
+			Destination: smiaccessv1alpha3.IdentityBindingSubject{Kind: "ServiceAccount", Name: destination},
+			Rules:       rules,
+		},
+	}
+	for _, source := range sources {
+		trafficTarget.Spec.Sources = append(trafficTarget.Spec.Sources,
+			smiaccessv1alpha3.IdentityBindingSubject{Kind: "ServiceAccount", Name: source, Namespace: source})
+	}
+	return trafficTarget
+}
+
+func newMeshConfig(permissive bool) *osmconfigv1alph2.MeshConfig {
+	meshConfig := &osmconfigv1alph2.MeshConfig{ObjectMeta: metaV1.ObjectMeta{Name: "osm-mesh-config", Namespace: "osm-system"}}
+	meshConfig.Spec.Traffic.EnablePermissiveTrafficPolicyMode = permissive
+	return meshConfig
+}
+
+func TestGetAccessMatrix(t *testing.T) {
+	smiAccessClient := smiaccessfake.NewSimpleClientset(
+		newTrafficTarget("bookstore", "bookstore", []string{"bookbuyer"},
+			smiaccessv1alpha3.TrafficTargetRule{Kind: "HTTPRouteGroup", Name: "bookstore-routes", Matches: []string{"buy-a-book"}}),
+		newTrafficTarget("bookstore-thief", "bookstore", []string{"bookthief"},
+			smiaccessv1alpha3.TrafficTargetRule{Kind: "HTTPRouteGroup", Name: "bookstore-routes", Matches: []string{"steal-a-book"}}),
+		newTrafficTarget("bookstore-tcp", "bookstore", []string{"bookbuyer"},
+			smiaccessv1alpha3.TrafficTargetRule{Kind: "TCPRoute", Name: "bookstore-tcp"}),
+	)
+	smiSpecsClient := smispecsfake.NewSimpleClientset(
+		&smispecsv1alpha4.HTTPRouteGroup{
+			ObjectMeta: metaV1.ObjectMeta{Name: "bookstore-routes", Namespace: "bookstore"},
+			Spec: smispecsv1alpha4.HTTPRouteGroupSpec{Matches: []smispecsv1alpha4.HTTPMatch{
+				{Name: "buy-a-book", PathRegex: "/buy", Methods: []string{"GET"}},
+			}},
+		},
+		&smispecsv1alpha4.TCPRoute{
+			ObjectMeta: metaV1.ObjectMeta{Name: "bookstore-tcp", Namespace: "bookstore"},
+			Spec:       smispecsv1alpha4.TCPRouteSpec{Matches: smispecsv1alpha4.TCPMatch{Name: "tcp", Ports: []int{14001}}},
+		},
+	)
+	k8sClient := fake.NewSimpleClientset(
+		&v1.ServiceAccount{ObjectMeta: metaV1.ObjectMeta{Name: "bookstore", Namespace: "bookstore"}},
+		&v1.ServiceAccount{ObjectMeta: metaV1.ObjectMeta{Name: "bookwarehouse", Namespace: "bookwarehouse"}},
+	)
+
+	matrix, err := GetAccessMatrix(smiAccessClient, smiSpecsClient, osmconfigfake.NewSimpleClientset(newMeshConfig(false)),
+		k8sClient, common.NewNamespaceQuery(nil), "", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expectedIdentities := []string{"bookbuyer/bookbuyer", "bookstore/bookstore", "bookthief/bookthief", "bookwarehouse/bookwarehouse"}
+	if !reflect.DeepEqual(matrix.Identities, expectedIdentities) {
+		t.Fatalf("expected identities %v, but got %v", expectedIdentities, matrix.Identities)
+	}
+
+	if matrix.Permissive || matrix.MeshConfig != "osm-system/osm-mesh-config" {
+		t.Errorf("expected non-permissive osm-system/osm-mesh-config, but got %v %s", matrix.Permissive, matrix.MeshConfig)
+	}
+
+	expectedCell := AccessCell{Allowed: true, TrafficTargets: []string{"bookstore/bookstore", "bookstore/bookstore-tcp"}}
+	if !reflect.DeepEqual(matrix.Matrix[0][1], expectedCell) {
+		t.Errorf("expected bookbuyer to access bookstore with %#v, but got %#v", expectedCell, matrix.Matrix[0][1])
+	}
+
+	if matrix.Matrix[2][1].Allowed || matrix.Matrix[1][0].Allowed {
+		t.Errorf("expected bookthief and reverse access to be denied, but got %#v", matrix.Matrix)
+	}
+
+	if len(matrix.Edges) != 1 || len(matrix.Edges[0].Rules) != 2 || matrix.Edges[0].Rules[1].Matches[0] != "tcp" {
+		t.Errorf("expected a single bookbuyer edge with two rules, but got %#v", matrix.Edges)
+	}
+
+	expectedWarnings := []string{"traffictarget bookstore/bookstore-thief allows no match of HTTPRouteGroup bookstore/bookstore-routes"}
+	if !reflect.DeepEqual(matrix.Warnings, expectedWarnings) {
+		t.Errorf("expected warnings %#v, but got %#v", expectedWarnings, matrix.Warnings)
+	}
+}
+
+func TestComputeAccessMatrixPermissive(t *testing.T) {
+	matrix := computeAccessMatrix(&Policies{Permissive: true}, []string{"bookstore/bookstore", "bookbuyer/bookbuyer"})
+
+	for _, row := range matrix.Matrix {
+		for _, cell := range row {
+			if !cell.Allowed || !cell.Permissive {
+				t.Errorf("expected permissive access between all identities, but got %#v", matrix.Matrix)
+			}
+		}
+	}
+
+	if len(matrix.Edges) != 0 {
+		t.Errorf("expected no traffic target edges, but got %#v", matrix.Edges)
+	}
+}
@@ -0,0 +1,159 @@
+package policy
+
+import (
+	"fmt"
+	"sort"
+
+	osmconfigv1alph2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	smiaccessv1alpha3 "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/access/v1alpha3"
+	smispecsv1alpha4 "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/specs/v1alpha4"
+)
+
+const (
+	serviceAccountKind = "ServiceAccount"
+	httpRouteGroupKind = "HTTPRouteGroup"
+	tcpRouteKind       = "TCPRoute"
+)
+
+// Policies are the SMI access policies of a mesh and its permissive traffic setting.
+type Policies struct {
+	TrafficTargets  []smiaccessv1alpha3.TrafficTarget
+	HTTPRouteGroups []smispecsv1alpha4.HTTPRouteGroup
+	TCPRoutes       []smispecsv1alpha4.TCPRoute
+
+	// Permissive is true when the MeshConfig allows all traffic regardless of traffic targets.
+	Permissive bool
+}
+
+// AccessRule is a rule of a traffic target that allows traffic between two identities.
+type AccessRule struct {
+	// TrafficTarget is the namespace/name of the traffic target the rule belongs to.
+	TrafficTarget string `json:"trafficTarget"`
+
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+
+	// Matches are the names of the route matches that are allowed.
+	Matches []string `json:"matches"`
+}
+
+// identityKey returns the namespace/name of a service account subject. Subjects without a namespace
+// are in the namespace of their traffic target.
+func identityKey(subject smiaccessv1alpha3.IdentityBindingSubject, defaultNamespace string) string {
+	namespace := subject.Namespace
+	if len(namespace) == 0 {
+		namespace = defaultNamespace
+	}
+	return namespace + "/" + subject.Name
+}
+
+func objectKey(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+// routeIndex finds the routes referenced by traffic target rules. Routes are always in the
+// namespace of the traffic target.
+type routeIndex struct {
+	httpRouteGroups map[string]smispecsv1alpha4.HTTPRouteGroup
+	tcpRoutes       map[string]smispecsv1alpha4.TCPRoute
+}
+
+func newRouteIndex(policies *Policies) *routeIndex {
+	index := &routeIndex{
+		httpRouteGroups: make(map[string]smispecsv1alpha4.HTTPRouteGroup),
+		tcpRoutes:       make(map[string]smispecsv1alpha4.TCPRoute),
+	}
+	for _, routeGroup := range policies.HTTPRouteGroups {
+		index.httpRouteGroups[objectKey(routeGroup.Namespace, routeGroup.Name)] = routeGroup
+	}
+	for _, route := range policies.TCPRoutes {
+		index.tcpRoutes[objectKey(route.Namespace, route.Name)] = route
+	}
+	return index
+}
+
+// resolve returns the rule with the matches it allows, or a warning when the route or none of the
+// referenced matches exist. A rule without match names allows all matches of its route.
+func (self *routeIndex) resolve(trafficTarget *smiaccessv1alpha3.TrafficTarget,
+	rule smiaccessv1alpha3.TrafficTargetRule) (*AccessRule, string) {
+	key := objectKey(trafficTarget.Namespace, rule.Name)
+	result := &AccessRule{
+		TrafficTarget: objectKey(trafficTarget.Namespace, trafficTarget.Name),
+		Kind:          rule.Kind,
+		Name:          rule.Name,
+		Matches:       make([]string, 0),
+	}
+
+	selected := func(name string) bool {
+		if len(rule.Matches) == 0 {
+			return true
+		}
+		for _, match := range rule.Matches {
+			if match == name {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch rule.Kind {
+	case httpRouteGroupKind:
+		routeGroup, ok := self.httpRouteGroups[key]
+		if !ok {
+			return nil, fmt.Sprintf("traffictarget %s references missing HTTPRouteGroup %s", result.TrafficTarget, key)
+		}
+		for _, match := range routeGroup.Spec.Matches {
+			if selected(match.Name) {
+				result.Matches = append(result.Matches, match.Name)
+			}
+		}
+	case tcpRouteKind:
+		route, ok := self.tcpRoutes[key]
+		if !ok {
+			return nil, fmt.Sprintf("traffictarget %s references missing TCPRoute %s", result.TrafficTarget, key)
+		}
+		if selected(route.Spec.Matches.Name) {
+			result.Matches = append(result.Matches, route.Spec.Matches.Name)
+		}
+	default:
+		return nil, fmt.Sprintf("traffictarget %s has rule of unsupported kind %q", result.TrafficTarget, rule.Kind)
+	}
+
+	if len(result.Matches) == 0 {
+		return nil, fmt.Sprintf("traffictarget %s allows no match of %s %s", result.TrafficTarget, rule.Kind, key)
+	}
+
+	return result, ""
+}
+
+// isPermissive returns the permissive traffic setting of the selected MeshConfig. Without a
+// selection, the only MeshConfig is used; when there are several, the first by namespace is used
+// and a warning is returned.
+func isPermissive(meshConfigs []osmconfigv1alph2.MeshConfig, namespace, name string) (bool, string, []string) {
+	warnings := make([]string, 0)
+	if len(meshConfigs) == 0 {
+		return false, "", append(warnings, "no MeshConfig found, SMI policies are assumed to be enforced")
+	}
+
+	sort.Slice(meshConfigs, func(i, j int) bool {
+		return objectKey(meshConfigs[i].Namespace, meshConfigs[i].Name) < objectKey(meshConfigs[j].Namespace, meshConfigs[j].Name)
+	})
+
+	if len(namespace) > 0 || len(name) > 0 {
+		for _, meshConfig := range meshConfigs {
+			if (len(namespace) == 0 || meshConfig.Namespace == namespace) && (len(name) == 0 || meshConfig.Name == name) {
+				return meshConfig.Spec.Traffic.EnablePermissiveTrafficPolicyMode,
+					objectKey(meshConfig.Namespace, meshConfig.Name), warnings
+			}
+		}
+		warnings = append(warnings, fmt.Sprintf("MeshConfig %s not found", objectKey(namespace, name)))
+	}
+
+	selected := meshConfigs[0]
+	if len(meshConfigs) > 1 {
+		warnings = append(warnings, fmt.Sprintf("found %d MeshConfigs, using %s", len(meshConfigs),
+			objectKey(selected.Namespace, selected.Name)))
+	}
+
+	return selected.Spec.Traffic.EnablePermissiveTrafficPolicyMode, objectKey(selected.Namespace, selected.Name), warnings
+}
@@ -0,0 +1,175 @@
+package policy
+
+import (
+	"fmt"
+	"strings"
+
+	smiaccessv1alpha3 "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/access/v1alpha3"
+	smispecsv1alpha4 "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/specs/v1alpha4"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/smi/httproutegroup"
+)
+
+// SimulatedRequest is a request between two resolved identities that is evaluated against SMI
+// policies. A request without method and path is a plain TCP connection.
+type SimulatedRequest struct {
+	// Source is the namespace/name of the source service account.
+	Source string `json:"source"`
+
+	// Destination is the namespace/name of the destination service account.
+	Destination string `json:"destination"`
+
+	// Port is the port of the destination workload the request is sent to.
+	Port int `json:"port"`
+
+	Method  string            `json:"method"`
+	Path    string            `json:"path"`
+	Headers map[string]string `json:"headers"`
+}
+
+// SimulationMatch is the traffic target rule and route match that allowed a request.
+type SimulationMatch struct {
+	TrafficTarget string `json:"trafficTarget"`
+	Kind          string `json:"kind"`
+	Name          string `json:"name"`
+	Match         string `json:"match"`
+}
+
+// SimulationDecision is the outcome of evaluating a request against SMI policies.
+type SimulationDecision struct {
+	Source      string `json:"source"`
+	Destination string `json:"destination"`
+
+	// Allowed tells whether the request reaches the destination.
+	Allowed bool `json:"allowed"`
+
+	// PolicyAllowed tells whether SMI policies alone allow the request.
+	PolicyAllowed bool `json:"policyAllowed"`
+
+	// Permissive is true when permissive traffic mode allows a request denied by SMI policies.
+	Permissive bool `json:"permissive"`
+
+	// Match is the route match that allowed the request, if any.
+	Match *SimulationMatch `json:"match,omitempty"`
+
+	// Reason explains the decision.
+	Reason string `json:"reason"`
+}
+
+// Simulate evaluates a request against SMI policies the way the sidecar of the destination does.
+// Only traffic targets in the destination namespace apply.
+func Simulate(policies *Policies, request *SimulatedRequest) *SimulationDecision {
+	decision := &SimulationDecision{Source: request.Source, Destination: request.Destination}
+	decision.Match, decision.Reason = evaluate(policies, request)
+	decision.PolicyAllowed = decision.Match != nil
+
+	switch {
+	case decision.PolicyAllowed:
+		decision.Allowed = true
+	case policies.Permissive:
+		decision.Allowed = true
+		decision.Permissive = true
+		decision.Reason = fmt.Sprintf("permissive traffic mode allows all traffic, SMI policies would deny it: %s", decision.Reason)
+	}
+
+	return decision
+}
+
+func evaluate(policies *Policies, request *SimulatedRequest) (*SimulationMatch, string) {
+	destinationNamespace := strings.SplitN(request.Destination, "/", 2)[0]
+	routes := newRouteIndex(policies)
+	isHTTP := len(request.Method) > 0 || len(request.Path) > 0
+
+	applying := make([]string, 0)
+	for i := range policies.TrafficTargets {
+		trafficTarget := &policies.TrafficTargets[i]
+		if trafficTarget.Namespace != destinationNamespace ||
+			trafficTarget.Spec.Destination.Kind != serviceAccountKind ||
+			identityKey(trafficTarget.Spec.Destination, trafficTarget.Namespace) != request.Destination ||
+			!hasSource(trafficTarget, request.Source) {
+			continue
+		}
+
+		applying = append(applying, objectKey(trafficTarget.Namespace, trafficTarget.Name))
+		for _, trafficTargetRule := range trafficTarget.Spec.Rules {
+			rule, _ := routes.resolve(trafficTarget, trafficTargetRule)
+			if rule == nil {
+				continue
+			}
+
+			key := objectKey(trafficTarget.Namespace, rule.Name)
+			var match string
+			switch rule.Kind {
+			case httpRouteGroupKind:
+				if isHTTP {
+					match = matchHTTPRouteGroup(routes.httpRouteGroups[key], rule.Matches, request)
+				}
+			case tcpRouteKind:
+				match = matchTCPRoute(routes.tcpRoutes[key], rule.Matches, request.Port)
+			}
+
+			if len(match) > 0 {
+				return &SimulationMatch{
+					TrafficTarget: rule.TrafficTarget,
+					Kind:          rule.Kind,
+					Name:          rule.Name,
+					Match:         match,
+				}, fmt.Sprintf("allowed by %s %s of traffictarget %s", rule.Kind, rule.Name, rule.TrafficTarget)
+			}
+		}
+	}
+
+	if len(applying) == 0 {
+		return nil, fmt.Sprintf("no traffictarget allows %s to access %s", request.Source, request.Destination)
+	}
+
+	if isHTTP {
+		return nil, fmt.Sprintf("traffictargets %s allow %s to access %s, but no route matches %s %s on port %d",
+			strings.Join(applying, ", "), request.Source, request.Destination, request.Method, request.Path, request.Port)
+	}
+	return nil, fmt.Sprintf("traffictargets %s allow %s to access %s, but no TCP route matches port %d",
+		strings.Join(applying, ", "), request.Source, request.Destination, request.Port)
+}
+
+func hasSource(trafficTarget *smiaccessv1alpha3.TrafficTarget, source string) bool {
+	for _, subject := range trafficTarget.Spec.Sources {
+		if subject.Kind == serviceAccountKind && identityKey(subject, trafficTarget.Namespace) == source {
+			return true
+		}
+	}
+	return false
+}
+
+// matchHTTPRouteGroup returns the name of the first allowed match of the route group that matches
+// the request.
+func matchHTTPRouteGroup(routeGroup smispecsv1alpha4.HTTPRouteGroup, allowed []string, request *SimulatedRequest) string {
+	for _, match := range routeGroup.Spec.Matches {
+		if containsString(allowed, match.Name) && MatchesHTTP(match, request.Method, request.Path, request.Headers) {
+			return match.Name
+		}
+	}
+	return ""
+}
+
+// matchTCPRoute returns the name of the route match when it is allowed and covers the port. A match
+// without ports covers all ports.
+func matchTCPRoute(route smispecsv1alpha4.TCPRoute, allowed []string, port int) string {
+	if !containsString(allowed, route.Spec.Matches.Name) {
+		return ""
+	}
+
+	if len(route.Spec.Matches.Ports) == 0 {
+		return route.Spec.Matches.Name
+	}
+	for _, matchPort := range route.Spec.Matches.Ports {
+		if matchPort == port {
+			return route.Spec.Matches.Name
+		}
+	}
+	return ""
+}
+
+// MatchesHTTP tells whether an HTTP request matches a route group match.
+func MatchesHTTP(match smispecsv1alpha4.HTTPMatch, method, path string, headers map[string]string) bool {
+	return httproutegroup.TestMatch(match, method, path, headers).Matched
+}
@@ -0,0 +1,166 @@
+package policy
+
+import (
+	"strings"
+	"testing"
+
+	osmconfigfake "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned/fake"
+	smiaccessv1alpha3 "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/access/v1alpha3"
+	smispecsv1alpha4 "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/specs/v1alpha4"
+	smiaccessfake "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/access/clientset/versioned/fake"
+	smispecsfake "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/specs/clientset/versioned/fake"
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newSimulationPolicies(permissive bool) *Policies {
+	return &Policies{
+		Permissive: permissive,
+		TrafficTargets: []smiaccessv1alpha3.TrafficTarget{
+			*newTrafficTarget("bookstore", "bookstore", []string{"bookbuyer"},
+				smiaccessv1alpha3.TrafficTargetRule{Kind: "HTTPRouteGroup", Name: "bookstore-routes", Matches: []string{"buy-a-book"}}),
+			*newTrafficTarget("bookstore-tcp", "bookstore", []string{"bookwarehouse"},
+				smiaccessv1alpha3.TrafficTargetRule{Kind: "TCPRoute", Name: "bookstore-tcp"}),
+		},
+		HTTPRouteGroups: []smispecsv1alpha4.HTTPRouteGroup{{
+			ObjectMeta: metaV1.ObjectMeta{Name: "bookstore-routes", Namespace: "bookstore"},
+			Spec: smispecsv1alpha4.HTTPRouteGroupSpec{Matches: []smispecsv1alpha4.HTTPMatch{
+				{Name: "buy-a-book", PathRegex: "/books/.*", Methods: []string{"GET"}, Headers: map[string]string{"user-agent": ".*bookbuyer.*"}},
+				{Name: "sell-a-book", PathRegex: ".*", Methods: []string{"*"}},
+			}},
+		}},
+		TCPRoutes: []smispecsv1alpha4.TCPRoute{{
+			ObjectMeta: metaV1.ObjectMeta{Name: "bookstore-tcp", Namespace: "bookstore"},
+			Spec:       smispecsv1alpha4.TCPRouteSpec{Matches: smispecsv1alpha4.TCPMatch{Name: "tcp", Ports: []int{14001}}},
+		}},
+	}
+}
+
+func TestSimulate(t *testing.T) {
+	cases := []struct {
+		info       string
+		permissive bool
+		request    SimulatedRequest
+		allowed    bool
+		policy     bool
+		match      string
+		reason     string
+	}{
+		{
+			"matching HTTP route", false,
+			SimulatedRequest{Source: "bookbuyer/bookbuyer", Destination: "bookstore/bookstore", Port: 14001,
+				Method: "get", Path: "/books/1", Headers: map[string]string{"User-Agent": "Go-http-client bookbuyer"}},
+			true, true, "buy-a-book", "allowed by HTTPRouteGroup bookstore-routes",
+		},
+		{
+			"path not fully matched", false,
+			SimulatedRequest{Source: "bookbuyer/bookbuyer", Destination: "bookstore/bookstore", Port: 14001,
+				Method: "GET", Path: "/api/books/1", Headers: map[string]string{"user-agent": "bookbuyer"}},
+			false, false, "", "no route matches GET /api/books/1",
+		},
+		{
+			"match not allowed by rule", false,
+			SimulatedRequest{Source: "bookbuyer/bookbuyer", Destination: "bookstore/bookstore", Port: 14001,
+				Method: "POST", Path: "/books/1", Headers: map[string]string{"user-agent": "bookbuyer"}},
+			false, false, "", "no route matches POST",
+		},
+		{
+			"missing header", false,
+			SimulatedRequest{Source: "bookbuyer/bookbuyer", Destination: "bookstore/bookstore", Port: 14001,
+				Method: "GET", Path: "/books/1"},
+			false, false, "", "no route matches",
+		},
+		{
+			"TCP route port", false,
+			SimulatedRequest{Source: "bookwarehouse/bookwarehouse", Destination: "bookstore/bookstore", Port: 14001},
+			true, true, "tcp", "allowed by TCPRoute bookstore-tcp",
+		},
+		{
+			"TCP route other port", false,
+			SimulatedRequest{Source: "bookwarehouse/bookwarehouse", Destination: "bookstore/bookstore", Port: 8080},
+			false, false, "", "no TCP route matches port 8080",
+		},
+		{
+			"no traffic target", false,
+			SimulatedRequest{Source: "bookthief/bookthief", Destination: "bookstore/bookstore", Port: 14001, Method: "GET", Path: "/books/1"},
+			false, false, "", "no traffictarget allows bookthief/bookthief",
+		},
+		{
+			"permissive override", true,
+			SimulatedRequest{Source: "bookthief/bookthief", Destination: "bookstore/bookstore", Port: 14001, Method: "GET", Path: "/books/1"},
+			true, false, "", "permissive traffic mode",
+		},
+	}
+
+	for _, c := range cases {
+		decision := Simulate(newSimulationPolicies(c.permissive), &c.request)
+
+		if decision.Allowed != c.allowed || decision.PolicyAllowed != c.policy || decision.Permissive != (c.permissive && !c.policy) {
+			t.Errorf("%s: expected allowed %v and policy allowed %v, but got %#v", c.info, c.allowed, c.policy, decision)
+		}
+
+		if (len(c.match) == 0) != (decision.Match == nil) || (decision.Match != nil && decision.Match.Match != c.match) {
+			t.Errorf("%s: expected match %q, but got %#v", c.info, c.match, decision.Match)
+		}
+
+		if !strings.Contains(decision.Reason, c.reason) {
+			t.Errorf("%s: expected reason to contain %q, but got %q", c.info, c.reason, decision.Reason)
+		}
+	}
+}
+
+func TestSimulateAccess(t *testing.T) {
+	policies := newSimulationPolicies(false)
+	smiAccessClient := smiaccessfake.NewSimpleClientset(&policies.TrafficTargets[0], &policies.TrafficTargets[1])
+	smiSpecsClient := smispecsfake.NewSimpleClientset(&policies.HTTPRouteGroups[0], &policies.TCPRoutes[0])
+	k8sClient := fake.NewSimpleClientset(
+		&v1.Pod{
+			ObjectMeta: metaV1.ObjectMeta{Name: "bookbuyer-5f8d", Namespace: "bookbuyer"},
+			Spec:       v1.PodSpec{ServiceAccountName: "bookbuyer"},
+		},
+		&v1.Pod{
+			ObjectMeta: metaV1.ObjectMeta{Name: "bookstore-7c8d", Namespace: "bookstore", Labels: map[string]string{"app": "bookstore"}},
+			Spec: v1.PodSpec{
+				ServiceAccountName: "bookstore",
+				Containers:         []v1.Container{{Name: "bookstore", Ports: []v1.ContainerPort{{Name: "web", ContainerPort: 14001}}}},
+			},
+		},
+		&v1.Service{
+			ObjectMeta: metaV1.ObjectMeta{Name: "bookstore", Namespace: "bookstore"},
+			Spec: v1.ServiceSpec{
+				Selector: map[string]string{"app": "bookstore"},
+				Ports:    []v1.ServicePort{{Port: 80, TargetPort: intstr.FromString("web")}},
+			},
+		},
+	)
+
+	spec := &SimulationSpec{
+		Source:      SimulationSource{Namespace: "bookbuyer", Pod: "bookbuyer-5f8d"},
+		Destination: SimulationDestination{Namespace: "bookstore", Service: "bookstore"},
+		Port:        80,
+		Method:      "GET",
+		Path:        "/books/2",
+		Headers:     map[string]string{"user-agent": "bookbuyer"},
+	}
+
+	result, err := SimulateAccess(smiAccessClient, smiSpecsClient, osmconfigfake.NewSimpleClientset(newMeshConfig(true)), k8sClient, spec)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if !result.Allowed || !result.PolicyAllowed || result.Permissive || result.Source != "bookbuyer/bookbuyer" ||
+		result.TargetPort != 14001 || result.Match == nil || result.Match.TrafficTarget != "bookstore/bookstore" {
+		t.Errorf("expected request to be allowed by bookstore/bookstore on port 14001, but got %#v", result)
+	}
+
+	if len(result.Decisions) != 1 || result.Decisions[0].Destination != "bookstore/bookstore" {
+		t.Errorf("expected a decision for bookstore/bookstore, but got %#v", result.Decisions)
+	}
+
+	spec.Port = 8080
+	if _, err := SimulateAccess(smiAccessClient, smiSpecsClient, osmconfigfake.NewSimpleClientset(), k8sClient, spec); err == nil {
+		t.Error("expected unknown service port to be rejected")
+	}
+}
@@ -0,0 +1,259 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+
+	osmconfigclientset "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned"
+	smiaccessclientset "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/access/clientset/versioned"
+	smispecsclientset "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/specs/clientset/versioned"
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+)
+
+// defaultServiceAccount is used by pods that do not set a service account.
+const defaultServiceAccount = "default"
+
+// SimulationSpec is a request from a pod or service account to a service to simulate.
+type SimulationSpec struct {
+	Source      SimulationSource      `json:"source"`
+	Destination SimulationDestination `json:"destination"`
+
+	// Port is a port of the destination service.
+	Port int `json:"port"`
+
+	// Method, path and headers of an HTTP request. Leave them empty to simulate a TCP connection.
+	Method  string            `json:"method"`
+	Path    string            `json:"path"`
+	Headers map[string]string `json:"headers"`
+}
+
+// SimulationSource is a pod or a service account. The service account is used when both are set.
+type SimulationSource struct {
+	Namespace      string `json:"namespace"`
+	Pod            string `json:"pod"`
+	ServiceAccount string `json:"serviceAccount"`
+}
+
+// SimulationDestination is a service.
+type SimulationDestination struct {
+	Namespace string `json:"namespace"`
+	Service   string `json:"service"`
+}
+
+// SimulationResult is the outcome of a simulated request. A service may be backed by pods of several
+// service accounts, so there is a decision for each of them.
+type SimulationResult struct {
+	// Allowed is true when the request is allowed for all destination identities.
+	Allowed bool `json:"allowed"`
+
+	// PolicyAllowed is true when SMI policies alone allow the request for all destination identities.
+	PolicyAllowed bool `json:"policyAllowed"`
+
+	// Permissive is true when permissive traffic mode overrides a denial.
+	Permissive bool `json:"permissive"`
+
+	// MeshConfig is the namespace/name of the MeshConfig the permissive setting was read from.
+	MeshConfig string `json:"meshConfig"`
+
+	Source string `json:"source"`
+
+	// TargetPort is the port of the destination pods the service port maps to.
+	TargetPort int `json:"targetPort"`
+
+	// Match and reason of the decisive decision: the first denial or, if there is none, the first match.
+	Match  *SimulationMatch `json:"match,omitempty"`
+	Reason string           `json:"reason"`
+
+	Decisions []SimulationDecision `json:"decisions"`
+
+	// Warnings about the MeshConfig selection.
+	Warnings []string `json:"warnings"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// SimulateAccess resolves the source and destination of a request and evaluates it against the SMI
+// policies of the destination namespace.
+func SimulateAccess(smiAccessClient smiaccessclientset.Interface, smiSpecsClient smispecsclientset.Interface,
+	osmConfigClient osmconfigclientset.Interface, client kubernetes.Interface, spec *SimulationSpec) (*SimulationResult, error) {
+	if err := validateSimulationSpec(spec); err != nil {
+		return nil, err
+	}
+	log.Printf("Simulating request to port %d of %s service in %s namespace", spec.Port,
+		spec.Destination.Service, spec.Destination.Namespace)
+
+	source, err := resolveSource(client, spec.Source)
+	if err != nil {
+		return nil, err
+	}
+
+	service, err := client.CoreV1().Services(spec.Destination.Namespace).Get(context.TODO(), spec.Destination.Service, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	servicePort := findServicePort(service, spec.Port)
+	if servicePort == nil {
+		return nil, errors.NewBadRequest(fmt.Sprintf("service %s/%s has no port %d", service.Namespace, service.Name, spec.Port))
+	}
+
+	pods := make([]v1.Pod, 0)
+	if len(service.Spec.Selector) > 0 {
+		podList, err := client.CoreV1().Pods(service.Namespace).List(context.TODO(), metaV1.ListOptions{
+			LabelSelector: labels.SelectorFromSet(service.Spec.Selector).String(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		pods = podList.Items
+	}
+
+	nsQuery := common.NewSameNamespaceQuery(service.Namespace)
+	channels := &common.ResourceChannels{
+		TrafficTargetList:  common.GetTrafficTargetListChannel(smiAccessClient, nsQuery, 1),
+		HttpRouteGroupList: common.GetHttpRouteGroupListChannel(smiSpecsClient, nsQuery, 1),
+		TCPRouteList:       common.GetTCPRouteListChannel(smiSpecsClient, nsQuery, 1),
+		MeshConfigList:     common.GetMeshConfigListChannel(osmConfigClient, common.NewNamespaceQuery(nil), 1),
+	}
+
+	policies, meshConfig, warnings, nonCriticalErrors, err := getPoliciesFromChannels(channels, "", "")
+	if err != nil {
+		return nil, err
+	}
+
+	request := &SimulatedRequest{
+		Source:  source,
+		Port:    targetPort(servicePort, pods),
+		Method:  spec.Method,
+		Path:    spec.Path,
+		Headers: spec.Headers,
+	}
+	result := simulateService(policies, request, destinationIdentities(service.Namespace, pods))
+	result.MeshConfig = meshConfig
+	result.Warnings = warnings
+	result.Errors = nonCriticalErrors
+	return result, nil
+}
+
+// simulateService evaluates a request for each destination identity of a service.
+func simulateService(policies *Policies, request *SimulatedRequest, destinations []string) *SimulationResult {
+	result := &SimulationResult{
+		Source:     request.Source,
+		TargetPort: request.Port,
+		Decisions:  make([]SimulationDecision, 0, len(destinations)),
+	}
+
+	if len(destinations) == 0 {
+		result.Reason = "destination service has no pods"
+		return result
+	}
+
+	result.Allowed = true
+	result.PolicyAllowed = true
+	var decisive *SimulationDecision
+	for _, destination := range destinations {
+		identityRequest := *request
+		identityRequest.Destination = destination
+		decision := Simulate(policies, &identityRequest)
+		result.Decisions = append(result.Decisions, *decision)
+
+		result.Allowed = result.Allowed && decision.Allowed
+		result.PolicyAllowed = result.PolicyAllowed && decision.PolicyAllowed
+		result.Permissive = result.Permissive || decision.Permissive
+		if decisive == nil || (decisive.PolicyAllowed && !decision.PolicyAllowed) {
+			decisive = decision
+		}
+	}
+
+	result.Match = decisive.Match
+	result.Reason = decisive.Reason
+	return result
+}
+
+func validateSimulationSpec(spec *SimulationSpec) error {
+	switch {
+	case len(spec.Source.Namespace) == 0:
+		return errors.NewBadRequest("source namespace is required")
+	case len(spec.Source.Pod) == 0 && len(spec.Source.ServiceAccount) == 0:
+		return errors.NewBadRequest("source pod or service account is required")
+	case len(spec.Destination.Namespace) == 0 || len(spec.Destination.Service) == 0:
+		return errors.NewBadRequest("destination namespace and service are required")
+	case spec.Port <= 0:
+		return errors.NewBadRequest("port is required")
+	}
+	return nil
+}
+
+func resolveSource(client kubernetes.Interface, source SimulationSource) (string, error) {
+	if len(source.ServiceAccount) > 0 {
+		return objectKey(source.Namespace, source.ServiceAccount), nil
+	}
+
+	pod, err := client.CoreV1().Pods(source.Namespace).Get(context.TODO(), source.Pod, metaV1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	return objectKey(pod.Namespace, podServiceAccount(pod.Spec)), nil
+}
+
+func findServicePort(service *v1.Service, port int) *v1.ServicePort {
+	for i := range service.Spec.Ports {
+		if int(service.Spec.Ports[i].Port) == port {
+			return &service.Spec.Ports[i]
+		}
+	}
+	return nil
+}
+
+// targetPort returns the pod port a service port maps to. Named target ports are looked up in the
+// containers of the pods.
+func targetPort(servicePort *v1.ServicePort, pods []v1.Pod) int {
+	if servicePort.TargetPort.Type == intstr.Int {
+		if servicePort.TargetPort.IntVal == 0 {
+			return int(servicePort.Port)
+		}
+		return int(servicePort.TargetPort.IntVal)
+	}
+
+	for _, pod := range pods {
+		for _, container := range pod.Spec.Containers {
+			for _, port := range container.Ports {
+				if port.Name == servicePort.TargetPort.StrVal {
+					return int(port.ContainerPort)
+				}
+			}
+		}
+	}
+	return int(servicePort.Port)
+}
+
+func destinationIdentities(namespace string, pods []v1.Pod) []string {
+	known := make(map[string]bool)
+	identities := make([]string, 0)
+	for _, pod := range pods {
+		identity := objectKey(namespace, podServiceAccount(pod.Spec))
+		if !known[identity] {
+			known[identity] = true
+			identities = append(identities, identity)
+		}
+	}
+	sort.Strings(identities)
+	return identities
+}
+
+func podServiceAccount(spec v1.PodSpec) string {
+	if len(spec.ServiceAccountName) == 0 {
+		return defaultServiceAccount
+	}
+	return spec.ServiceAccountName
+}
@@ -0,0 +1,50 @@
+package tcproute
+
+import (
+	"context"
+	"log"
+
+	smiaccessv1alpha3 "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/access/v1alpha3"
+	smiaccessclientset "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/access/clientset/versioned"
+	smispecsclientset "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/specs/clientset/versioned"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+)
+
+// TCPRouteDetail is a TCP route with the traffic targets that reference it.
+type TCPRouteDetail struct {
+	// Extends list item structure.
+	TCPRoute `json:",inline"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// GetTCPRouteDetail returns a TCP route with the traffic targets that reference it.
+func GetTCPRouteDetail(smiSpecsClient smispecsclientset.Interface, smiAccessClient smiaccessclientset.Interface,
+	namespace, name string) (*TCPRouteDetail, error) {
+	log.Printf("Getting details of %s tcp route in %s namespace", name, namespace)
+
+	tcpRoute, err := smiSpecsClient.SpecsV1alpha4().TCPRoutes(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	trafficTargets, err := smiAccessClient.AccessV1alpha3().TrafficTargets(namespace).List(context.TODO(), api.ListEverything)
+	nonCriticalErrors, criticalError := errors.HandleError(err)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	var trafficTargetItems []smiaccessv1alpha3.TrafficTarget
+	if err == nil {
+		trafficTargetItems = trafficTargets.Items
+	}
+
+	return &TCPRouteDetail{
+		TCPRoute: toTCPRoute(tcpRoute, trafficTargetItems),
+		Errors:   nonCriticalErrors,
+	}, nil
+}
@@ -0,0 +1,138 @@
+package tcproute
+
+import (
+	"log"
+	"sort"
+
+	smiaccessv1alpha3 "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/access/v1alpha3"
+	smispecsv1alpha4 "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/specs/v1alpha4"
+	smiaccessclientset "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/access/clientset/versioned"
+	smispecsclientset "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/specs/clientset/versioned"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+)
+
+// tcpRouteKind is the kind of TCP routes in traffic target rules.
+const tcpRouteKind = "TCPRoute"
+
+// TCPRoute is a representation of a TCP route.
+type TCPRoute struct {
+	ObjectMeta api.ObjectMeta `json:"objectMeta"`
+	TypeMeta   api.TypeMeta   `json:"typeMeta"`
+
+	Match TCPMatch `json:"match"`
+
+	// TrafficTargets are the names of traffic targets that reference the route. Traffic targets
+	// always reference routes in their own namespace.
+	TrafficTargets []string `json:"trafficTargets"`
+}
+
+// TCPMatch is the match of a TCP route. A match without ports matches all ports.
+type TCPMatch struct {
+	Name  string `json:"name"`
+	Ports []int  `json:"ports"`
+}
+
+// TCPRouteList contains a list of TCP routes in the cluster.
+type TCPRouteList struct {
+	ListMeta api.ListMeta `json:"listMeta"`
+
+	// Unordered list of TCP routes.
+	TCPRoutes []TCPRoute `json:"tcproutes"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// GetTCPRouteList returns a list of all TCP routes in the cluster.
+func GetTCPRouteList(smiSpecsClient smispecsclientset.Interface, smiAccessClient smiaccessclientset.Interface,
+	nsQuery *common.NamespaceQuery, dsQuery *dataselect.DataSelectQuery) (*TCPRouteList, error) {
+	log.Print("Getting list of all tcp routes in the cluster")
+
+	channels := &common.ResourceChannels{
+		TCPRouteList:      common.GetTCPRouteListChannel(smiSpecsClient, nsQuery, 1),
+		TrafficTargetList: common.GetTrafficTargetListChannel(smiAccessClient, nsQuery, 1),
+	}
+
+	return GetTCPRouteListFromChannels(channels, dsQuery)
+}
+
+// GetTCPRouteListFromChannels returns a list of all TCP routes in the cluster.
+func GetTCPRouteListFromChannels(channels *common.ResourceChannels,
+	dsQuery *dataselect.DataSelectQuery) (*TCPRouteList, error) {
+	tcpRoutes := <-channels.TCPRouteList.List
+	err := <-channels.TCPRouteList.Error
+	nonCriticalErrors, criticalError := errors.HandleError(err)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	trafficTargets := <-channels.TrafficTargetList.List
+	err = <-channels.TrafficTargetList.Error
+	nonCriticalErrors, criticalError = errors.AppendError(err, nonCriticalErrors)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	var trafficTargetItems []smiaccessv1alpha3.TrafficTarget
+	if trafficTargets != nil {
+		trafficTargetItems = trafficTargets.Items
+	}
+
+	return CreateTCPRouteList(tcpRoutes.Items, trafficTargetItems, nonCriticalErrors, dsQuery), nil
+}
+
+func toTCPRoute(tcpRoute *smispecsv1alpha4.TCPRoute, trafficTargets []smiaccessv1alpha3.TrafficTarget) TCPRoute {
+	return TCPRoute{
+		ObjectMeta: api.NewObjectMeta(tcpRoute.ObjectMeta),
+		TypeMeta:   api.NewTypeMeta(api.ResourceKindTCPRoute),
+		Match: TCPMatch{
+			Name:  tcpRoute.Spec.Matches.Name,
+			Ports: tcpRoute.Spec.Matches.Ports,
+		},
+		TrafficTargets: referencingTrafficTargets(tcpRoute, trafficTargets),
+	}
+}
+
+// referencingTrafficTargets returns the sorted names of traffic targets with a rule for the route.
+func referencingTrafficTargets(tcpRoute *smispecsv1alpha4.TCPRoute, trafficTargets []smiaccessv1alpha3.TrafficTarget) []string {
+	result := make([]string, 0)
+	for _, trafficTarget := range trafficTargets {
+		if trafficTarget.Namespace != tcpRoute.Namespace {
+			continue
+		}
+
+		for _, rule := range trafficTarget.Spec.Rules {
+			if rule.Kind == tcpRouteKind && rule.Name == tcpRoute.Name {
+				result = append(result, trafficTarget.Name)
+				break
+			}
+		}
+	}
+
+	sort.Strings(result)
+	return result
+}
+
+// CreateTCPRouteList returns paginated TCP route list based on given TCP route array and pagination query.
+func CreateTCPRouteList(tcpRoutes []smispecsv1alpha4.TCPRoute, trafficTargets []smiaccessv1alpha3.TrafficTarget,
+	nonCriticalErrors []error, dsQuery *dataselect.DataSelectQuery) *TCPRouteList {
+	tcpRouteList := &TCPRouteList{
+		TCPRoutes: make([]TCPRoute, 0),
+		ListMeta:  api.ListMeta{TotalItems: len(tcpRoutes)},
+		Errors:    nonCriticalErrors,
+	}
+
+	tcpRouteCells, filteredTotal := dataselect.GenericDataSelectWithFilter(toCells(tcpRoutes), dsQuery)
+	tcpRoutes = fromCells(tcpRouteCells)
+	tcpRouteList.ListMeta = api.ListMeta{TotalItems: filteredTotal}
+
+	for i := range tcpRoutes {
+		tcpRouteList.TCPRoutes = append(tcpRouteList.TCPRoutes, toTCPRoute(&tcpRoutes[i], trafficTargets))
+	}
+
+	return tcpRouteList
+}
@@ -0,0 +1,85 @@
+package tcproute
+
+import (
+	"reflect"
+	"testing"
+
+	smiaccessv1alpha3 "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/access/v1alpha3"
+	smispecsv1alpha4 "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/specs/v1alpha4"
+	smiaccessfake "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/access/clientset/versioned/fake"
+	smispecsfake "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/specs/clientset/versioned/fake"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+)
+
+func newTrafficTarget(name, namespace string, rules ...smiaccessv1alpha3.TrafficTargetRule) *smiaccessv1alpha3.TrafficTarget {
+	return &smiaccessv1alpha3.TrafficTarget{
+		ObjectMeta: metaV1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec:       smiaccessv1alpha3.TrafficTargetSpec{Rules: rules},
+	}
+}
+
+func newClients() (*smispecsfake.Clientset, *smiaccessfake.Clientset) {
+	smiSpecsClient := smispecsfake.NewSimpleClientset(
+		&smispecsv1alpha4.TCPRoute{
+			ObjectMeta: metaV1.ObjectMeta{Name: "bookstore-tcp", Namespace: "bookstore"},
+			Spec:       smispecsv1alpha4.TCPRouteSpec{Matches: smispecsv1alpha4.TCPMatch{Name: "tcp", Ports: []int{14001}}},
+		},
+		&smispecsv1alpha4.TCPRoute{
+			ObjectMeta: metaV1.ObjectMeta{Name: "mysql", Namespace: "bookwarehouse"},
+		},
+	)
+	smiAccessClient := smiaccessfake.NewSimpleClientset(
+		newTrafficTarget("bookbuyer", "bookstore", smiaccessv1alpha3.TrafficTargetRule{Kind: "TCPRoute", Name: "bookstore-tcp"}),
+		newTrafficTarget("bookthief", "bookstore",
+			smiaccessv1alpha3.TrafficTargetRule{Kind: "HTTPRouteGroup", Name: "bookstore-tcp"},
+			smiaccessv1alpha3.TrafficTargetRule{Kind: "TCPRoute", Name: "bookstore-tcp"}),
+		newTrafficTarget("other", "bookstore", smiaccessv1alpha3.TrafficTargetRule{Kind: "HTTPRouteGroup", Name: "bookstore-tcp"}),
+		newTrafficTarget("bookstore", "bookwarehouse", smiaccessv1alpha3.TrafficTargetRule{Kind: "TCPRoute", Name: "bookstore-tcp"}),
+	)
+	return smiSpecsClient, smiAccessClient
+}
+
+func TestGetTCPRouteList(t *testing.T) {
+	smiSpecsClient, smiAccessClient := newClients()
+
+	list, err := GetTCPRouteList(smiSpecsClient, smiAccessClient, common.NewNamespaceQuery(nil), dataselect.NoDataSelect)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if list.ListMeta.TotalItems != 2 || len(list.TCPRoutes) != 2 {
+		t.Fatalf("expected two TCP routes, but got %#v", list)
+	}
+
+	for _, route := range list.TCPRoutes {
+		expected := []string{}
+		if route.ObjectMeta.Name == "bookstore-tcp" {
+			expected = []string{"bookbuyer", "bookthief"}
+		}
+
+		if !reflect.DeepEqual(route.TrafficTargets, expected) {
+			t.Errorf("expected %s to be referenced by %v, but got %v", route.ObjectMeta.Name, expected, route.TrafficTargets)
+		}
+	}
+}
+
+func TestGetTCPRouteDetail(t *testing.T) {
+	smiSpecsClient, smiAccessClient := newClients()
+
+	detail, err := GetTCPRouteDetail(smiSpecsClient, smiAccessClient, "bookstore", "bookstore-tcp")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expectedMatch := TCPMatch{Name: "tcp", Ports: []int{14001}}
+	if !reflect.DeepEqual(detail.Match, expectedMatch) {
+		t.Errorf("expected match %#v, but got %#v", expectedMatch, detail.Match)
+	}
+
+	if !reflect.DeepEqual(detail.TrafficTargets, []string{"bookbuyer", "bookthief"}) {
+		t.Errorf("expected bookbuyer and bookthief traffic targets, but got %v", detail.TrafficTargets)
+	}
+}
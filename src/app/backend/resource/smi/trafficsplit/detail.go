@@ -0,0 +1,212 @@
+package trafficsplit
+
+import (
+	"context"
+	"log"
+	"sort"
+
+	smisplitv1alpha2 "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/split/v1alpha2"
+	smisplitclientset "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/split/clientset/versioned"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+)
+
+// TrafficSplitDetail is a traffic split with its root service and resolved backends.
+type TrafficSplitDetail struct {
+	// Extends list item structure.
+	TrafficSplit `json:",inline"`
+
+	// ResourceVersion of the traffic split, used to guard weight updates.
+	ResourceVersion string `json:"resourceVersion"`
+
+	// RootService is the service the traffic is split for. It is nil when the service does not exist.
+	RootServiceName string           `json:"rootServiceName"`
+	RootService     *ResolvedService `json:"rootService"`
+
+	// TotalWeight is the sum of the weights of all backends.
+	TotalWeight int `json:"totalWeight"`
+
+	Backends []TrafficSplitBackend `json:"backends"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// TrafficSplitBackend is a backend of a traffic split.
+type TrafficSplitBackend struct {
+	ServiceName string `json:"serviceName"`
+	Weight      int    `json:"weight"`
+
+	// Percentage is the share of the traffic the backend receives, between 0 and 100.
+	Percentage float64 `json:"percentage"`
+
+	// Service is nil when the backend service does not exist.
+	Service *ResolvedService `json:"service"`
+
+	ReadyEndpoints int `json:"readyEndpoints"`
+
+	// Deployment is the deployment whose pods are selected by the backend service, if any.
+	Deployment *BackendDeployment `json:"deployment"`
+}
+
+// ResolvedService is a service referenced by a traffic split.
+type ResolvedService struct {
+	ObjectMeta api.ObjectMeta    `json:"objectMeta"`
+	Type       v1.ServiceType    `json:"type"`
+	ClusterIP  string            `json:"clusterIP"`
+	Selector   map[string]string `json:"selector"`
+}
+
+// BackendDeployment is a deployment serving a backend of a traffic split.
+type BackendDeployment struct {
+	Name          string `json:"name"`
+	Replicas      int32  `json:"replicas"`
+	ReadyReplicas int32  `json:"readyReplicas"`
+}
+
+// GetTrafficSplitDetail returns a traffic split with its root service and resolved backends.
+func GetTrafficSplitDetail(smiSplitClient smisplitclientset.Interface, client kubernetes.Interface, namespace,
+	name string) (*TrafficSplitDetail, error) {
+	log.Printf("Getting details of %s trafficsplit in %s namespace", name, namespace)
+
+	trafficSplit, err := smiSplitClient.SplitV1alpha2().TrafficSplits(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return toTrafficSplitDetail(client, trafficSplit)
+}
+
+func toTrafficSplitDetail(client kubernetes.Interface, trafficSplit *smisplitv1alpha2.TrafficSplit) (*TrafficSplitDetail, error) {
+	namespace := trafficSplit.Namespace
+	nonCriticalErrors := make([]error, 0)
+
+	services, err := client.CoreV1().Services(namespace).List(context.TODO(), api.ListEverything)
+	nonCriticalErrors, criticalError := errors.AppendError(err, nonCriticalErrors)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	endpoints, err := client.CoreV1().Endpoints(namespace).List(context.TODO(), api.ListEverything)
+	nonCriticalErrors, criticalError = errors.AppendError(err, nonCriticalErrors)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	deployments, err := client.AppsV1().Deployments(namespace).List(context.TODO(), api.ListEverything)
+	nonCriticalErrors, criticalError = errors.AppendError(err, nonCriticalErrors)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	detail := &TrafficSplitDetail{
+		TrafficSplit:    toTrafficSplit(trafficSplit),
+		ResourceVersion: trafficSplit.ResourceVersion,
+		RootServiceName: trafficSplit.Spec.Service,
+		RootService:     findService(services, trafficSplit.Spec.Service),
+		Backends:        make([]TrafficSplitBackend, 0, len(trafficSplit.Spec.Backends)),
+		Errors:          nonCriticalErrors,
+	}
+
+	for _, backend := range trafficSplit.Spec.Backends {
+		detail.TotalWeight += backend.Weight
+	}
+
+	for _, backend := range trafficSplit.Spec.Backends {
+		result := TrafficSplitBackend{
+			ServiceName: backend.Service,
+			Weight:      backend.Weight,
+			Service:     findService(services, backend.Service),
+		}
+
+		if detail.TotalWeight > 0 {
+			result.Percentage = float64(backend.Weight) * 100 / float64(detail.TotalWeight)
+		}
+
+		result.ReadyEndpoints = countReadyEndpoints(endpoints, backend.Service)
+		if result.Service != nil {
+			result.Deployment = findDeployment(deployments, result.Service.Selector)
+		}
+
+		detail.Backends = append(detail.Backends, result)
+	}
+
+	return detail, nil
+}
+
+func findService(services *v1.ServiceList, name string) *ResolvedService {
+	if services == nil {
+		return nil
+	}
+
+	for _, service := range services.Items {
+		if service.Name == name {
+			return &ResolvedService{
+				ObjectMeta: api.NewObjectMeta(service.ObjectMeta),
+				Type:       service.Spec.Type,
+				ClusterIP:  service.Spec.ClusterIP,
+				Selector:   service.Spec.Selector,
+			}
+		}
+	}
+
+	return nil
+}
+
+func countReadyEndpoints(endpoints *v1.EndpointsList, name string) int {
+	if endpoints == nil {
+		return 0
+	}
+
+	count := 0
+	for _, endpoint := range endpoints.Items {
+		if endpoint.Name != name {
+			continue
+		}
+
+		for _, subset := range endpoint.Subsets {
+			count += len(subset.Addresses)
+		}
+	}
+
+	return count
+}
+
+// findDeployment returns the deployment whose pod template is selected by the service selector. When
+// several deployments match, the first one by name is returned.
+func findDeployment(deployments *appsv1.DeploymentList, selector map[string]string) *BackendDeployment {
+	if deployments == nil || len(selector) == 0 {
+		return nil
+	}
+
+	matching := make([]appsv1.Deployment, 0)
+	for _, deployment := range deployments.Items {
+		if labels.SelectorFromSet(selector).Matches(labels.Set(deployment.Spec.Template.Labels)) {
+			matching = append(matching, deployment)
+		}
+	}
+
+	if len(matching) == 0 {
+		return nil
+	}
+
+	sort.Slice(matching, func(i, j int) bool { return matching[i].Name < matching[j].Name })
+
+	deployment := matching[0]
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+
+	return &BackendDeployment{
+		Name:          deployment.Name,
+		Replicas:      replicas,
+		ReadyReplicas: deployment.Status.ReadyReplicas,
+	}
+}
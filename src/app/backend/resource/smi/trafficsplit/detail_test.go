@@ -0,0 +1,143 @@
+package trafficsplit
+
+import (
+	"net/http"
+	"testing"
+
+	smisplitv1alpha2 "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/split/v1alpha2"
+	smisplitfake "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/split/clientset/versioned/fake"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newTrafficSplit() *smisplitv1alpha2.TrafficSplit {
+	return &smisplitv1alpha2.TrafficSplit{
+		ObjectMeta: metaV1.ObjectMeta{Name: "bookstore-split", Namespace: "bookstore", ResourceVersion: "3"},
+		Spec: smisplitv1alpha2.TrafficSplitSpec{
+			Service: "bookstore",
+			Backends: []smisplitv1alpha2.TrafficSplitBackend{
+				{Service: "bookstore-v1", Weight: 75},
+				{Service: "bookstore-v2", Weight: 25},
+			},
+		},
+	}
+}
+
+func newBackendFixtures() *fake.Clientset {
+	newService := func(name, version string) *v1.Service {
+		selector := map[string]string{"app": "bookstore"}
+		if len(version) > 0 {
+			selector["version"] = version
+		}
+		return &v1.Service{
+			ObjectMeta: metaV1.ObjectMeta{Name: name, Namespace: "bookstore"},
+			Spec:       v1.ServiceSpec{Selector: selector, ClusterIP: "10.0.0.1"},
+		}
+	}
+
+	return fake.NewSimpleClientset(
+		newService("bookstore", ""),
+		newService("bookstore-v1", "v1"),
+		&v1.Endpoints{
+			ObjectMeta: metaV1.ObjectMeta{Name: "bookstore-v1", Namespace: "bookstore"},
+			Subsets: []v1.EndpointSubset{{
+				Addresses:         []v1.EndpointAddress{{IP: "10.1.0.1"}, {IP: "10.1.0.2"}},
+				NotReadyAddresses: []v1.EndpointAddress{{IP: "10.1.0.3"}},
+			}},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metaV1.ObjectMeta{Name: "bookstore-v1", Namespace: "bookstore"},
+			Spec: appsv1.DeploymentSpec{Template: v1.PodTemplateSpec{
+				ObjectMeta: metaV1.ObjectMeta{Labels: map[string]string{"app": "bookstore", "version": "v1"}},
+			}},
+			Status: appsv1.DeploymentStatus{ReadyReplicas: 1},
+		},
+	)
+}
+
+func TestGetTrafficSplitDetail(t *testing.T) {
+	detail, err := GetTrafficSplitDetail(smisplitfake.NewSimpleClientset(newTrafficSplit()), newBackendFixtures(),
+		"bookstore", "bookstore-split")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if detail.RootService == nil || detail.TotalWeight != 100 || detail.ResourceVersion != "3" {
+		t.Errorf("expected resolved root service and total weight 100, but got %#v", detail)
+	}
+
+	v1Backend := detail.Backends[0]
+	if v1Backend.Percentage != 75 || v1Backend.Service == nil || v1Backend.ReadyEndpoints != 2 ||
+		v1Backend.Deployment == nil || v1Backend.Deployment.Name != "bookstore-v1" {
+		t.Errorf("expected resolved bookstore-v1 backend, but got %#v", v1Backend)
+	}
+
+	v2Backend := detail.Backends[1]
+	if v2Backend.Percentage != 25 || v2Backend.Service != nil || v2Backend.ReadyEndpoints != 0 || v2Backend.Deployment != nil {
+		t.Errorf("expected unresolved bookstore-v2 backend, but got %#v", v2Backend)
+	}
+}
+
+func TestUpdateTrafficSplitWeights(t *testing.T) {
+	weights := &TrafficSplitWeights{ResourceVersion: "3", Weights: map[string]int{"bookstore-v1": 10, "bookstore-v2": 90}}
+	detail, err := UpdateTrafficSplitWeights(smisplitfake.NewSimpleClientset(newTrafficSplit()), newBackendFixtures(),
+		"bookstore", "bookstore-split", weights)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if detail.Backends[0].Weight != 10 || detail.Backends[1].Weight != 90 {
+		t.Errorf("expected weights 10 and 90, but got %#v", detail.Backends)
+	}
+}
+
+func TestUpdateTrafficSplitWeightsRejected(t *testing.T) {
+	cases := []struct {
+		info    string
+		weights *TrafficSplitWeights
+		code    int32
+	}{
+		{
+			"stale resource version",
+			&TrafficSplitWeights{ResourceVersion: "2", Weights: map[string]int{"bookstore-v1": 50, "bookstore-v2": 50}},
+			http.StatusConflict,
+		},
+		{
+			"missing backend",
+			&TrafficSplitWeights{Weights: map[string]int{"bookstore-v1": 100}},
+			http.StatusBadRequest,
+		},
+		{
+			"unknown backend",
+			&TrafficSplitWeights{Weights: map[string]int{"bookstore-v1": 50, "bookstore-v2": 50, "bookstore-v3": 0}},
+			http.StatusBadRequest,
+		},
+		{
+			"negative weight",
+			&TrafficSplitWeights{Weights: map[string]int{"bookstore-v1": 110, "bookstore-v2": -10}},
+			http.StatusBadRequest,
+		},
+		{
+			"no traffic",
+			&TrafficSplitWeights{Weights: map[string]int{"bookstore-v1": 0, "bookstore-v2": 0}},
+			http.StatusBadRequest,
+		},
+	}
+
+	for _, c := range cases {
+		_, err := UpdateTrafficSplitWeights(smisplitfake.NewSimpleClientset(newTrafficSplit()), newBackendFixtures(),
+			"bookstore", "bookstore-split", c.weights)
+		statusError, ok := err.(*k8serrors.StatusError)
+		if !ok {
+			t.Errorf("%s: expected status error, but got %v", c.info, err)
+			continue
+		}
+
+		if statusError.ErrStatus.Code != c.code {
+			t.Errorf("%s: expected code %d, but got %d", c.info, c.code, statusError.ErrStatus.Code)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package trafficsplit
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+
+	smisplitv1alpha2 "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/split/v1alpha2"
+	smisplitclientset "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/split/clientset/versioned"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+)
+
+// TrafficSplitWeights is a change of the backend weights of a traffic split.
+type TrafficSplitWeights struct {
+	// ResourceVersion is the version of the traffic split the change was made on. When set, the
+	// change is rejected if the traffic split has changed since.
+	ResourceVersion string `json:"resourceVersion"`
+
+	// Weights maps every backend service of the traffic split to its new weight.
+	Weights map[string]int `json:"weights"`
+}
+
+// UpdateTrafficSplitWeights sets the weights of all backends of a traffic split in a single update.
+func UpdateTrafficSplitWeights(smiSplitClient smisplitclientset.Interface, client kubernetes.Interface, namespace,
+	name string, weights *TrafficSplitWeights) (*TrafficSplitDetail, error) {
+	log.Printf("Updating weights of %s trafficsplit in %s namespace", name, namespace)
+
+	trafficSplit, err := smiSplitClient.SplitV1alpha2().TrafficSplits(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(weights.ResourceVersion) > 0 && weights.ResourceVersion != trafficSplit.ResourceVersion {
+		return nil, errors.NewGenericResponse(http.StatusConflict, fmt.Sprintf(
+			"trafficsplit %s in namespace %s was changed since it was read (resourceVersion %s, current %s), reload it and retry",
+			name, namespace, weights.ResourceVersion, trafficSplit.ResourceVersion))
+	}
+
+	if problems := validateWeights(trafficSplit.Spec.Backends, weights.Weights); len(problems) > 0 {
+		return nil, errors.NewBadRequest(strings.Join(problems, "; "))
+	}
+
+	for i := range trafficSplit.Spec.Backends {
+		trafficSplit.Spec.Backends[i].Weight = weights.Weights[trafficSplit.Spec.Backends[i].Service]
+	}
+
+	// The update carries the resource version that was read, so the API server rejects it when the
+	// traffic split was changed concurrently.
+	updated, err := smiSplitClient.SplitV1alpha2().TrafficSplits(namespace).Update(context.TODO(), trafficSplit, metaV1.UpdateOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("Successfully updated weights of %s trafficsplit in %s namespace", name, namespace)
+	return toTrafficSplitDetail(client, updated)
+}
+
+// validateWeights checks that the weights cover exactly the backends of the traffic split and that
+// at least one backend receives traffic.
+func validateWeights(backends []smisplitv1alpha2.TrafficSplitBackend, weights map[string]int) []string {
+	problems := make([]string, 0)
+	known := make(map[string]bool)
+	total := 0
+
+	for _, backend := range backends {
+		known[backend.Service] = true
+		weight, ok := weights[backend.Service]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("weight of backend %s is missing", backend.Service))
+			continue
+		}
+
+		if weight < 0 {
+			problems = append(problems, fmt.Sprintf("weight of backend %s must not be negative", backend.Service))
+		}
+		total += weight
+	}
+
+	unknown := make([]string, 0)
+	for service := range weights {
+		if !known[service] {
+			unknown = append(unknown, service)
+		}
+	}
+
+	sort.Strings(unknown)
+	for _, service := range unknown {
+		problems = append(problems, fmt.Sprintf("%s is not a backend of the trafficsplit", service))
+	}
+
+	if len(problems) == 0 && total == 0 {
+		problems = append(problems, "at least one backend must have a positive weight")
+	}
+
+	return problems
+}
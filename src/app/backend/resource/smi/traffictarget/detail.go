@@ -0,0 +1,230 @@
+package traffictarget
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+
+	smiaccessv1alpha3 "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/access/v1alpha3"
+	smispecsv1alpha4 "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/specs/v1alpha4"
+	smiaccessclientset "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/access/clientset/versioned"
+	smispecsclientset "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/specs/clientset/versioned"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+)
+
+const (
+	httpRouteGroupKind = "HTTPRouteGroup"
+	tcpRouteKind       = "TCPRoute"
+)
+
+// TrafficTargetDetail is a traffic target with its identities expanded into workloads and its
+// rules expanded into route matches.
+type TrafficTargetDetail struct {
+	// Extends list item structure.
+	TrafficTarget `json:",inline"`
+
+	Destination Identity   `json:"destination"`
+	Sources     []Identity `json:"sources"`
+	Rules       []Rule     `json:"rules"`
+
+	// Warnings flag references to missing service accounts, routes and matches.
+	Warnings []string `json:"warnings"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// Rule is a route referenced by a traffic target with the matches it allows.
+type Rule struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+
+	// Found is false when the route does not exist or has an unsupported kind.
+	Found bool `json:"found"`
+
+	HTTPMatches []HTTPMatch `json:"httpMatches"`
+	TCPMatches  []TCPMatch  `json:"tcpMatches"`
+
+	// MissingMatches are names of matches referenced by the rule, but not defined in the route.
+	MissingMatches []string `json:"missingMatches"`
+}
+
+// HTTPMatch is a concrete HTTP match of a route group.
+type HTTPMatch struct {
+	Name      string            `json:"name"`
+	Methods   []string          `json:"methods"`
+	PathRegex string            `json:"pathRegex"`
+	Headers   map[string]string `json:"headers"`
+}
+
+// TCPMatch is a concrete TCP match of a route.
+type TCPMatch struct {
+	Name  string `json:"name"`
+	Ports []int  `json:"ports"`
+}
+
+// GetTrafficTargetDetail returns a traffic target with resolved identities and rules.
+func GetTrafficTargetDetail(smiAccessClient smiaccessclientset.Interface, smiSpecsClient smispecsclientset.Interface,
+	client kubernetes.Interface, namespace, name string) (*TrafficTargetDetail, error) {
+	log.Printf("Getting details of %s traffictarget in %s namespace", name, namespace)
+
+	trafficTarget, err := smiAccessClient.AccessV1alpha3().TrafficTargets(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	resolver := newIdentityResolver(client)
+	detail := &TrafficTargetDetail{
+		TrafficTarget: toTrafficTarget(trafficTarget),
+		Sources:       make([]Identity, 0, len(trafficTarget.Spec.Sources)),
+		Rules:         make([]Rule, 0, len(trafficTarget.Spec.Rules)),
+		Warnings:      make([]string, 0),
+	}
+
+	detail.Destination, err = resolver.resolve(trafficTarget.Spec.Destination, namespace)
+	if err != nil {
+		return nil, err
+	}
+	detail.Warnings = append(detail.Warnings, identityWarnings("destination", detail.Destination)...)
+
+	for _, source := range trafficTarget.Spec.Sources {
+		identity, err := resolver.resolve(source, namespace)
+		if err != nil {
+			return nil, err
+		}
+		detail.Sources = append(detail.Sources, identity)
+		detail.Warnings = append(detail.Warnings, identityWarnings("source", identity)...)
+	}
+
+	nonCriticalErrors := resolver.errors
+	for _, trafficTargetRule := range trafficTarget.Spec.Rules {
+		rule, err := resolveRule(smiSpecsClient, namespace, trafficTargetRule)
+		nonCriticalErrors, err = errors.AppendError(err, nonCriticalErrors)
+		if err != nil {
+			return nil, err
+		}
+
+		detail.Rules = append(detail.Rules, rule)
+		detail.Warnings = append(detail.Warnings, ruleWarnings(rule)...)
+	}
+
+	detail.Errors = nonCriticalErrors
+	return detail, nil
+}
+
+func identityWarnings(role string, identity Identity) []string {
+	if identity.Kind != serviceAccountKind {
+		return []string{fmt.Sprintf("%s %s/%s has unsupported kind %q", role, identity.Namespace, identity.Name, identity.Kind)}
+	}
+
+	if !identity.Found {
+		return []string{fmt.Sprintf("%s service account %s/%s does not exist", role, identity.Namespace, identity.Name)}
+	}
+
+	return nil
+}
+
+func ruleWarnings(rule Rule) []string {
+	if rule.Kind != httpRouteGroupKind && rule.Kind != tcpRouteKind {
+		return []string{fmt.Sprintf("rule %s has unsupported kind %q", rule.Name, rule.Kind)}
+	}
+
+	warnings := make([]string, 0)
+	if !rule.Found {
+		warnings = append(warnings, fmt.Sprintf("%s %s does not exist", rule.Kind, rule.Name))
+	}
+
+	for _, match := range rule.MissingMatches {
+		warnings = append(warnings, fmt.Sprintf("%s %s has no match %q", rule.Kind, rule.Name, match))
+	}
+
+	return warnings
+}
+
+// resolveRule expands a rule into the matches of its route. A rule without match names allows all
+// matches of the route. Routes are always in the namespace of the traffic target.
+func resolveRule(smiSpecsClient smispecsclientset.Interface, namespace string,
+	trafficTargetRule smiaccessv1alpha3.TrafficTargetRule) (Rule, error) {
+	rule := Rule{
+		Kind:           trafficTargetRule.Kind,
+		Name:           trafficTargetRule.Name,
+		HTTPMatches:    make([]HTTPMatch, 0),
+		TCPMatches:     make([]TCPMatch, 0),
+		MissingMatches: make([]string, 0),
+	}
+
+	selected := make(map[string]bool)
+	for _, match := range trafficTargetRule.Matches {
+		selected[match] = false
+	}
+	isSelected := func(name string) bool {
+		if len(trafficTargetRule.Matches) == 0 {
+			return true
+		}
+		if _, ok := selected[name]; ok {
+			selected[name] = true
+			return true
+		}
+		return false
+	}
+
+	switch trafficTargetRule.Kind {
+	case httpRouteGroupKind:
+		routeGroup, err := smiSpecsClient.SpecsV1alpha4().HTTPRouteGroups(namespace).Get(context.TODO(), trafficTargetRule.Name, metaV1.GetOptions{})
+		if k8serrors.IsNotFound(err) {
+			return rule, nil
+		}
+		if err != nil {
+			return rule, err
+		}
+
+		rule.Found = true
+		for _, match := range routeGroup.Spec.Matches {
+			if isSelected(match.Name) {
+				rule.HTTPMatches = append(rule.HTTPMatches, toHTTPMatch(match))
+			}
+		}
+	case tcpRouteKind:
+		route, err := smiSpecsClient.SpecsV1alpha4().TCPRoutes(namespace).Get(context.TODO(), trafficTargetRule.Name, metaV1.GetOptions{})
+		if k8serrors.IsNotFound(err) {
+			return rule, nil
+		}
+		if err != nil {
+			return rule, err
+		}
+
+		rule.Found = true
+		if isSelected(route.Spec.Matches.Name) {
+			rule.TCPMatches = append(rule.TCPMatches, TCPMatch{Name: route.Spec.Matches.Name, Ports: route.Spec.Matches.Ports})
+		}
+	default:
+		return rule, nil
+	}
+
+	for match, found := range selected {
+		if !found {
+			rule.MissingMatches = append(rule.MissingMatches, match)
+		}
+	}
+	sort.Strings(rule.MissingMatches)
+
+	return rule, nil
+}
+
+func toHTTPMatch(match smispecsv1alpha4.HTTPMatch) HTTPMatch {
+	result := HTTPMatch{
+		Name:      match.Name,
+		Methods:   match.Methods,
+		PathRegex: match.PathRegex,
+		Headers:   make(map[string]string, len(match.Headers)),
+	}
+	for header, value := range match.Headers {
+		result.Headers[header] = value
+	}
+	return result
+}
@@ -0,0 +1,111 @@
+package traffictarget
+
+import (
+	"reflect"
+	"testing"
+
+	smiaccessv1alpha3 "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/access/v1alpha3"
+	smispecsv1alpha4 "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/specs/v1alpha4"
+	smiaccessfake "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/access/clientset/versioned/fake"
+	smispecsfake "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/specs/clientset/versioned/fake"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetTrafficTargetDetail(t *testing.T) {
+	trafficTarget := &smiaccessv1alpha3.TrafficTarget{
+		ObjectMeta: metaV1.ObjectMeta{Name: "bookstore", Namespace: "bookstore"},
+		Spec: smiaccessv1alpha3.TrafficTargetSpec{
+			Destination: smiaccessv1alpha3.IdentityBindingSubject{Kind: "ServiceAccount", Name: "bookstore"},
+			Sources: []smiaccessv1alpha3.IdentityBindingSubject{
+				{Kind: "ServiceAccount", Name: "bookbuyer", Namespace: "bookbuyer"},
+				{Kind: "ServiceAccount", Name: "bookthief", Namespace: "bookthief"},
+			},
+			Rules: []smiaccessv1alpha3.TrafficTargetRule{
+				{Kind: "HTTPRouteGroup", Name: "bookstore-service-routes", Matches: []string{"buy-a-book", "sell-a-book"}},
+				{Kind: "TCPRoute", Name: "bookstore-tcp"},
+				{Kind: "UDPRoute", Name: "bookstore-udp"},
+			},
+		},
+	}
+
+	routeGroup := &smispecsv1alpha4.HTTPRouteGroup{
+		ObjectMeta: metaV1.ObjectMeta{Name: "bookstore-service-routes", Namespace: "bookstore"},
+		Spec: smispecsv1alpha4.HTTPRouteGroupSpec{Matches: []smispecsv1alpha4.HTTPMatch{
+			{Name: "buy-a-book", PathRegex: ".*a-book.*new", Methods: []string{"GET"}},
+			{Name: "books-bought", PathRegex: "/books-bought", Methods: []string{"GET"}},
+		}},
+	}
+
+	k8sClient := fake.NewSimpleClientset(
+		&v1.ServiceAccount{ObjectMeta: metaV1.ObjectMeta{Name: "bookstore", Namespace: "bookstore"}},
+		&v1.ServiceAccount{ObjectMeta: metaV1.ObjectMeta{Name: "bookbuyer", Namespace: "bookbuyer"}},
+		&v1.Pod{
+			ObjectMeta: metaV1.ObjectMeta{Name: "bookstore-7c8d9", Namespace: "bookstore", Labels: map[string]string{"app": "bookstore"}},
+			Spec:       v1.PodSpec{ServiceAccountName: "bookstore"},
+		},
+		&v1.Pod{
+			ObjectMeta: metaV1.ObjectMeta{Name: "other", Namespace: "bookstore", Labels: map[string]string{"app": "other"}},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metaV1.ObjectMeta{Name: "bookstore", Namespace: "bookstore"},
+			Spec: appsv1.DeploymentSpec{Template: v1.PodTemplateSpec{
+				ObjectMeta: metaV1.ObjectMeta{Labels: map[string]string{"app": "bookstore"}},
+				Spec:       v1.PodSpec{ServiceAccountName: "bookstore"},
+			}},
+		},
+		&v1.Service{
+			ObjectMeta: metaV1.ObjectMeta{Name: "bookstore", Namespace: "bookstore"},
+			Spec:       v1.ServiceSpec{Selector: map[string]string{"app": "bookstore"}},
+		},
+		&v1.Service{
+			ObjectMeta: metaV1.ObjectMeta{Name: "other", Namespace: "bookstore"},
+			Spec:       v1.ServiceSpec{Selector: map[string]string{"app": "other"}},
+		},
+	)
+
+	detail, err := GetTrafficTargetDetail(smiaccessfake.NewSimpleClientset(trafficTarget),
+		smispecsfake.NewSimpleClientset(routeGroup), k8sClient, "bookstore", "bookstore")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expectedDestination := Identity{
+		Kind:        "ServiceAccount",
+		Name:        "bookstore",
+		Namespace:   "bookstore",
+		Found:       true,
+		Pods:        []string{"bookstore-7c8d9"},
+		Deployments: []string{"bookstore"},
+		Services:    []string{"bookstore"},
+	}
+	if !reflect.DeepEqual(detail.Destination, expectedDestination) {
+		t.Errorf("expected destination %#v, but got %#v", expectedDestination, detail.Destination)
+	}
+
+	if !detail.Sources[0].Found || detail.Sources[1].Found {
+		t.Errorf("expected only bookbuyer source to be found, but got %#v", detail.Sources)
+	}
+
+	httpRule := detail.Rules[0]
+	if !httpRule.Found || len(httpRule.HTTPMatches) != 1 || httpRule.HTTPMatches[0].Name != "buy-a-book" ||
+		!reflect.DeepEqual(httpRule.MissingMatches, []string{"sell-a-book"}) {
+		t.Errorf("expected buy-a-book match and missing sell-a-book match, but got %#v", httpRule)
+	}
+
+	if detail.Rules[1].Found {
+		t.Errorf("expected missing TCPRoute, but got %#v", detail.Rules[1])
+	}
+
+	expectedWarnings := []string{
+		"source service account bookthief/bookthief does not exist",
+		`HTTPRouteGroup bookstore-service-routes has no match "sell-a-book"`,
+		"TCPRoute bookstore-tcp does not exist",
+		`rule bookstore-udp has unsupported kind "UDPRoute"`,
+	}
+	if !reflect.DeepEqual(detail.Warnings, expectedWarnings) {
+		t.Errorf("expected warnings %#v, but got %#v", expectedWarnings, detail.Warnings)
+	}
+}
@@ -0,0 +1,205 @@
+package traffictarget
+
+import (
+	"context"
+	"sort"
+
+	smiaccessv1alpha3 "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/access/v1alpha3"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+)
+
+// serviceAccountKind is the only identity kind supported by SMI traffic targets.
+const serviceAccountKind = "ServiceAccount"
+
+// defaultServiceAccount is used by pods that do not set a service account.
+const defaultServiceAccount = "default"
+
+// Identity is a service account of a traffic target with the workloads that run as it.
+type Identity struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+
+	// Found is false when the service account does not exist.
+	Found bool `json:"found"`
+
+	Pods        []string `json:"pods"`
+	Deployments []string `json:"deployments"`
+	Services    []string `json:"services"`
+}
+
+// identityResolver expands service accounts into the workloads that run as them. Workloads are read
+// once per namespace.
+type identityResolver struct {
+	client     kubernetes.Interface
+	namespaces map[string]*namespaceWorkloads
+	errors     []error
+}
+
+type namespaceWorkloads struct {
+	serviceAccounts map[string]bool
+	pods            []v1.Pod
+	deployments     []appsv1.Deployment
+	services        []v1.Service
+}
+
+func newIdentityResolver(client kubernetes.Interface) *identityResolver {
+	return &identityResolver{
+		client:     client,
+		namespaces: make(map[string]*namespaceWorkloads),
+		errors:     make([]error, 0),
+	}
+}
+
+// resolve returns the identity of a subject. Subjects without a namespace are in the namespace of
+// the traffic target.
+func (self *identityResolver) resolve(subject smiaccessv1alpha3.IdentityBindingSubject, defaultNamespace string) (Identity, error) {
+	namespace := subject.Namespace
+	if len(namespace) == 0 {
+		namespace = defaultNamespace
+	}
+
+	identity := Identity{
+		Kind:        subject.Kind,
+		Name:        subject.Name,
+		Namespace:   namespace,
+		Pods:        make([]string, 0),
+		Deployments: make([]string, 0),
+		Services:    make([]string, 0),
+	}
+
+	if subject.Kind != serviceAccountKind {
+		return identity, nil
+	}
+
+	workloads, err := self.workloads(namespace)
+	if err != nil {
+		return identity, err
+	}
+
+	// Service accounts that could not be listed are assumed to exist.
+	identity.Found = workloads.serviceAccounts == nil || workloads.serviceAccounts[subject.Name]
+
+	podLabels := make([]labels.Set, 0)
+	for _, pod := range workloads.pods {
+		if podServiceAccount(pod.Spec) == subject.Name {
+			identity.Pods = append(identity.Pods, pod.Name)
+			podLabels = append(podLabels, pod.Labels)
+		}
+	}
+
+	for _, deployment := range workloads.deployments {
+		if podServiceAccount(deployment.Spec.Template.Spec) == subject.Name {
+			identity.Deployments = append(identity.Deployments, deployment.Name)
+			podLabels = append(podLabels, deployment.Spec.Template.Labels)
+		}
+	}
+
+	for _, service := range workloads.services {
+		if len(service.Spec.Selector) == 0 {
+			continue
+		}
+
+		selector := labels.SelectorFromSet(service.Spec.Selector)
+		for _, set := range podLabels {
+			if selector.Matches(set) {
+				identity.Services = append(identity.Services, service.Name)
+				break
+			}
+		}
+	}
+
+	sort.Strings(identity.Pods)
+	sort.Strings(identity.Deployments)
+	sort.Strings(identity.Services)
+	return identity, nil
+}
+
+// ResolveIdentities returns the identities of subjects with the workloads that run as them, together
+// with non-critical errors that occurred while reading the workloads.
+func ResolveIdentities(client kubernetes.Interface, subjects []smiaccessv1alpha3.IdentityBindingSubject,
+	defaultNamespace string) ([]Identity, []error, error) {
+	resolver := newIdentityResolver(client)
+	identities := make([]Identity, 0, len(subjects))
+	for _, subject := range subjects {
+		identity, err := resolver.resolve(subject, defaultNamespace)
+		if err != nil {
+			return nil, nil, err
+		}
+		identities = append(identities, identity)
+	}
+
+	return identities, resolver.errors, nil
+}
+
+func (self *identityResolver) workloads(namespace string) (*namespaceWorkloads, error) {
+	if workloads, ok := self.namespaces[namespace]; ok {
+		return workloads, nil
+	}
+
+	workloads := new(namespaceWorkloads)
+
+	serviceAccounts, err := self.client.CoreV1().ServiceAccounts(namespace).List(context.TODO(), api.ListEverything)
+	if err := self.appendError(err); err != nil {
+		return nil, err
+	}
+	if err == nil {
+		workloads.serviceAccounts = make(map[string]bool)
+		for _, serviceAccount := range serviceAccounts.Items {
+			workloads.serviceAccounts[serviceAccount.Name] = true
+		}
+	}
+
+	pods, err := self.client.CoreV1().Pods(namespace).List(context.TODO(), api.ListEverything)
+	if err := self.appendError(err); err != nil {
+		return nil, err
+	}
+	if err == nil {
+		workloads.pods = pods.Items
+	}
+
+	deployments, err := self.client.AppsV1().Deployments(namespace).List(context.TODO(), api.ListEverything)
+	if err := self.appendError(err); err != nil {
+		return nil, err
+	}
+	if err == nil {
+		workloads.deployments = deployments.Items
+	}
+
+	services, err := self.client.CoreV1().Services(namespace).List(context.TODO(), api.ListEverything)
+	if err := self.appendError(err); err != nil {
+		return nil, err
+	}
+	if err == nil {
+		workloads.services = services.Items
+	}
+
+	self.namespaces[namespace] = workloads
+	return workloads, nil
+}
+
+// appendError keeps non-critical errors, such as missing permissions in one namespace, and returns
+// critical ones.
+func (self *identityResolver) appendError(err error) error {
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+
+	nonCriticalErrors, criticalError := errors.AppendError(err, self.errors)
+	self.errors = nonCriticalErrors
+	return criticalError
+}
+
+func podServiceAccount(spec v1.PodSpec) string {
+	if len(spec.ServiceAccountName) == 0 {
+		return defaultServiceAccount
+	}
+	return spec.ServiceAccountName
+}
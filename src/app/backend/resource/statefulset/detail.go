@@ -21,7 +21,10 @@ import (
 	"github.com/kubernetes/dashboard/src/app/backend/errors"
 	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/osm/sidecar"
+	osmconfigclientset "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned"
 	apps "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 )
@@ -36,8 +39,8 @@ type StatefulSetDetail struct {
 }
 
 // GetStatefulSetDetail gets Stateful Set details.
-func GetStatefulSetDetail(client kubernetes.Interface, metricClient metricapi.MetricClient, namespace,
-	name string) (*StatefulSetDetail, error) {
+func GetStatefulSetDetail(osmConfigClient osmconfigclientset.Interface, client kubernetes.Interface,
+	metricClient metricapi.MetricClient, namespace, name string) (*StatefulSetDetail, error) {
 	log.Printf("Getting details of %s statefulset in %s namespace", name, namespace)
 
 	ss, err := client.AppsV1().StatefulSets(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
@@ -45,19 +48,27 @@ func GetStatefulSetDetail(client kubernetes.Interface, metricClient metricapi.Me
 		return nil, err
 	}
 
-	podInfo, err := getStatefulSetPodInfo(client, ss)
+	pods, err := getRawStatefulSetPods(client, ss.Name, ss.Namespace)
 	nonCriticalErrors, criticalError := errors.HandleError(err)
 	if criticalError != nil {
 		return nil, criticalError
 	}
+	podInfo := common.GetPodInfo(ss.Status.Replicas, ss.Spec.Replicas, pods)
 
-	ssDetail := getStatefulSetDetail(ss, podInfo, nonCriticalErrors)
+	meshes, err := sidecar.GetMeshes(osmConfigClient, client, sidecar.SidecarNamespaces(pods))
+	if err != nil {
+		return nil, err
+	}
+
+	ssDetail := getStatefulSetDetail(ss, &podInfo, pods, nonCriticalErrors)
+	meshes.ResolveWorkload(&ssDetail.Sidecars, namespace)
 	return &ssDetail, nil
 }
 
-func getStatefulSetDetail(statefulSet *apps.StatefulSet, podInfo *common.PodInfo, nonCriticalErrors []error) StatefulSetDetail {
+func getStatefulSetDetail(statefulSet *apps.StatefulSet, podInfo *common.PodInfo, pods []v1.Pod,
+	nonCriticalErrors []error) StatefulSetDetail {
 	return StatefulSetDetail{
-		StatefulSet: toStatefulSet(statefulSet, podInfo),
+		StatefulSet: toStatefulSet(statefulSet, podInfo, pods),
 		Errors:      nonCriticalErrors,
 	}
 }
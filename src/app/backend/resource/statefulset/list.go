@@ -23,6 +23,8 @@ import (
 	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/event"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/osm/sidecar"
+	osmconfigclientset "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned"
 	apps "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/kubernetes"
@@ -47,10 +49,13 @@ type StatefulSet struct {
 	Pods                common.PodInfo `json:"podInfo"`
 	ContainerImages     []string       `json:"containerImages"`
 	InitContainerImages []string       `json:"initContainerImages"`
+
+	// Sidecar proxies of the pods of the Stateful Set.
+	Sidecars sidecar.WorkloadSidecars `json:"sidecars"`
 }
 
-// GetStatefulSetList returns a list of all Stateful Sets in the cluster.
-func GetStatefulSetList(client kubernetes.Interface, nsQuery *common.NamespaceQuery,
+// GetStatefulSetList returns a list of all Stateful Sets in the cluster, with the mesh of their sidecars.
+func GetStatefulSetList(osmConfigClient osmconfigclientset.Interface, client kubernetes.Interface, nsQuery *common.NamespaceQuery,
 	dsQuery *dataselect.DataSelectQuery, metricClient metricapi.MetricClient) (*StatefulSetList, error) {
 	log.Print("Getting list of all pet sets in the cluster")
 
@@ -60,7 +65,27 @@ func GetStatefulSetList(client kubernetes.Interface, nsQuery *common.NamespaceQu
 		EventList:       common.GetEventListChannel(client, nsQuery, 1),
 	}
 
-	return GetStatefulSetListFromChannels(channels, dsQuery, metricClient)
+	statefulSetList, err := GetStatefulSetListFromChannels(channels, dsQuery, metricClient)
+	if err != nil {
+		return nil, err
+	}
+
+	namespaces := make([]string, 0)
+	for _, item := range statefulSetList.StatefulSets {
+		if item.Sidecars.Injected > 0 {
+			namespaces = append(namespaces, item.ObjectMeta.Namespace)
+		}
+	}
+	meshes, err := sidecar.GetMeshes(osmConfigClient, client, namespaces)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range statefulSetList.StatefulSets {
+		meshes.ResolveWorkload(&statefulSetList.StatefulSets[i].Sidecars, statefulSetList.StatefulSets[i].ObjectMeta.Namespace)
+	}
+
+	return statefulSetList, nil
 }
 
 // GetStatefulSetListFromChannels returns a list of all Stateful Sets in the cluster reading
@@ -115,7 +140,7 @@ func toStatefulSetList(statefulSets []apps.StatefulSet, pods []v1.Pod, events []
 		matchingPods := common.FilterPodsByControllerRef(&statefulSet, pods)
 		podInfo := common.GetPodInfo(statefulSet.Status.Replicas, statefulSet.Spec.Replicas, matchingPods)
 		podInfo.Warnings = event.GetPodsEventWarnings(events, matchingPods)
-		statefulSetList.StatefulSets = append(statefulSetList.StatefulSets, toStatefulSet(&statefulSet, &podInfo))
+		statefulSetList.StatefulSets = append(statefulSetList.StatefulSets, toStatefulSet(&statefulSet, &podInfo, matchingPods))
 	}
 
 	cumulativeMetrics, err := metricPromises.GetMetrics()
@@ -127,12 +152,13 @@ func toStatefulSetList(statefulSets []apps.StatefulSet, pods []v1.Pod, events []
 	return statefulSetList
 }
 
-func toStatefulSet(statefulSet *apps.StatefulSet, podInfo *common.PodInfo) StatefulSet {
+func toStatefulSet(statefulSet *apps.StatefulSet, podInfo *common.PodInfo, pods []v1.Pod) StatefulSet {
 	return StatefulSet{
 		ObjectMeta:          api.NewObjectMeta(statefulSet.ObjectMeta),
 		TypeMeta:            api.NewTypeMeta(api.ResourceKindStatefulSet),
 		ContainerImages:     common.GetContainerImages(&statefulSet.Spec.Template.Spec),
 		InitContainerImages: common.GetInitContainerImages(&statefulSet.Spec.Template.Spec),
 		Pods:                *podInfo,
+		Sidecars:            sidecar.GetWorkloadSidecars(pods),
 	}
 }
@@ -24,7 +24,6 @@ import (
 	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/event"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/pod"
-	apps "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
@@ -69,14 +68,3 @@ func getRawStatefulSetPods(client kubernetes.Interface, name, namespace string)
 
 	return common.FilterPodsByControllerRef(statefulSet, podList.Items), nil
 }
-
-// Returns simple info about pods(running, desired, failing, etc.) related to given pet set.
-func getStatefulSetPodInfo(client kubernetes.Interface, statefulSet *apps.StatefulSet) (*common.PodInfo, error) {
-	pods, err := getRawStatefulSetPods(client, statefulSet.Name, statefulSet.Namespace)
-	if err != nil {
-		return nil, err
-	}
-
-	podInfo := common.GetPodInfo(statefulSet.Status.Replicas, statefulSet.Spec.Replicas, pods)
-	return &podInfo, nil
-}
@@ -0,0 +1,242 @@
+package rollout
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+
+	restful "github.com/emicklei/go-restful/v3"
+	smisplitv1alpha2 "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/split/v1alpha2"
+	smisplitclientset "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/split/clientset/versioned"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/kubernetes/dashboard/src/app/backend/args"
+	clientapi "github.com/kubernetes/dashboard/src/app/backend/client/api"
+	backenderrors "github.com/kubernetes/dashboard/src/app/backend/errors"
+)
+
+// trafficSplitsResource is the resource rollouts change the weights of.
+var trafficSplitsResource = smisplitv1alpha2.SchemeGroupVersion.WithResource("trafficsplits").GroupResource()
+
+// RolloutHandler manages canary rollouts of traffic splits.
+type RolloutHandler struct {
+	clientManager clientapi.ClientManager
+	rollouts      *RolloutManager
+}
+
+// Install creates new endpoints for rollout management.
+func (self RolloutHandler) Install(ws *restful.WebService) {
+	ws.Route(
+		ws.POST("/rollout").
+			To(self.handleStartRollout).
+			Reads(RolloutSpec{}).
+			Writes(Rollout{}))
+
+	ws.Route(
+		ws.GET("/rollout").
+			To(self.handleGetRollouts).
+			Writes(RolloutList{}))
+
+	ws.Route(
+		ws.GET("/rollout/{namespace}/{trafficsplit}").
+			To(self.handleGetRollout).
+			Writes(Rollout{}))
+
+	ws.Route(
+		ws.POST("/rollout/{namespace}/{trafficsplit}/pause").
+			To(self.handlePauseRollout).
+			Writes(Rollout{}))
+
+	ws.Route(
+		ws.POST("/rollout/{namespace}/{trafficsplit}/resume").
+			To(self.handleResumeRollout).
+			Writes(Rollout{}))
+
+	ws.Route(
+		ws.POST("/rollout/{namespace}/{trafficsplit}/abort").
+			To(self.handleAbortRollout).
+			Writes(Rollout{}))
+}
+
+func (self RolloutHandler) handleStartRollout(request *restful.Request, response *restful.Response) {
+	smiSplitClient, err := self.clientManager.SmiSplitClient(request)
+	if err != nil {
+		backenderrors.HandleInternalError(response, err)
+		return
+	}
+
+	spec := new(RolloutSpec)
+	if err := request.ReadEntity(spec); err != nil {
+		backenderrors.HandleInternalError(response, err)
+		return
+	}
+
+	result, err := self.rollouts.Start(smiSplitClient, spec)
+	if err != nil {
+		backenderrors.HandleInternalError(response, err)
+		return
+	}
+
+	response.WriteHeaderAndEntity(http.StatusCreated, result)
+}
+
+func (self RolloutHandler) handleGetRollouts(request *restful.Request, response *restful.Response) {
+	smiSplitClient, err := self.clientManager.SmiSplitClient(request)
+	if err != nil {
+		backenderrors.HandleInternalError(response, err)
+		return
+	}
+
+	// Only rollouts of traffic splits the user can get are listed.
+	rollouts := self.rollouts.List()
+	result := RolloutList{Rollouts: make([]Rollout, 0, len(rollouts.Rollouts))}
+	for _, rollout := range rollouts.Rollouts {
+		err := authorize(smiSplitClient, rollout.Spec.Namespace, rollout.Spec.TrafficSplit)
+		if k8serrors.IsForbidden(err) || k8serrors.IsUnauthorized(err) {
+			continue
+		}
+		if err != nil {
+			backenderrors.HandleInternalError(response, err)
+			return
+		}
+		result.Rollouts = append(result.Rollouts, rollout)
+	}
+
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (self RolloutHandler) handleGetRollout(request *restful.Request, response *restful.Response) {
+	namespace, trafficSplit := request.PathParameter("namespace"), request.PathParameter("trafficsplit")
+	if err := self.authorizeRequest(request, namespace, trafficSplit); err != nil {
+		backenderrors.HandleInternalError(response, err)
+		return
+	}
+
+	result, err := self.rollouts.Get(namespace, trafficSplit)
+	if err != nil {
+		backenderrors.HandleInternalError(response, err)
+		return
+	}
+
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (self RolloutHandler) handlePauseRollout(request *restful.Request, response *restful.Response) {
+	namespace, trafficSplit := request.PathParameter("namespace"), request.PathParameter("trafficsplit")
+	if err := self.authorizeRequest(request, namespace, trafficSplit); err != nil {
+		backenderrors.HandleInternalError(response, err)
+		return
+	}
+
+	// Pausing does not write the traffic split, so the permission to update it, which resuming and
+	// aborting need for their weight changes, is checked explicitly.
+	if !self.canUpdate(request, namespace, trafficSplit) {
+		backenderrors.HandleInternalError(response, k8serrors.NewForbidden(trafficSplitsResource, trafficSplit,
+			fmt.Errorf("pausing a rollout requires permission to update its trafficsplit")))
+		return
+	}
+
+	result, err := self.rollouts.Pause(namespace, trafficSplit)
+	if err != nil {
+		backenderrors.HandleInternalError(response, err)
+		return
+	}
+
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (self RolloutHandler) handleResumeRollout(request *restful.Request, response *restful.Response) {
+	smiSplitClient, err := self.clientManager.SmiSplitClient(request)
+	if err != nil {
+		backenderrors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace, trafficSplit := request.PathParameter("namespace"), request.PathParameter("trafficsplit")
+	if err := authorize(smiSplitClient, namespace, trafficSplit); err != nil {
+		backenderrors.HandleInternalError(response, err)
+		return
+	}
+
+	result, err := self.rollouts.Resume(smiSplitClient, namespace, trafficSplit)
+	if err != nil {
+		backenderrors.HandleInternalError(response, err)
+		return
+	}
+
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (self RolloutHandler) handleAbortRollout(request *restful.Request, response *restful.Response) {
+	smiSplitClient, err := self.clientManager.SmiSplitClient(request)
+	if err != nil {
+		backenderrors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace, trafficSplit := request.PathParameter("namespace"), request.PathParameter("trafficsplit")
+	if err := authorize(smiSplitClient, namespace, trafficSplit); err != nil {
+		backenderrors.HandleInternalError(response, err)
+		return
+	}
+
+	result, err := self.rollouts.Abort(smiSplitClient, namespace, trafficSplit)
+	if err != nil {
+		backenderrors.HandleInternalError(response, err)
+		return
+	}
+
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+// authorizeRequest checks that the user of a request can get a traffic split.
+func (self RolloutHandler) authorizeRequest(request *restful.Request, namespace, trafficSplit string) error {
+	smiSplitClient, err := self.clientManager.SmiSplitClient(request)
+	if err != nil {
+		return err
+	}
+	return authorize(smiSplitClient, namespace, trafficSplit)
+}
+
+// canUpdate checks that the user of a request can update a traffic split.
+func (self RolloutHandler) canUpdate(request *restful.Request, namespace, trafficSplit string) bool {
+	return self.clientManager.CanI(request, &authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: namespace,
+				Name:      trafficSplit,
+				Verb:      "update",
+				Group:     trafficSplitsResource.Group,
+				Resource:  trafficSplitsResource.Resource,
+			},
+		},
+	})
+}
+
+// authorize checks that a client can get a traffic split. Rollouts outlive their traffic split, and
+// the API server only reports a traffic split as missing to users allowed to get it.
+func authorize(client smisplitclientset.Interface, namespace, trafficSplit string) error {
+	_, err := client.SplitV1alpha2().TrafficSplits(namespace).Get(context.TODO(), trafficSplit, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// NewRolloutHandler creates a rollout handler, restores the persisted rollouts and starts
+// reconciling them. The
+// metric source may be nil, in which case rollouts can only follow their schedule.
+func NewRolloutHandler(clientManager clientapi.ClientManager, metrics MetricSource) RolloutHandler {
+	rollouts := NewRolloutManager(clientManager.InsecureClient(), args.Holder.GetNamespace(), metrics)
+
+	if err := rollouts.Restore(); err != nil {
+		log.Printf("Failed to restore rollouts: %s", err)
+	}
+	go rollouts.Run(wait.NeverStop)
+
+	return RolloutHandler{clientManager: clientManager, rollouts: rollouts}
+}
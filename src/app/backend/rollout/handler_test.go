@@ -0,0 +1,89 @@
+package rollout
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	restful "github.com/emicklei/go-restful/v3"
+	smisplitclientset "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/split/clientset/versioned"
+	smisplitfake "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/split/clientset/versioned/fake"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clienttesting "k8s.io/client-go/testing"
+
+	clientapi "github.com/kubernetes/dashboard/src/app/backend/client/api"
+)
+
+// fakeClientManager hands out a fixed SMI split client and grants only the given verbs.
+type fakeClientManager struct {
+	clientapi.ClientManager
+	client smisplitclientset.Interface
+	verbs  map[string]bool
+}
+
+func (self *fakeClientManager) SmiSplitClient(req *restful.Request) (smisplitclientset.Interface, error) {
+	return self.client, nil
+}
+
+func (self *fakeClientManager) CanI(req *restful.Request, ssar *authorizationv1.SelfSubjectAccessReview) bool {
+	return self.verbs[ssar.Spec.ResourceAttributes.Verb]
+}
+
+func TestAuthorize(t *testing.T) {
+	client := smisplitfake.NewSimpleClientset(newTrafficSplit())
+	if err := authorize(client, "bookstore", "bookstore-split"); err != nil {
+		t.Errorf("expected access to an existing trafficsplit, but got %s", err)
+	}
+	if err := authorize(client, "bookstore", "deleted-split"); err != nil {
+		t.Errorf("expected access to the rollout of a deleted trafficsplit, but got %s", err)
+	}
+
+	client.PrependReactor("get", "trafficsplits", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		return true, nil, k8serrors.NewForbidden(schema.GroupResource{Resource: "trafficsplits"}, "bookstore-split", nil)
+	})
+	if err := authorize(client, "bookstore", "bookstore-split"); !k8serrors.IsForbidden(err) {
+		t.Errorf("expected forbidden, but got %v", err)
+	}
+}
+
+func TestPauseRolloutRequiresUpdate(t *testing.T) {
+	client := smisplitfake.NewSimpleClientset(newTrafficSplit())
+	manager, _ := newTestManager(nil)
+	if _, err := manager.Start(client, newRolloutSpec(nil)); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	cases := []struct {
+		verbs          map[string]bool
+		expectedStatus int
+		expectedPhase  RolloutPhase
+	}{
+		{map[string]bool{"get": true}, http.StatusForbidden, RolloutPhaseProgressing},
+		{map[string]bool{"get": true, "update": true}, http.StatusOK, RolloutPhasePaused},
+	}
+
+	for _, c := range cases {
+		handler := RolloutHandler{clientManager: &fakeClientManager{client: client, verbs: c.verbs}, rollouts: manager}
+		ws := new(restful.WebService).Produces(restful.MIME_JSON)
+		handler.Install(ws)
+		container := restful.NewContainer()
+		container.Add(ws)
+
+		recorder := httptest.NewRecorder()
+		container.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/rollout/bookstore/bookstore-split/pause", nil))
+		if recorder.Code != c.expectedStatus {
+			t.Errorf("expected status %d for verbs %v, but got %d: %s", c.expectedStatus, c.verbs, recorder.Code, recorder.Body)
+		}
+
+		rollout, err := manager.Get("bookstore", "bookstore-split")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if rollout.Phase != c.expectedPhase {
+			t.Errorf("expected rollout to be %s for verbs %v, but got %s", c.expectedPhase, c.verbs, rollout.Phase)
+		}
+	}
+}
@@ -0,0 +1,508 @@
+package rollout
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	smisplitclientset "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/split/clientset/versioned"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+)
+
+// reconcileInterval is how often active rollouts are checked for due steps and analysis.
+const reconcileInterval = 10 * time.Second
+
+// RolloutManager steps the backend weights of traffic splits and keeps the state of rollouts in a
+// config map. Weights are changed with the SMI split client of the user that started or last
+// resumed a rollout.
+type RolloutManager struct {
+	// mutex guards the maps of the manager. It is only held to read or store their entries, never
+	// while talking to the API server or the metric source, so rollouts can be read meanwhile.
+	mutex    sync.Mutex
+	rollouts map[string]*Rollout
+	clients  map[string]smisplitclientset.Interface
+
+	// locks serialize the changes of each rollout. They are held across the API calls of a change
+	// and taken before the mutex.
+	locks map[string]*sync.Mutex
+
+	store   *rolloutStore
+	metrics MetricSource
+	now     func() time.Time
+}
+
+// NewRolloutManager creates a manager that persists rollouts in the given namespace. The metric
+// source may be nil, in which case rollouts with analysis are rejected.
+func NewRolloutManager(client kubernetes.Interface, namespace string, metrics MetricSource) *RolloutManager {
+	return &RolloutManager{
+		rollouts: make(map[string]*Rollout),
+		clients:  make(map[string]smisplitclientset.Interface),
+		locks:    make(map[string]*sync.Mutex),
+		store:    &rolloutStore{client: client, namespace: namespace},
+		metrics:  metrics,
+		now:      time.Now,
+	}
+}
+
+// Restore loads the persisted rollouts. It has to complete before Run is started. Rollouts that were progressing are paused, as the clients
+// they changed weights with are gone; resuming them continues with the client of the user resuming.
+func (self *RolloutManager) Restore() error {
+	rollouts, err := self.store.load()
+	if err != nil {
+		return err
+	}
+
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	for _, rollout := range rollouts {
+		if rollout.Phase == RolloutPhaseProgressing {
+			rollout.Phase = RolloutPhasePaused
+			rollout.Message = "Paused after Dashboard restart, resume to continue"
+			self.save(rollout)
+		}
+		self.rollouts[rollout.Spec.key()] = rollout
+	}
+
+	return nil
+}
+
+// Run reconciles active rollouts until the stop channel is closed.
+func (self *RolloutManager) Run(stopCh <-chan struct{}) {
+	ticker := time.NewTicker(reconcileInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-ticker.C:
+			self.reconcile()
+		}
+	}
+}
+
+// Start begins a rollout by applying the weight of its first step.
+func (self *RolloutManager) Start(client smisplitclientset.Interface, spec *RolloutSpec) (*Rollout, error) {
+	log.Printf("Starting rollout of %s trafficsplit in %s namespace", spec.TrafficSplit, spec.Namespace)
+
+	defer self.lock(spec.key())()
+
+	if existing, err := self.Get(spec.Namespace, spec.TrafficSplit); err == nil && existing.isActive() {
+		return nil, errors.NewGenericResponse(http.StatusConflict, fmt.Sprintf(
+			"trafficsplit %s in namespace %s already has a %s rollout", spec.TrafficSplit, spec.Namespace, existing.Phase))
+	}
+
+	trafficSplit, err := client.SplitV1alpha2().TrafficSplits(spec.Namespace).Get(context.TODO(), spec.TrafficSplit, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := spec.validate(trafficSplit, self.metrics != nil); err != nil {
+		return nil, err
+	}
+
+	now := metav1.NewTime(self.now())
+	rollout := &Rollout{
+		Spec:            *spec,
+		Phase:           RolloutPhaseProgressing,
+		OriginalWeights: make(map[string]int),
+		StartTime:       now,
+		StepStartTime:   now,
+		Analysis:        make([]AnalysisResult, 0),
+	}
+	for _, backend := range trafficSplit.Spec.Backends {
+		rollout.OriginalWeights[backend.Service] = backend.Weight
+	}
+
+	if err := self.applyStepWeight(client, rollout); err != nil {
+		return nil, err
+	}
+
+	rollout.Message = fmt.Sprintf("Step 1 of %d: %d%% of traffic to %s", len(spec.Steps), rollout.CurrentWeight, spec.Canary)
+	return self.commit(rollout, client), nil
+}
+
+// Get returns the rollout of a traffic split.
+func (self *RolloutManager) Get(namespace, trafficSplit string) (*Rollout, error) {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	rollout, err := self.get(namespace, trafficSplit)
+	if err != nil {
+		return nil, err
+	}
+
+	result := rollout.copy()
+	return &result, nil
+}
+
+// List returns all rollouts, sorted by start time, newest first.
+func (self *RolloutManager) List() RolloutList {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	result := RolloutList{Rollouts: make([]Rollout, 0, len(self.rollouts))}
+	for _, rollout := range self.rollouts {
+		result.Rollouts = append(result.Rollouts, rollout.copy())
+	}
+
+	sort.Slice(result.Rollouts, func(i, j int) bool {
+		return result.Rollouts[j].StartTime.Before(&result.Rollouts[i].StartTime)
+	})
+	return result
+}
+
+// Pause holds a progressing rollout at its current step.
+func (self *RolloutManager) Pause(namespace, trafficSplit string) (*Rollout, error) {
+	defer self.lock(rolloutKey(namespace, trafficSplit))()
+
+	rollout, err := self.Get(namespace, trafficSplit)
+	if err != nil {
+		return nil, err
+	}
+
+	if rollout.Phase != RolloutPhaseProgressing {
+		return nil, errors.NewGenericResponse(http.StatusConflict, fmt.Sprintf("rollout is %s, only progressing rollouts can be paused", rollout.Phase))
+	}
+
+	rollout.Phase = RolloutPhasePaused
+	rollout.Message = fmt.Sprintf("Paused at step %d", rollout.CurrentStep+1)
+	return self.commit(rollout, nil), nil
+}
+
+// Resume continues a paused rollout. The pause of the current step starts over.
+func (self *RolloutManager) Resume(client smisplitclientset.Interface, namespace, trafficSplit string) (*Rollout, error) {
+	defer self.lock(rolloutKey(namespace, trafficSplit))()
+
+	rollout, err := self.Get(namespace, trafficSplit)
+	if err != nil {
+		return nil, err
+	}
+
+	if rollout.Phase != RolloutPhasePaused {
+		return nil, errors.NewGenericResponse(http.StatusConflict, fmt.Sprintf("rollout is %s, only paused rollouts can be resumed", rollout.Phase))
+	}
+
+	// Weights may have been changed while the rollout was paused, so the current step is applied again.
+	if err := self.applyStepWeight(client, rollout); err != nil {
+		return nil, err
+	}
+
+	rollout.Phase = RolloutPhaseProgressing
+	rollout.StepStartTime = metav1.NewTime(self.now())
+	rollout.Message = fmt.Sprintf("Resumed at step %d", rollout.CurrentStep+1)
+	return self.commit(rollout, client), nil
+}
+
+// Abort stops an active rollout and restores the weights the traffic split had before it.
+func (self *RolloutManager) Abort(client smisplitclientset.Interface, namespace, trafficSplit string) (*Rollout, error) {
+	defer self.lock(rolloutKey(namespace, trafficSplit))()
+
+	rollout, err := self.Get(namespace, trafficSplit)
+	if err != nil {
+		return nil, err
+	}
+
+	if !rollout.isActive() {
+		return nil, errors.NewGenericResponse(http.StatusConflict, fmt.Sprintf("rollout is already %s", rollout.Phase))
+	}
+
+	if err := self.restoreWeights(client, rollout); err != nil {
+		return nil, err
+	}
+
+	self.complete(rollout, RolloutPhaseAborted, "Aborted, original weights restored")
+	return self.commit(rollout, nil), nil
+}
+
+func (self *RolloutManager) get(namespace, trafficSplit string) (*Rollout, error) {
+	rollout, ok := self.rollouts[rolloutKey(namespace, trafficSplit)]
+	if !ok {
+		return nil, errors.NewNotFound(fmt.Sprintf("no rollout of trafficsplit %s in namespace %s", trafficSplit, namespace))
+	}
+	return rollout, nil
+}
+
+// commit stores a changed copy of a rollout under the mutex and persists it. The client, if not nil,
+// replaces the client of the rollout, which is dropped once the rollout is no longer active. It
+// returns a copy of the stored rollout.
+func (self *RolloutManager) commit(rollout *Rollout, client smisplitclientset.Interface) *Rollout {
+	key := rollout.Spec.key()
+
+	self.mutex.Lock()
+	self.rollouts[key] = rollout
+	if client != nil {
+		self.clients[key] = client
+	}
+	if !rollout.isActive() {
+		delete(self.clients, key)
+	}
+	result := rollout.copy()
+	self.mutex.Unlock()
+
+	self.save(rollout)
+	return &result
+}
+
+// lock takes the lock of a rollout and returns the function releasing it.
+func (self *RolloutManager) lock(key string) func() {
+	self.mutex.Lock()
+	lock, ok := self.locks[key]
+	if !ok {
+		lock = new(sync.Mutex)
+		self.locks[key] = lock
+	}
+	self.mutex.Unlock()
+
+	lock.Lock()
+	return lock.Unlock
+}
+
+// reconcile analyzes progressing rollouts and advances those whose current step is complete.
+func (self *RolloutManager) reconcile() {
+	self.mutex.Lock()
+	keys := make([]string, 0, len(self.rollouts))
+	for key, rollout := range self.rollouts {
+		if rollout.Phase == RolloutPhaseProgressing {
+			keys = append(keys, key)
+		}
+	}
+	self.mutex.Unlock()
+
+	for _, key := range keys {
+		self.reconcileKey(key)
+	}
+}
+
+// reconcileKey reconciles a copy of a rollout without holding the mutex, and commits the copy once
+// done. The lock of the rollout keeps it from being changed meanwhile.
+func (self *RolloutManager) reconcileKey(key string) {
+	defer self.lock(key)()
+
+	self.mutex.Lock()
+	rollout, ok := self.rollouts[key]
+	if !ok || rollout.Phase != RolloutPhaseProgressing {
+		self.mutex.Unlock()
+		return
+	}
+
+	client, ok := self.clients[key]
+	if !ok {
+		rollout.Phase = RolloutPhasePaused
+		rollout.Message = "Paused because no client is available, resume to continue"
+		self.mutex.Unlock()
+		self.save(rollout)
+		return
+	}
+
+	work := rollout.copy()
+	self.mutex.Unlock()
+
+	if !self.reconcileRollout(client, &work) {
+		return
+	}
+
+	self.commit(&work, nil)
+}
+
+// reconcileRollout analyzes and advances a rollout, and returns whether it changed.
+func (self *RolloutManager) reconcileRollout(client smisplitclientset.Interface, rollout *Rollout) bool {
+	now := self.now()
+	analysis := rollout.Spec.Analysis
+	changed := false
+
+	if analysis != nil && self.analysisDue(rollout, now) {
+		result := self.analyze(rollout, now)
+		rollout.addAnalysis(result)
+		analysisTime := metav1.NewTime(now)
+		rollout.LastAnalysisTime = &analysisTime
+		changed = true
+
+		if result.Status == AnalysisStatusFailed {
+			if err := self.restoreWeights(client, rollout); err != nil {
+				self.setError(rollout, err)
+				return true
+			}
+
+			self.complete(rollout, RolloutPhaseRolledBack, "Rolled back: "+result.Message)
+			return true
+		}
+	}
+
+	if now.Sub(rollout.StepStartTime.Time) < rollout.Spec.Steps[rollout.CurrentStep].Pause.Duration {
+		return changed
+	}
+
+	// With analysis, a step is only left after a passing check made during the step.
+	if analysis != nil && !self.stepAnalysisPassed(rollout) {
+		return changed
+	}
+
+	if rollout.CurrentStep == len(rollout.Spec.Steps)-1 {
+		self.complete(rollout, RolloutPhaseSucceeded, fmt.Sprintf("Completed with %d%% of traffic to %s",
+			rollout.CurrentWeight, rollout.Spec.Canary))
+		return true
+	}
+
+	rollout.CurrentStep++
+	if err := self.applyStepWeight(client, rollout); err != nil {
+		rollout.CurrentStep--
+		self.setError(rollout, err)
+		return true
+	}
+
+	rollout.StepStartTime = metav1.NewTime(now)
+	rollout.Message = fmt.Sprintf("Step %d of %d: %d%% of traffic to %s", rollout.CurrentStep+1,
+		len(rollout.Spec.Steps), rollout.CurrentWeight, rollout.Spec.Canary)
+	return true
+}
+
+// analysisDue returns true when the analysis interval has passed since the current step started
+// and since the last check.
+func (self *RolloutManager) analysisDue(rollout *Rollout, now time.Time) bool {
+	since := rollout.StepStartTime.Time
+	if rollout.LastAnalysisTime != nil && rollout.LastAnalysisTime.After(since) {
+		since = rollout.LastAnalysisTime.Time
+	}
+	return now.Sub(since) >= rollout.Spec.Analysis.Interval.Duration
+}
+
+func (self *RolloutManager) stepAnalysisPassed(rollout *Rollout) bool {
+	for i := len(rollout.Analysis) - 1; i >= 0; i-- {
+		result := rollout.Analysis[i]
+		if result.Time.Before(&rollout.StepStartTime) {
+			return false
+		}
+		if result.Status == AnalysisStatusPassed {
+			return true
+		}
+	}
+	return false
+}
+
+func (self *RolloutManager) analyze(rollout *Rollout, now time.Time) AnalysisResult {
+	analysis := rollout.Spec.Analysis
+	result := AnalysisResult{Time: metav1.NewTime(now), Status: AnalysisStatusInconclusive}
+
+	metrics, err := self.metrics.BackendMetrics(rollout.Spec.Namespace, rollout.Spec.Canary, analysis.Interval.Duration)
+	if err != nil {
+		result.Message = fmt.Sprintf("Could not get metrics of %s: %s", rollout.Spec.Canary, err)
+		return result
+	}
+
+	result.Requests = metrics.Requests
+	result.SuccessRate = metrics.SuccessRate
+	result.Latency = metav1.Duration{Duration: metrics.Latency}
+
+	if metrics.Requests == 0 {
+		result.Message = fmt.Sprintf("%s received no requests", rollout.Spec.Canary)
+		return result
+	}
+
+	if metrics.SuccessRate < analysis.MinSuccessRate {
+		result.Status = AnalysisStatusFailed
+		result.Message = fmt.Sprintf("success rate %.2f%% of %s is below %.2f%%", metrics.SuccessRate*100,
+			rollout.Spec.Canary, analysis.MinSuccessRate*100)
+		return result
+	}
+
+	if analysis.MaxLatency.Duration > 0 && metrics.Latency > analysis.MaxLatency.Duration {
+		result.Status = AnalysisStatusFailed
+		result.Message = fmt.Sprintf("latency %s of %s is above %s", metrics.Latency, rollout.Spec.Canary,
+			analysis.MaxLatency.Duration)
+		return result
+	}
+
+	result.Status = AnalysisStatusPassed
+	result.Message = "All thresholds met"
+	return result
+}
+
+// applyStepWeight sends the weight of the current step to the canary and shares the rest between
+// the other backends.
+func (self *RolloutManager) applyStepWeight(client smisplitclientset.Interface, rollout *Rollout) error {
+	weight := rollout.Spec.Steps[rollout.CurrentStep].Weight
+	if err := self.updateWeights(client, rollout, rollout.stepWeights(weight)); err != nil {
+		return err
+	}
+
+	rollout.CurrentWeight = weight
+	return nil
+}
+
+func (self *RolloutManager) restoreWeights(client smisplitclientset.Interface, rollout *Rollout) error {
+	return self.updateWeights(client, rollout, rollout.OriginalWeights)
+}
+
+func (self *RolloutManager) updateWeights(client smisplitclientset.Interface, rollout *Rollout, weights map[string]int) error {
+	namespace, name := rollout.Spec.Namespace, rollout.Spec.TrafficSplit
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		trafficSplit, err := client.SplitV1alpha2().TrafficSplits(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		for i, backend := range trafficSplit.Spec.Backends {
+			if weight, ok := weights[backend.Service]; ok {
+				trafficSplit.Spec.Backends[i].Weight = weight
+			}
+		}
+
+		_, err = client.SplitV1alpha2().TrafficSplits(namespace).Update(context.TODO(), trafficSplit, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// setError records a failed weight change. The rollout is retried on the next reconcile, unless
+// its traffic split is gone.
+func (self *RolloutManager) setError(rollout *Rollout, err error) {
+	log.Printf("Rollout of %s trafficsplit in %s namespace: %s", rollout.Spec.TrafficSplit, rollout.Spec.Namespace, err)
+
+	if k8serrors.IsNotFound(err) {
+		self.complete(rollout, RolloutPhaseFailed, "Trafficsplit was deleted")
+		return
+	}
+
+	rollout.Message = fmt.Sprintf("Could not update weights, retrying: %s", err)
+}
+
+// complete sets the final phase of a rollout.
+func (self *RolloutManager) complete(rollout *Rollout, phase RolloutPhase, message string) {
+	log.Printf("Rollout of %s trafficsplit in %s namespace %s: %s", rollout.Spec.TrafficSplit, rollout.Spec.Namespace,
+		phase, message)
+
+	now := metav1.NewTime(self.now())
+	rollout.Phase = phase
+	rollout.Message = message
+	rollout.CompletionTime = &now
+}
+
+// save persists a rollout. Failures are only logged, the rollout keeps running from memory.
+func (self *RolloutManager) save(rollout *Rollout) {
+	if err := self.store.save(rollout); err != nil {
+		log.Printf("Failed to persist rollout of %s trafficsplit in %s namespace: %s", rollout.Spec.TrafficSplit,
+			rollout.Spec.Namespace, err)
+	}
+}
+
+func (self *Rollout) copy() Rollout {
+	result := *self
+	result.Spec.Steps = append([]RolloutStep(nil), self.Spec.Steps...)
+	result.Analysis = append(make([]AnalysisResult, 0, len(self.Analysis)), self.Analysis...)
+	result.OriginalWeights = make(map[string]int, len(self.OriginalWeights))
+	for service, weight := range self.OriginalWeights {
+		result.OriginalWeights[service] = weight
+	}
+	return result
+}
@@ -0,0 +1,358 @@
+package rollout
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	smisplitv1alpha2 "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/split/v1alpha2"
+	smisplitclientset "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/split/clientset/versioned"
+	smisplitfake "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/split/clientset/versioned/fake"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+type fakeMetricSource struct {
+	metrics *BackendMetrics
+}
+
+func (self *fakeMetricSource) BackendMetrics(namespace, service string, window time.Duration) (*BackendMetrics, error) {
+	return self.metrics, nil
+}
+
+type fakeClock struct {
+	time time.Time
+}
+
+func (self *fakeClock) now() time.Time {
+	return self.time
+}
+
+func (self *fakeClock) advance(duration time.Duration) {
+	self.time = self.time.Add(duration)
+}
+
+func newTrafficSplit() *smisplitv1alpha2.TrafficSplit {
+	return &smisplitv1alpha2.TrafficSplit{
+		ObjectMeta: metav1.ObjectMeta{Name: "bookstore-split", Namespace: "bookstore"},
+		Spec: smisplitv1alpha2.TrafficSplitSpec{
+			Service: "bookstore",
+			Backends: []smisplitv1alpha2.TrafficSplitBackend{
+				{Service: "bookstore-v1", Weight: 100},
+				{Service: "bookstore-v2", Weight: 0},
+			},
+		},
+	}
+}
+
+func newRolloutSpec(analysis *RolloutAnalysis) *RolloutSpec {
+	pause := metav1.Duration{Duration: time.Minute}
+	return &RolloutSpec{
+		Namespace:    "bookstore",
+		TrafficSplit: "bookstore-split",
+		Canary:       "bookstore-v2",
+		Stable:       "bookstore-v1",
+		Steps:        []RolloutStep{{Weight: 5, Pause: pause}, {Weight: 50, Pause: pause}, {Weight: 100}},
+		Analysis:     analysis,
+	}
+}
+
+func newTestManager(metrics MetricSource) (*RolloutManager, *fakeClock) {
+	clock := &fakeClock{time: time.Date(2022, 7, 1, 12, 0, 0, 0, time.UTC)}
+	manager := NewRolloutManager(fake.NewSimpleClientset(), "kubernetes-dashboard", metrics)
+	manager.now = clock.now
+	return manager, clock
+}
+
+func getWeights(t *testing.T, client smisplitclientset.Interface) map[string]int {
+	trafficSplit, err := client.SplitV1alpha2().TrafficSplits("bookstore").Get(context.TODO(), "bookstore-split", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	weights := make(map[string]int)
+	for _, backend := range trafficSplit.Spec.Backends {
+		weights[backend.Service] = backend.Weight
+	}
+	return weights
+}
+
+func TestRolloutFollowsSchedule(t *testing.T) {
+	client := smisplitfake.NewSimpleClientset(newTrafficSplit())
+	manager, clock := newTestManager(nil)
+
+	if _, err := manager.Start(client, newRolloutSpec(nil)); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if weights := getWeights(t, client); weights["bookstore-v2"] != 5 || weights["bookstore-v1"] != 95 {
+		t.Errorf("expected first step weights 95/5, but got %v", weights)
+	}
+
+	clock.advance(30 * time.Second)
+	manager.reconcile()
+	if weights := getWeights(t, client); weights["bookstore-v2"] != 5 {
+		t.Errorf("expected rollout to hold the first step during its pause, but got %v", weights)
+	}
+
+	clock.advance(30 * time.Second)
+	manager.reconcile()
+	if weights := getWeights(t, client); weights["bookstore-v2"] != 50 {
+		t.Errorf("expected second step weight 50, but got %v", weights)
+	}
+
+	clock.advance(time.Minute)
+	manager.reconcile()
+	manager.reconcile()
+
+	rollout, _ := manager.Get("bookstore", "bookstore-split")
+	if rollout.Phase != RolloutPhaseSucceeded || rollout.CurrentWeight != 100 {
+		t.Errorf("expected succeeded rollout with weight 100, but got %s with %d", rollout.Phase, rollout.CurrentWeight)
+	}
+}
+
+func TestRolloutRollsBackOnBreach(t *testing.T) {
+	client := smisplitfake.NewSimpleClientset(newTrafficSplit())
+	metrics := &fakeMetricSource{metrics: &BackendMetrics{Requests: 100, SuccessRate: 1, Latency: 50 * time.Millisecond}}
+	manager, clock := newTestManager(metrics)
+
+	analysis := &RolloutAnalysis{
+		MinSuccessRate: 0.99,
+		MaxLatency:     metav1.Duration{Duration: 200 * time.Millisecond},
+		Interval:       metav1.Duration{Duration: time.Minute},
+	}
+	if _, err := manager.Start(client, newRolloutSpec(analysis)); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	clock.advance(time.Minute)
+	manager.reconcile()
+	if weights := getWeights(t, client); weights["bookstore-v2"] != 50 {
+		t.Errorf("expected passing analysis to advance to weight 50, but got %v", weights)
+	}
+
+	metrics.metrics = &BackendMetrics{Requests: 100, SuccessRate: 1, Latency: time.Second}
+	clock.advance(time.Minute)
+	manager.reconcile()
+
+	rollout, _ := manager.Get("bookstore", "bookstore-split")
+	if rollout.Phase != RolloutPhaseRolledBack {
+		t.Errorf("expected latency breach to roll back, but got %s", rollout.Phase)
+	}
+
+	if weights := getWeights(t, client); weights["bookstore-v2"] != 0 || weights["bookstore-v1"] != 100 {
+		t.Errorf("expected original weights to be restored, but got %v", weights)
+	}
+
+	if len(rollout.Analysis) != 2 || rollout.Analysis[1].Status != AnalysisStatusFailed {
+		t.Errorf("expected failed analysis to be recorded, but got %#v", rollout.Analysis)
+	}
+}
+
+func TestRolloutWaitsForConclusiveAnalysis(t *testing.T) {
+	client := smisplitfake.NewSimpleClientset(newTrafficSplit())
+	manager, clock := newTestManager(&fakeMetricSource{metrics: &BackendMetrics{}})
+
+	analysis := &RolloutAnalysis{MinSuccessRate: 0.99, Interval: metav1.Duration{Duration: time.Minute}}
+	if _, err := manager.Start(client, newRolloutSpec(analysis)); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	clock.advance(2 * time.Minute)
+	manager.reconcile()
+
+	rollout, _ := manager.Get("bookstore", "bookstore-split")
+	if rollout.Phase != RolloutPhaseProgressing || rollout.CurrentStep != 0 {
+		t.Errorf("expected rollout to hold the first step without requests, but got %s at step %d",
+			rollout.Phase, rollout.CurrentStep)
+	}
+}
+
+func TestRolloutPauseResumeAbort(t *testing.T) {
+	client := smisplitfake.NewSimpleClientset(newTrafficSplit())
+	manager, clock := newTestManager(nil)
+
+	if _, err := manager.Start(client, newRolloutSpec(nil)); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if _, err := manager.Pause("bookstore", "bookstore-split"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	clock.advance(time.Hour)
+	manager.reconcile()
+	if weights := getWeights(t, client); weights["bookstore-v2"] != 5 {
+		t.Errorf("expected paused rollout to keep weight 5, but got %v", weights)
+	}
+
+	if _, err := manager.Resume(client, "bookstore", "bookstore-split"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	rollout, err := manager.Abort(client, "bookstore", "bookstore-split")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if rollout.Phase != RolloutPhaseAborted || getWeights(t, client)["bookstore-v1"] != 100 {
+		t.Errorf("expected aborted rollout with original weights, but got %s", rollout.Phase)
+	}
+
+	if _, err := manager.Abort(client, "bookstore", "bookstore-split"); err == nil {
+		t.Error("expected abort of a finished rollout to fail")
+	}
+}
+
+func TestRolloutRestore(t *testing.T) {
+	client := smisplitfake.NewSimpleClientset(newTrafficSplit())
+	manager, _ := newTestManager(nil)
+
+	if _, err := manager.Start(client, newRolloutSpec(nil)); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	restored := NewRolloutManager(manager.store.client, manager.store.namespace, nil)
+	if err := restored.Restore(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	rollout, err := restored.Get("bookstore", "bookstore-split")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if rollout.Phase != RolloutPhasePaused || rollout.CurrentWeight != 5 || rollout.OriginalWeights["bookstore-v1"] != 100 {
+		t.Errorf("expected restored rollout to be paused at weight 5, but got %#v", rollout)
+	}
+}
+
+func TestStartRolloutRejected(t *testing.T) {
+	cases := []struct {
+		info string
+		spec func(spec *RolloutSpec)
+		code int32
+	}{
+		{"unknown canary", func(spec *RolloutSpec) { spec.Canary = "bookstore-v3" }, http.StatusBadRequest},
+		{"decreasing weights", func(spec *RolloutSpec) { spec.Steps[1].Weight = 1 }, http.StatusBadRequest},
+		{"analysis without metric source", func(spec *RolloutSpec) {
+			spec.Analysis = &RolloutAnalysis{MinSuccessRate: 0.9, Interval: metav1.Duration{Duration: time.Minute}}
+		}, http.StatusBadRequest},
+		{"active rollout", func(spec *RolloutSpec) {}, http.StatusConflict},
+	}
+
+	client := smisplitfake.NewSimpleClientset(newTrafficSplit())
+	manager, _ := newTestManager(nil)
+	if _, err := manager.Start(client, newRolloutSpec(nil)); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for _, c := range cases {
+		spec := newRolloutSpec(nil)
+		c.spec(spec)
+
+		target := manager
+		if c.code != http.StatusConflict {
+			target, _ = newTestManager(nil)
+		}
+
+		_, err := target.Start(client, spec)
+		statusError, ok := err.(*k8serrors.StatusError)
+		if !ok || statusError.ErrStatus.Code != c.code {
+			t.Errorf("%s: expected error code %d, but got %v", c.info, c.code, err)
+		}
+	}
+}
+
+func TestRolloutScalesOtherBackends(t *testing.T) {
+	trafficSplit := newTrafficSplit()
+	trafficSplit.Spec.Backends = []smisplitv1alpha2.TrafficSplitBackend{
+		{Service: "bookstore-v1", Weight: 60},
+		{Service: "bookstore-v2", Weight: 0},
+		{Service: "bookstore-legacy", Weight: 40},
+	}
+	client := smisplitfake.NewSimpleClientset(trafficSplit)
+	manager, clock := newTestManager(nil)
+
+	if _, err := manager.Start(client, newRolloutSpec(nil)); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if weights := getWeights(t, client); weights["bookstore-v2"] != 5 || weights["bookstore-v1"] != 57 ||
+		weights["bookstore-legacy"] != 38 {
+		t.Errorf("expected first step weights 57/5/38, but got %v", weights)
+	}
+
+	clock.advance(time.Minute)
+	manager.reconcile()
+	if weights := getWeights(t, client); weights["bookstore-v2"] != 50 || weights["bookstore-v1"] != 30 ||
+		weights["bookstore-legacy"] != 20 {
+		t.Errorf("expected second step weights 30/50/20, but got %v", weights)
+	}
+}
+
+// listingMetricSource lists the rollouts while it is asked for metrics, which only completes when
+// reconciling does not hold the mutex of the manager.
+type listingMetricSource struct {
+	manager *RolloutManager
+	listed  int
+}
+
+func (self *listingMetricSource) BackendMetrics(namespace, service string, window time.Duration) (*BackendMetrics, error) {
+	self.listed = len(self.manager.List().Rollouts)
+	return &BackendMetrics{Requests: 100, SuccessRate: 1}, nil
+}
+
+func TestReconcileDoesNotBlockReads(t *testing.T) {
+	client := smisplitfake.NewSimpleClientset(newTrafficSplit())
+	metrics := new(listingMetricSource)
+	manager, clock := newTestManager(metrics)
+	metrics.manager = manager
+
+	analysis := &RolloutAnalysis{MinSuccessRate: 0.99, Interval: metav1.Duration{Duration: time.Minute}}
+	if _, err := manager.Start(client, newRolloutSpec(analysis)); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	clock.advance(time.Minute)
+	manager.reconcile()
+
+	if metrics.listed != 1 {
+		t.Errorf("expected rollouts to be listed during analysis, but got %d", metrics.listed)
+	}
+	if weights := getWeights(t, client); weights["bookstore-v2"] != 50 {
+		t.Errorf("expected passing analysis to advance to weight 50, but got %v", weights)
+	}
+}
+
+func TestWeightChangesDoNotBlockReads(t *testing.T) {
+	client := smisplitfake.NewSimpleClientset(newTrafficSplit())
+	manager, _ := newTestManager(nil)
+
+	updates := 0
+	client.PrependReactor("update", "trafficsplits", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		manager.List()
+		updates++
+		return false, nil, nil
+	})
+
+	if _, err := manager.Start(client, newRolloutSpec(nil)); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := manager.Pause("bookstore", "bookstore-split"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := manager.Resume(client, "bookstore", "bookstore-split"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := manager.Abort(client, "bookstore", "bookstore-split"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if updates != 3 {
+		t.Errorf("expected start, resume and abort to update the trafficsplit, but got %d updates", updates)
+	}
+}
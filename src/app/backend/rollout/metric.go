@@ -0,0 +1,23 @@
+package rollout
+
+import (
+	"time"
+)
+
+// BackendMetrics are the request metrics of a backend service over a time window.
+type BackendMetrics struct {
+	// Requests is the number of requests received in the window.
+	Requests int64
+
+	// SuccessRate is the ratio of successful requests, between 0 and 1.
+	SuccessRate float64
+
+	// Latency is the 99th percentile request latency.
+	Latency time.Duration
+}
+
+// MetricSource provides the request metrics rollouts are analyzed with.
+type MetricSource interface {
+	// BackendMetrics returns the metrics of the service over the window ending now.
+	BackendMetrics(namespace, service string, window time.Duration) (*BackendMetrics, error)
+}
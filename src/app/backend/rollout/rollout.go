@@ -0,0 +1,227 @@
+package rollout
+
+import (
+	"fmt"
+	"strings"
+
+	smisplitv1alpha2 "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/split/v1alpha2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+)
+
+// RolloutPhase is the current stage of a canary rollout.
+type RolloutPhase string
+
+const (
+	RolloutPhaseProgressing RolloutPhase = "Progressing"
+	RolloutPhasePaused      RolloutPhase = "Paused"
+	RolloutPhaseSucceeded   RolloutPhase = "Succeeded"
+	RolloutPhaseAborted     RolloutPhase = "Aborted"
+	RolloutPhaseRolledBack  RolloutPhase = "RolledBack"
+	RolloutPhaseFailed      RolloutPhase = "Failed"
+)
+
+// AnalysisStatus is the outcome of a check of the canary backend.
+type AnalysisStatus string
+
+const (
+	AnalysisStatusPassed AnalysisStatus = "Passed"
+	AnalysisStatusFailed AnalysisStatus = "Failed"
+
+	// AnalysisStatusInconclusive means there were no metrics to check, e.g. because the canary
+	// received no requests. It neither rolls back nor advances the rollout.
+	AnalysisStatusInconclusive AnalysisStatus = "Inconclusive"
+)
+
+const (
+	// analysisHistoryLimit is the number of analysis results kept per rollout.
+	analysisHistoryLimit = 20
+)
+
+// RolloutStep is a single traffic shift of a rollout.
+type RolloutStep struct {
+	// Weight is the percentage of the traffic sent to the canary backend in this step.
+	Weight int `json:"weight"`
+
+	// Pause is how long the step is held before the next step starts.
+	Pause metav1.Duration `json:"pause"`
+}
+
+// RolloutAnalysis are the thresholds the canary backend is checked against between steps.
+type RolloutAnalysis struct {
+	// MinSuccessRate is the lowest accepted ratio of successful requests, between 0 and 1.
+	MinSuccessRate float64 `json:"minSuccessRate"`
+
+	// MaxLatency is the highest accepted 99th percentile latency. Zero disables the check.
+	MaxLatency metav1.Duration `json:"maxLatency"`
+
+	// Interval is how often the thresholds are checked, and the window the metrics are taken over.
+	Interval metav1.Duration `json:"interval"`
+}
+
+// RolloutSpec describes a canary rollout of a traffic split.
+type RolloutSpec struct {
+	Namespace    string `json:"namespace"`
+	TrafficSplit string `json:"trafficSplit"`
+
+	// Canary is the backend service traffic is shifted to.
+	Canary string `json:"canary"`
+
+	// Stable is the backend service traffic is shifted from. It receives the remaining percentage.
+	Stable string `json:"stable"`
+
+	Steps []RolloutStep `json:"steps"`
+
+	// Analysis is optional. Without it the steps only follow the schedule.
+	Analysis *RolloutAnalysis `json:"analysis,omitempty"`
+}
+
+// AnalysisResult is a single check of the canary backend against the rollout thresholds.
+type AnalysisResult struct {
+	Time        metav1.Time     `json:"time"`
+	Requests    int64           `json:"requests"`
+	SuccessRate float64         `json:"successRate"`
+	Latency     metav1.Duration `json:"latency"`
+	Status      AnalysisStatus  `json:"status"`
+	Message     string          `json:"message"`
+}
+
+// Rollout is the persisted state of a canary rollout.
+type Rollout struct {
+	Spec  RolloutSpec  `json:"spec"`
+	Phase RolloutPhase `json:"phase"`
+
+	// CurrentStep is the index of the step currently held.
+	CurrentStep   int `json:"currentStep"`
+	CurrentWeight int `json:"currentWeight"`
+
+	// OriginalWeights are the backend weights before the rollout, restored on abort and rollback.
+	OriginalWeights map[string]int `json:"originalWeights"`
+
+	StartTime      metav1.Time  `json:"startTime"`
+	StepStartTime  metav1.Time  `json:"stepStartTime"`
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// LastAnalysisTime is when the thresholds were last checked.
+	LastAnalysisTime *metav1.Time `json:"lastAnalysisTime,omitempty"`
+
+	Analysis []AnalysisResult `json:"analysis"`
+
+	Message string `json:"message"`
+}
+
+// RolloutList contains all tracked rollouts.
+type RolloutList struct {
+	Rollouts []Rollout `json:"rollouts"`
+}
+
+// key identifies the rollout of a traffic split. Namespace names cannot contain dots, so the key is
+// unambiguous and can be used as a config map key.
+func (self *RolloutSpec) key() string {
+	return rolloutKey(self.Namespace, self.TrafficSplit)
+}
+
+func rolloutKey(namespace, trafficSplit string) string {
+	return namespace + "." + trafficSplit
+}
+
+// isActive returns true when the rollout still controls the weights of its traffic split.
+func (self *Rollout) isActive() bool {
+	return self.Phase == RolloutPhaseProgressing || self.Phase == RolloutPhasePaused
+}
+
+// stepWeights returns the backend weights for a canary weight. The other backends share the rest
+// in proportion to their original weights, with the rounding remainder going to the stable backend,
+// which gets all of the rest when the other backends had no weight.
+func (self *Rollout) stepWeights(weight int) map[string]int {
+	result := map[string]int{self.Spec.Canary: weight}
+	rest := 100 - weight
+
+	total := 0
+	for service, original := range self.OriginalWeights {
+		if service != self.Spec.Canary {
+			total += original
+		}
+	}
+
+	assigned := 0
+	for service, original := range self.OriginalWeights {
+		if service == self.Spec.Canary {
+			continue
+		}
+		result[service] = 0
+		if total > 0 {
+			result[service] = rest * original / total
+			assigned += result[service]
+		}
+	}
+	result[self.Spec.Stable] += rest - assigned
+
+	return result
+}
+
+func (self *Rollout) addAnalysis(result AnalysisResult) {
+	self.Analysis = append(self.Analysis, result)
+	if len(self.Analysis) > analysisHistoryLimit {
+		self.Analysis = self.Analysis[len(self.Analysis)-analysisHistoryLimit:]
+	}
+}
+
+// validate checks the spec against the traffic split it rolls out.
+func (self *RolloutSpec) validate(trafficSplit *smisplitv1alpha2.TrafficSplit, hasMetricSource bool) error {
+	problems := make([]string, 0)
+
+	backends := make(map[string]bool)
+	for _, backend := range trafficSplit.Spec.Backends {
+		backends[backend.Service] = true
+	}
+
+	if !backends[self.Canary] {
+		problems = append(problems, fmt.Sprintf("canary %q is not a backend of the trafficsplit", self.Canary))
+	}
+
+	if !backends[self.Stable] {
+		problems = append(problems, fmt.Sprintf("stable %q is not a backend of the trafficsplit", self.Stable))
+	}
+
+	if self.Canary == self.Stable {
+		problems = append(problems, "canary and stable must be different backends")
+	}
+
+	if len(self.Steps) == 0 {
+		problems = append(problems, "at least one step is required")
+	}
+
+	previous := 0
+	for i, step := range self.Steps {
+		if step.Weight < previous || step.Weight > 100 {
+			problems = append(problems, fmt.Sprintf("weight of step %d must be between %d and 100", i+1, previous))
+		}
+		if step.Pause.Duration < 0 {
+			problems = append(problems, fmt.Sprintf("pause of step %d must not be negative", i+1))
+		}
+		previous = step.Weight
+	}
+
+	if self.Analysis != nil {
+		if !hasMetricSource {
+			problems = append(problems, "analysis requires a metric source, but none is configured")
+		}
+		if self.Analysis.MinSuccessRate < 0 || self.Analysis.MinSuccessRate > 1 {
+			problems = append(problems, "minSuccessRate must be between 0 and 1")
+		}
+		if self.Analysis.MaxLatency.Duration < 0 {
+			problems = append(problems, "maxLatency must not be negative")
+		}
+		if self.Analysis.Interval.Duration <= 0 {
+			problems = append(problems, "analysis interval must be positive")
+		}
+	}
+
+	if len(problems) > 0 {
+		return errors.NewBadRequest(strings.Join(problems, "; "))
+	}
+
+	return nil
+}
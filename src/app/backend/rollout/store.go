@@ -0,0 +1,75 @@
+package rollout
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+)
+
+// RolloutConfigMapName is the name of the config map in the Dashboard namespace that stores the
+// state of rollouts.
+const RolloutConfigMapName = "kubernetes-dashboard-rollouts"
+
+// rolloutStore persists rollouts in a config map, one key per traffic split.
+type rolloutStore struct {
+	client    kubernetes.Interface
+	namespace string
+}
+
+func (self *rolloutStore) save(rollout *Rollout) error {
+	data, err := json.Marshal(rollout)
+	if err != nil {
+		return err
+	}
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		configMap, err := self.client.CoreV1().ConfigMaps(self.namespace).Get(context.TODO(), RolloutConfigMapName, metav1.GetOptions{})
+		if k8serrors.IsNotFound(err) {
+			log.Printf("Creating %s config map in %s namespace", RolloutConfigMapName, self.namespace)
+			_, err = self.client.CoreV1().ConfigMaps(self.namespace).Create(context.TODO(), &v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: RolloutConfigMapName, Namespace: self.namespace},
+				Data:       map[string]string{rollout.Spec.key(): string(data)},
+			}, metav1.CreateOptions{})
+			return err
+		}
+		if err != nil {
+			return err
+		}
+
+		if configMap.Data == nil {
+			configMap.Data = make(map[string]string)
+		}
+		configMap.Data[rollout.Spec.key()] = string(data)
+
+		_, err = self.client.CoreV1().ConfigMaps(self.namespace).Update(context.TODO(), configMap, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+func (self *rolloutStore) load() ([]*Rollout, error) {
+	configMap, err := self.client.CoreV1().ConfigMaps(self.namespace).Get(context.TODO(), RolloutConfigMapName, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rollouts := make([]*Rollout, 0, len(configMap.Data))
+	for key, data := range configMap.Data {
+		rollout := new(Rollout)
+		if err := json.Unmarshal([]byte(data), rollout); err != nil {
+			log.Printf("Skipping corrupted rollout %s: %s", key, err)
+			continue
+		}
+		rollouts = append(rollouts, rollout)
+	}
+
+	return rollouts, nil
+}
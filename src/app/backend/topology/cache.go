@@ -0,0 +1,202 @@
+package topology
+
+import (
+	"reflect"
+	"sync"
+	"time"
+)
+
+// graphTTL is how long a graph is served from the cache before it is built again.
+const graphTTL = 10 * time.Second
+
+// graphHistory is the number of versions kept per cache key to compute updates from.
+const graphHistory = 5
+
+// GraphUpdate is the change of a graph since a version the client already has.
+type GraphUpdate struct {
+	Version string `json:"version"`
+
+	// Since is the version the update applies to.
+	Since string `json:"since"`
+
+	// Full is true when the version the client has is unknown, in which case Graph holds the whole
+	// graph instead of changes.
+	Full  bool   `json:"full"`
+	Graph *Graph `json:"graph,omitempty"`
+
+	// Namespaces always hold all namespace groups, as they are small and change with any node.
+	Namespaces []NamespaceGroup `json:"namespaces,omitempty"`
+
+	// UpdatedNodes and UpdatedEdges are the nodes and edges that are new or changed.
+	UpdatedNodes []Node `json:"updatedNodes"`
+	UpdatedEdges []Edge `json:"updatedEdges"`
+
+	// RemovedNodes and RemovedEdges are the IDs of the nodes and edges that are gone.
+	RemovedNodes []string `json:"removedNodes"`
+	RemovedEdges []string `json:"removedEdges"`
+
+	Permissive      bool     `json:"permissive"`
+	TrafficObserved bool     `json:"trafficObserved"`
+	Warnings        []string `json:"warnings"`
+	Errors          []error  `json:"errors"`
+}
+
+// graphCache keeps the recent graphs built for a cache key. Keys include the credentials of the user,
+// so that graphs are never shared between users with different permissions.
+type graphCache struct {
+	mutex   sync.Mutex
+	ttl     time.Duration
+	entries map[string]*graphCacheEntry
+	now     func() time.Time
+}
+
+type graphCacheEntry struct {
+	expires time.Time
+
+	// graphs are the recent versions of the graph, oldest first.
+	graphs []*Graph
+}
+
+func newGraphCache(ttl time.Duration) *graphCache {
+	return &graphCache{
+		ttl:     ttl,
+		entries: make(map[string]*graphCacheEntry),
+		now:     time.Now,
+	}
+}
+
+// get returns the graph cached for a key, building it again when it expired. Concurrent requests for
+// an expired key may build the graph more than once.
+func (self *graphCache) get(key string, build func() (*Graph, error)) (*Graph, error) {
+	if graph := self.current(key); graph != nil {
+		return graph, nil
+	}
+
+	graph, err := build()
+	if err != nil {
+		return nil, err
+	}
+
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	entry, ok := self.entries[key]
+	if !ok {
+		entry = new(graphCacheEntry)
+		self.entries[key] = entry
+	}
+	entry.expires = self.now().Add(self.ttl)
+	if last := len(entry.graphs) - 1; last >= 0 && entry.graphs[last].Version == graph.Version {
+		entry.graphs[last] = graph
+	} else {
+		entry.graphs = append(entry.graphs, graph)
+		if len(entry.graphs) > graphHistory {
+			entry.graphs = entry.graphs[len(entry.graphs)-graphHistory:]
+		}
+	}
+
+	return graph, nil
+}
+
+// update returns the changes of the graph cached for a key since the given version.
+func (self *graphCache) update(key, since string, build func() (*Graph, error)) (*GraphUpdate, error) {
+	graph, err := self.get(key, build)
+	if err != nil {
+		return nil, err
+	}
+
+	self.mutex.Lock()
+	var previous *Graph
+	if entry, ok := self.entries[key]; ok {
+		for _, cached := range entry.graphs {
+			if cached.Version == since {
+				previous = cached
+			}
+		}
+	}
+	self.mutex.Unlock()
+
+	return diffGraphs(previous, graph, since), nil
+}
+
+// current returns the unexpired graph of a key, and drops all expired entries.
+func (self *graphCache) current(key string) *Graph {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	now := self.now()
+	var result *Graph
+	for entryKey, entry := range self.entries {
+		if now.After(entry.expires) {
+			// Expired entries stay a while, so that clients polling slower than the TTL still get
+			// updates instead of full graphs.
+			if now.After(entry.expires.Add(graphHistory * self.ttl)) {
+				delete(self.entries, entryKey)
+			}
+			continue
+		}
+		if entryKey == key && len(entry.graphs) > 0 {
+			result = entry.graphs[len(entry.graphs)-1]
+		}
+	}
+	return result
+}
+
+// diffGraphs returns the changes from previous to current. When previous is nil the update holds
+// the full graph.
+func diffGraphs(previous, current *Graph, since string) *GraphUpdate {
+	update := &GraphUpdate{
+		Version:         current.Version,
+		Since:           since,
+		UpdatedNodes:    make([]Node, 0),
+		UpdatedEdges:    make([]Edge, 0),
+		RemovedNodes:    make([]string, 0),
+		RemovedEdges:    make([]string, 0),
+		Permissive:      current.Permissive,
+		TrafficObserved: current.TrafficObserved,
+		Warnings:        current.Warnings,
+		Errors:          current.Errors,
+	}
+
+	if previous == nil {
+		update.Full = true
+		update.Graph = current
+		return update
+	}
+
+	update.Namespaces = current.Namespaces
+
+	previousNodes := make(map[string]Node, len(previous.Nodes))
+	for _, node := range previous.Nodes {
+		previousNodes[node.ID] = node
+	}
+	for _, node := range current.Nodes {
+		if old, ok := previousNodes[node.ID]; !ok || !reflect.DeepEqual(old, node) {
+			update.UpdatedNodes = append(update.UpdatedNodes, node)
+		}
+		delete(previousNodes, node.ID)
+	}
+	for _, node := range previous.Nodes {
+		if _, ok := previousNodes[node.ID]; ok {
+			update.RemovedNodes = append(update.RemovedNodes, node.ID)
+		}
+	}
+
+	previousEdges := make(map[string]Edge, len(previous.Edges))
+	for _, edge := range previous.Edges {
+		previousEdges[edge.ID] = edge
+	}
+	for _, edge := range current.Edges {
+		if old, ok := previousEdges[edge.ID]; !ok || !reflect.DeepEqual(old, edge) {
+			update.UpdatedEdges = append(update.UpdatedEdges, edge)
+		}
+		delete(previousEdges, edge.ID)
+	}
+	for _, edge := range previous.Edges {
+		if _, ok := previousEdges[edge.ID]; ok {
+			update.RemovedEdges = append(update.RemovedEdges, edge.ID)
+		}
+	}
+
+	return update
+}
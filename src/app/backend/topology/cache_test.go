@@ -0,0 +1,68 @@
+package topology
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestGraphCache(t *testing.T) {
+	clock := time.Date(2022, 7, 1, 0, 0, 0, 0, time.UTC)
+	cache := newGraphCache(graphTTL)
+	cache.now = func() time.Time { return clock }
+
+	first := &Graph{
+		Version: "1",
+		Nodes:   []Node{{ID: "deployment/bookstore/bookstore-v1"}, {ID: "service/bookstore/bookstore"}},
+		Edges:   []Edge{{ID: "deployment/bookbuyer/bookbuyer>service/bookstore/bookstore"}},
+	}
+	second := &Graph{
+		Version: "2",
+		Nodes: []Node{
+			{ID: "deployment/bookstore/bookstore-v2"},
+			{ID: "service/bookstore/bookstore", Health: HealthHealthy},
+		},
+		Edges: []Edge{{ID: "deployment/bookbuyer/bookbuyer>service/bookstore/bookstore"}},
+	}
+
+	builds := 0
+	next := first
+	build := func() (*Graph, error) {
+		builds++
+		return next, nil
+	}
+
+	for i := 0; i < 2; i++ {
+		if graph, err := cache.get("user", build); err != nil || graph != first {
+			t.Fatalf("expected the first graph, but got %#v, %v", graph, err)
+		}
+	}
+	if builds != 1 {
+		t.Errorf("expected the graph to be built once while it is fresh, but it was built %d times", builds)
+	}
+
+	clock = clock.Add(graphTTL + time.Second)
+	next = second
+	update, err := cache.update("user", "1", build)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if builds != 2 || update.Full || update.Version != "2" {
+		t.Fatalf("expected an update of the rebuilt graph from version 1, but got %#v", update)
+	}
+	if !reflect.DeepEqual(update.UpdatedNodes, second.Nodes) ||
+		!reflect.DeepEqual(update.RemovedNodes, []string{"deployment/bookstore/bookstore-v1"}) ||
+		len(update.UpdatedEdges) != 0 || len(update.RemovedEdges) != 0 {
+		t.Errorf("expected the bookstore-v1 deployment to be replaced by bookstore-v2, but got %#v", update)
+	}
+
+	update, err = cache.update("user", "2", build)
+	if err != nil || update.Full || len(update.UpdatedNodes) != 0 || len(update.RemovedNodes) != 0 {
+		t.Errorf("expected no changes since the current version, but got %#v, %v", update, err)
+	}
+
+	update, err = cache.update("other-user", "1", build)
+	if err != nil || !update.Full || update.Graph != second {
+		t.Errorf("expected the full graph for a version another user has never seen, but got %#v, %v", update, err)
+	}
+}
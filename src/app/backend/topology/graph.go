@@ -0,0 +1,588 @@
+package topology
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	osmconfigclientset "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned"
+	smiaccessclientset "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/access/clientset/versioned"
+	smispecsclientset "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/specs/clientset/versioned"
+	apps "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/osm/sidecar"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/smi/policy"
+)
+
+// trafficWindow is the window observed traffic is averaged over.
+const trafficWindow = 5 * time.Minute
+
+// defaultServiceAccount is used by pods that do not set a service account.
+const defaultServiceAccount = "default"
+
+// Health is the health of a node derived from the phases of its pods.
+type Health string
+
+const (
+	// HealthHealthy is used when all desired pods are running.
+	HealthHealthy Health = "Healthy"
+
+	// HealthDegraded is used when some, but not all, desired pods are running or some pods failed.
+	HealthDegraded Health = "Degraded"
+
+	// HealthUnhealthy is used when pods are desired but none is running.
+	HealthUnhealthy Health = "Unhealthy"
+
+	// HealthIdle is used when no pods are desired, e.g. for a workload scaled to zero or a service
+	// without backends.
+	HealthIdle Health = "Idle"
+)
+
+// NodeRef identifies a service or workload of the mesh.
+type NodeRef struct {
+	Kind      api.ResourceKind `json:"kind"`
+	Namespace string           `json:"namespace"`
+	Name      string           `json:"name"`
+}
+
+// ID returns the kind/namespace/name of the node, which is unique within a graph.
+func (n NodeRef) ID() string {
+	return fmt.Sprintf("%s/%s/%s", n.Kind, n.Namespace, n.Name)
+}
+
+// Node is a meshed service or workload.
+type Node struct {
+	NodeRef `json:",inline"`
+
+	ID string `json:"id"`
+
+	// ServiceAccount is the identity the pods of a workload run as.
+	ServiceAccount string `json:"serviceAccount,omitempty"`
+
+	// Backends are the IDs of the workloads a service selects.
+	Backends []string `json:"backends,omitempty"`
+
+	// Pods aggregates the pods of a workload, or of the backends of a service.
+	Pods common.PodInfo `json:"pods"`
+
+	Health Health `json:"health"`
+
+	// Sidecars are the sidecar proxies of the pods of a workload.
+	Sidecars *sidecar.WorkloadSidecars `json:"sidecars,omitempty"`
+}
+
+// Edge is a connection from a workload to a service.
+type Edge struct {
+	ID          string `json:"id"`
+	Source      string `json:"source"`
+	Destination string `json:"destination"`
+
+	// Allowed tells whether the mesh permits the connection, either by traffic targets or because
+	// of permissive traffic mode.
+	Allowed bool `json:"allowed"`
+
+	// TrafficTargets are the namespace/name of the traffic targets that permit the connection.
+	TrafficTargets []string `json:"trafficTargets"`
+
+	// Traffic is the observed traffic. It is empty when no metrics source is available or no traffic
+	// was observed.
+	Traffic *Traffic `json:"traffic,omitempty"`
+}
+
+// NamespaceGroup groups the nodes of a namespace.
+type NamespaceGroup struct {
+	Name string `json:"name"`
+
+	// Mesh is the mesh that monitors the namespace.
+	Mesh string `json:"mesh"`
+
+	// Nodes are the IDs of the nodes in the namespace.
+	Nodes []string `json:"nodes"`
+}
+
+// Graph is the service graph of the mesh.
+type Graph struct {
+	// Version changes whenever the namespaces, nodes or edges of the graph change.
+	Version string `json:"version"`
+
+	// Permissive is the permissive traffic setting of MeshConfig. In permissive mode all
+	// connections are allowed, so only observed ones are edges.
+	Permissive bool `json:"permissive"`
+
+	// TrafficObserved tells whether edges carry the traffic observed by a metrics source.
+	TrafficObserved bool `json:"trafficObserved"`
+
+	Namespaces []NamespaceGroup `json:"namespaces"`
+	Nodes      []Node           `json:"nodes"`
+	Edges      []Edge           `json:"edges"`
+
+	// Warnings flag policies that could not be applied to the graph.
+	Warnings []string `json:"warnings"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// graphResources are the resources a graph is built from.
+type graphResources struct {
+	meshes       *sidecar.Meshes
+	services     []v1.Service
+	deployments  []apps.Deployment
+	statefulSets []apps.StatefulSet
+	daemonSets   []apps.DaemonSet
+	replicaSets  []apps.ReplicaSet
+	pods         []v1.Pod
+	access       *policy.AccessMatrix
+	traffic      []ObservedTraffic
+}
+
+// GetGraph returns the service graph of the meshed namespaces selected by the namespace query. Edges
+// are the connections SMI traffic targets permit, together with the traffic observed by the given
+// source, which may be nil.
+func GetGraph(smiAccessClient smiaccessclientset.Interface, smiSpecsClient smispecsclientset.Interface,
+	osmConfigClient osmconfigclientset.Interface, client kubernetes.Interface, nsQuery *common.NamespaceQuery,
+	traffic TrafficSource) (*Graph, error) {
+	log.Print("Getting topology of the mesh")
+
+	channels := &common.ResourceChannels{
+		ServiceList:        common.GetServiceListChannel(client, nsQuery, 1),
+		DeploymentList:     common.GetDeploymentListChannel(client, nsQuery, 1),
+		StatefulSetList:    common.GetStatefulSetListChannel(client, nsQuery, 1),
+		DaemonSetList:      common.GetDaemonSetListChannel(client, nsQuery, 1),
+		ReplicaSetList:     common.GetReplicaSetListChannel(client, nsQuery, 1),
+		PodList:            common.GetPodListChannel(client, nsQuery, 1),
+		TrafficTargetList:  common.GetTrafficTargetListChannel(smiAccessClient, nsQuery, 1),
+		HttpRouteGroupList: common.GetHttpRouteGroupListChannel(smiSpecsClient, nsQuery, 1),
+		TCPRouteList:       common.GetTCPRouteListChannel(smiSpecsClient, nsQuery, 1),
+		ServiceAccountList: common.GetServiceAccountListChannel(client, nsQuery, 1),
+		MeshConfigList:     common.GetMeshConfigListChannel(osmConfigClient, common.NewNamespaceQuery(nil), 1),
+	}
+
+	resources, nonCriticalErrors, err := getGraphResources(channels)
+	if err != nil {
+		return nil, err
+	}
+
+	meshes, err := sidecar.GetMeshes(osmConfigClient, client, getShownNamespaces(resources))
+	if err != nil {
+		return nil, err
+	}
+	resources.meshes = meshes
+
+	warnings := make([]string, 0)
+	var trafficErr error
+	if traffic != nil {
+		resources.traffic, trafficErr = traffic.ObservedTraffic(getMeshedNamespaces(resources, nsQuery), trafficWindow)
+		if trafficErr != nil {
+			warnings = append(warnings, fmt.Sprintf("observed traffic is not available: %s", trafficErr))
+		}
+	}
+
+	graph := buildGraph(resources, nsQuery)
+	graph.TrafficObserved = traffic != nil && trafficErr == nil
+	graph.Warnings = append(warnings, graph.Warnings...)
+	graph.Errors = append(nonCriticalErrors, graph.Errors...)
+	return graph, nil
+}
+
+func getGraphResources(channels *common.ResourceChannels) (*graphResources, []error, error) {
+	resources := new(graphResources)
+
+	services := <-channels.ServiceList.List
+	err := <-channels.ServiceList.Error
+	nonCriticalErrors, criticalError := errors.HandleError(err)
+	if criticalError != nil {
+		return nil, nil, criticalError
+	}
+	if services != nil {
+		resources.services = services.Items
+	}
+
+	deployments := <-channels.DeploymentList.List
+	err = <-channels.DeploymentList.Error
+	nonCriticalErrors, criticalError = errors.AppendError(err, nonCriticalErrors)
+	if criticalError != nil {
+		return nil, nil, criticalError
+	}
+	if deployments != nil {
+		resources.deployments = deployments.Items
+	}
+
+	statefulSets := <-channels.StatefulSetList.List
+	err = <-channels.StatefulSetList.Error
+	nonCriticalErrors, criticalError = errors.AppendError(err, nonCriticalErrors)
+	if criticalError != nil {
+		return nil, nil, criticalError
+	}
+	if statefulSets != nil {
+		resources.statefulSets = statefulSets.Items
+	}
+
+	daemonSets := <-channels.DaemonSetList.List
+	err = <-channels.DaemonSetList.Error
+	nonCriticalErrors, criticalError = errors.AppendError(err, nonCriticalErrors)
+	if criticalError != nil {
+		return nil, nil, criticalError
+	}
+	if daemonSets != nil {
+		resources.daemonSets = daemonSets.Items
+	}
+
+	replicaSets := <-channels.ReplicaSetList.List
+	err = <-channels.ReplicaSetList.Error
+	nonCriticalErrors, criticalError = errors.AppendError(err, nonCriticalErrors)
+	if criticalError != nil {
+		return nil, nil, criticalError
+	}
+	if replicaSets != nil {
+		resources.replicaSets = replicaSets.Items
+	}
+
+	pods := <-channels.PodList.List
+	err = <-channels.PodList.Error
+	nonCriticalErrors, criticalError = errors.AppendError(err, nonCriticalErrors)
+	if criticalError != nil {
+		return nil, nil, criticalError
+	}
+	if pods != nil {
+		resources.pods = pods.Items
+	}
+
+	access, err := policy.GetAccessMatrixFromChannels(channels, "", "")
+	if err != nil {
+		return nil, nil, err
+	}
+	resources.access = access
+
+	return resources, append(nonCriticalErrors, access.Errors...), nil
+}
+
+// getShownNamespaces returns the namespaces of the services and pods of the graph.
+func getShownNamespaces(resources *graphResources) []string {
+	result := make([]string, 0, len(resources.services)+len(resources.pods))
+	for _, service := range resources.services {
+		result = append(result, service.Namespace)
+	}
+	for _, pod := range resources.pods {
+		result = append(result, pod.Namespace)
+	}
+	return result
+}
+
+// getMeshedNamespaces returns the sorted namespaces that are selected and enrolled in a mesh.
+func getMeshedNamespaces(resources *graphResources, nsQuery *common.NamespaceQuery) []string {
+	known := make(map[string]bool)
+	result := make([]string, 0)
+	add := func(namespace string) {
+		if !known[namespace] && nsQuery.Matches(namespace) && len(resources.meshes.Mesh(namespace)) > 0 {
+			known[namespace] = true
+			result = append(result, namespace)
+		}
+	}
+
+	for _, service := range resources.services {
+		add(service.Namespace)
+	}
+	for _, pod := range resources.pods {
+		add(pod.Namespace)
+	}
+
+	sort.Strings(result)
+	return result
+}
+
+// graphBuilder collects the nodes and edges of a graph.
+type graphBuilder struct {
+	resources *graphResources
+	nsQuery   *common.NamespaceQuery
+
+	nodes map[string]*Node
+	edges map[string]*Edge
+
+	// workloadsByIdentity and servicesByIdentity map namespace/service account to the IDs of the
+	// workloads that run as it and the services that select those workloads.
+	workloadsByIdentity map[string][]string
+	servicesByIdentity  map[string][]string
+
+	// templates are the pod template labels of workloads by ID, used to find service backends.
+	templates map[string]labels.Set
+}
+
+func buildGraph(resources *graphResources, nsQuery *common.NamespaceQuery) *Graph {
+	builder := &graphBuilder{
+		resources:           resources,
+		nsQuery:             nsQuery,
+		nodes:               make(map[string]*Node),
+		edges:               make(map[string]*Edge),
+		workloadsByIdentity: make(map[string][]string),
+		servicesByIdentity:  make(map[string][]string),
+		templates:           make(map[string]labels.Set),
+	}
+
+	builder.addWorkloads()
+	builder.addServices()
+	builder.addPermittedEdges()
+	builder.addObservedTraffic()
+
+	return builder.graph()
+}
+
+func (self *graphBuilder) meshed(namespace string) bool {
+	return self.nsQuery.Matches(namespace) && len(self.resources.meshes.Mesh(namespace)) > 0
+}
+
+func (self *graphBuilder) addWorkloads() {
+	for _, deployment := range self.resources.deployments {
+		if !self.meshed(deployment.Namespace) {
+			continue
+		}
+		pods := common.FilterDeploymentPodsByOwnerReference(deployment, self.resources.replicaSets, self.resources.pods)
+		self.addWorkload(api.ResourceKindDeployment, deployment.ObjectMeta.Namespace, deployment.Name,
+			&deployment.Spec.Template, common.GetPodInfo(deployment.Status.Replicas, deployment.Spec.Replicas, pods), pods)
+	}
+
+	for i := range self.resources.statefulSets {
+		statefulSet := &self.resources.statefulSets[i]
+		if !self.meshed(statefulSet.Namespace) {
+			continue
+		}
+		pods := common.FilterPodsByControllerRef(statefulSet, self.resources.pods)
+		self.addWorkload(api.ResourceKindStatefulSet, statefulSet.Namespace, statefulSet.Name,
+			&statefulSet.Spec.Template, common.GetPodInfo(statefulSet.Status.Replicas, statefulSet.Spec.Replicas, pods), pods)
+	}
+
+	for i := range self.resources.daemonSets {
+		daemonSet := &self.resources.daemonSets[i]
+		if !self.meshed(daemonSet.Namespace) {
+			continue
+		}
+		pods := common.FilterPodsByControllerRef(daemonSet, self.resources.pods)
+		desired := daemonSet.Status.DesiredNumberScheduled
+		self.addWorkload(api.ResourceKindDaemonSet, daemonSet.Namespace, daemonSet.Name,
+			&daemonSet.Spec.Template, common.GetPodInfo(daemonSet.Status.CurrentNumberScheduled, &desired, pods), pods)
+	}
+}
+
+func (self *graphBuilder) addWorkload(kind api.ResourceKind, namespace, name string, template *v1.PodTemplateSpec,
+	podInfo common.PodInfo, pods []v1.Pod) {
+	serviceAccount := template.Spec.ServiceAccountName
+	if len(serviceAccount) == 0 {
+		serviceAccount = defaultServiceAccount
+	}
+
+	sidecars := sidecar.GetWorkloadSidecars(pods)
+	self.resources.meshes.ResolveWorkload(&sidecars, namespace)
+
+	ref := NodeRef{Kind: kind, Namespace: namespace, Name: name}
+	node := &Node{
+		NodeRef:        ref,
+		ID:             ref.ID(),
+		ServiceAccount: serviceAccount,
+		Pods:           podInfo,
+		Health:         getHealth(podInfo),
+		Sidecars:       &sidecars,
+	}
+	self.nodes[node.ID] = node
+	self.templates[node.ID] = template.Labels
+
+	identity := namespace + "/" + serviceAccount
+	self.workloadsByIdentity[identity] = append(self.workloadsByIdentity[identity], node.ID)
+}
+
+func (self *graphBuilder) addServices() {
+	workloads := make([]*Node, 0, len(self.nodes))
+	for _, node := range self.nodes {
+		workloads = append(workloads, node)
+	}
+	sort.Slice(workloads, func(i, j int) bool { return workloads[i].ID < workloads[j].ID })
+
+	for _, service := range self.resources.services {
+		if !self.meshed(service.Namespace) {
+			continue
+		}
+
+		ref := NodeRef{Kind: api.ResourceKindService, Namespace: service.Namespace, Name: service.Name}
+		node := &Node{NodeRef: ref, ID: ref.ID(), Backends: make([]string, 0)}
+		identities := make(map[string]bool)
+
+		var pods common.PodInfo
+		var desired int32
+		if len(service.Spec.Selector) > 0 {
+			selector := labels.SelectorFromSet(service.Spec.Selector)
+			for _, workload := range workloads {
+				if workload.Namespace != service.Namespace || !selector.Matches(self.templates[workload.ID]) {
+					continue
+				}
+
+				node.Backends = append(node.Backends, workload.ID)
+				identities[workload.Namespace+"/"+workload.ServiceAccount] = true
+				pods.Current += workload.Pods.Current
+				pods.Running += workload.Pods.Running
+				pods.Pending += workload.Pods.Pending
+				pods.Failed += workload.Pods.Failed
+				pods.Succeeded += workload.Pods.Succeeded
+				if workload.Pods.Desired != nil {
+					desired += *workload.Pods.Desired
+				} else {
+					desired += workload.Pods.Current
+				}
+			}
+		}
+
+		pods.Desired = &desired
+		pods.Warnings = make([]common.Event, 0)
+		node.Pods = pods
+		node.Health = getHealth(pods)
+		self.nodes[node.ID] = node
+
+		for identity := range identities {
+			self.servicesByIdentity[identity] = append(self.servicesByIdentity[identity], node.ID)
+		}
+	}
+}
+
+// addPermittedEdges connects the workloads that run as the source of a traffic target to the
+// services that select workloads running as its destination.
+func (self *graphBuilder) addPermittedEdges() {
+	for _, accessEdge := range self.resources.access.Edges {
+		trafficTargets := make([]string, 0, len(accessEdge.Rules))
+		for _, rule := range accessEdge.Rules {
+			trafficTargets = append(trafficTargets, rule.TrafficTarget)
+		}
+
+		for _, source := range self.workloadsByIdentity[accessEdge.Source] {
+			for _, destination := range self.servicesByIdentity[accessEdge.Destination] {
+				edge := self.edge(source, destination)
+				edge.Allowed = true
+				edge.TrafficTargets = append(edge.TrafficTargets, trafficTargets...)
+			}
+		}
+	}
+}
+
+// addObservedTraffic attaches observed traffic to edges. Traffic that no traffic target permits
+// becomes an edge of its own, allowed only in permissive mode.
+func (self *graphBuilder) addObservedTraffic() {
+	for _, observed := range self.resources.traffic {
+		source, destination := observed.Source.ID(), observed.Destination.ID()
+		if self.nodes[source] == nil || self.nodes[destination] == nil {
+			continue
+		}
+
+		edge := self.edge(source, destination)
+		edge.Allowed = edge.Allowed || self.resources.access.Permissive
+		traffic := observed.Traffic
+		edge.Traffic = &traffic
+	}
+}
+
+func (self *graphBuilder) edge(source, destination string) *Edge {
+	id := source + ">" + destination
+	if edge, ok := self.edges[id]; ok {
+		return edge
+	}
+
+	edge := &Edge{ID: id, Source: source, Destination: destination, TrafficTargets: make([]string, 0)}
+	self.edges[id] = edge
+	return edge
+}
+
+func (self *graphBuilder) graph() *Graph {
+	graph := &Graph{
+		Permissive: self.resources.access.Permissive,
+		Namespaces: make([]NamespaceGroup, 0),
+		Nodes:      make([]Node, 0, len(self.nodes)),
+		Edges:      make([]Edge, 0, len(self.edges)),
+		Warnings:   self.resources.access.Warnings,
+		Errors:     make([]error, 0),
+	}
+
+	groups := make(map[string]*NamespaceGroup)
+	for _, namespace := range getMeshedNamespaces(self.resources, self.nsQuery) {
+		groups[namespace] = &NamespaceGroup{Name: namespace, Mesh: self.resources.meshes.Mesh(namespace), Nodes: make([]string, 0)}
+	}
+
+	for _, node := range self.nodes {
+		graph.Nodes = append(graph.Nodes, *node)
+	}
+	sort.Slice(graph.Nodes, func(i, j int) bool { return graph.Nodes[i].ID < graph.Nodes[j].ID })
+	for _, node := range graph.Nodes {
+		group, ok := groups[node.Namespace]
+		if !ok {
+			group = &NamespaceGroup{Name: node.Namespace, Mesh: self.resources.meshes.Mesh(node.Namespace), Nodes: make([]string, 0)}
+			groups[node.Namespace] = group
+		}
+		group.Nodes = append(group.Nodes, node.ID)
+	}
+
+	for _, group := range groups {
+		graph.Namespaces = append(graph.Namespaces, *group)
+	}
+	sort.Slice(graph.Namespaces, func(i, j int) bool { return graph.Namespaces[i].Name < graph.Namespaces[j].Name })
+
+	for _, edge := range self.edges {
+		edge.TrafficTargets = uniqueSorted(edge.TrafficTargets)
+		graph.Edges = append(graph.Edges, *edge)
+	}
+	sort.Slice(graph.Edges, func(i, j int) bool { return graph.Edges[i].ID < graph.Edges[j].ID })
+
+	graph.Version = graphVersion(graph)
+	return graph
+}
+
+// getHealth derives the health of a node from the phases of its pods.
+func getHealth(pods common.PodInfo) Health {
+	desired := pods.Current
+	if pods.Desired != nil {
+		desired = *pods.Desired
+	}
+
+	switch {
+	case desired == 0 && pods.Running == 0:
+		return HealthIdle
+	case pods.Running == 0:
+		return HealthUnhealthy
+	case pods.Running < desired || pods.Failed > 0:
+		return HealthDegraded
+	}
+	return HealthHealthy
+}
+
+// graphVersion hashes the namespaces, nodes and edges of a graph.
+func graphVersion(graph *Graph) string {
+	content, err := json.Marshal(struct {
+		Permissive bool
+		Namespaces []NamespaceGroup
+		Nodes      []Node
+		Edges      []Edge
+	}{graph.Permissive, graph.Namespaces, graph.Nodes, graph.Edges})
+	if err != nil {
+		return ""
+	}
+
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:8])
+}
+
+func uniqueSorted(values []string) []string {
+	known := make(map[string]bool)
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		if !known[value] {
+			known[value] = true
+			result = append(result, value)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
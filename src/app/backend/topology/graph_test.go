@@ -0,0 +1,177 @@
+package topology
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	osmconfigfake "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned/fake"
+	smiaccessv1alpha3 "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/access/v1alpha3"
+	smispecsv1alpha4 "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/specs/v1alpha4"
+	smiaccessfake "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/access/clientset/versioned/fake"
+	smispecsfake "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/specs/clientset/versioned/fake"
+	apps "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+)
+
+type fakeTrafficSource struct {
+	traffic []ObservedTraffic
+}
+
+func (self *fakeTrafficSource) ObservedTraffic(namespaces []string, window time.Duration) ([]ObservedTraffic, error) {
+	return self.traffic, nil
+}
+
+func enrolledNamespace(name string) *v1.Namespace {
+	return &v1.Namespace{ObjectMeta: metaV1.ObjectMeta{Name: name,
+		Labels: map[string]string{constants.OSMKubeResourceMonitorAnnotation: "osm"}}}
+}
+
+// newDeployment returns a deployment of the given app, running as the service account of the same
+// name, with one pod per phase.
+func newDeployment(namespace, name string, desired int32, phases ...v1.PodPhase) []runtime.Object {
+	controller := true
+	deploymentUID, replicaSetUID := types.UID(name+"-deployment"), types.UID(name+"-replicaset")
+	template := v1.PodTemplateSpec{
+		ObjectMeta: metaV1.ObjectMeta{Labels: map[string]string{"app": name}},
+		Spec:       v1.PodSpec{ServiceAccountName: name},
+	}
+
+	objects := []runtime.Object{
+		&apps.Deployment{
+			ObjectMeta: metaV1.ObjectMeta{Name: name, Namespace: namespace, UID: deploymentUID},
+			Spec:       apps.DeploymentSpec{Replicas: &desired, Template: template},
+			Status:     apps.DeploymentStatus{Replicas: int32(len(phases))},
+		},
+		&apps.ReplicaSet{ObjectMeta: metaV1.ObjectMeta{Name: name + "-5d8b", Namespace: namespace, UID: replicaSetUID,
+			OwnerReferences: []metaV1.OwnerReference{{Kind: "Deployment", Name: name, UID: deploymentUID, Controller: &controller}}}},
+	}
+	for i, phase := range phases {
+		objects = append(objects, &v1.Pod{
+			ObjectMeta: metaV1.ObjectMeta{Name: name + "-5d8b-" + string(rune('a'+i)), Namespace: namespace,
+				Labels:          template.Labels,
+				OwnerReferences: []metaV1.OwnerReference{{Kind: "ReplicaSet", Name: name + "-5d8b", UID: replicaSetUID, Controller: &controller}}},
+			Spec:   template.Spec,
+			Status: v1.PodStatus{Phase: phase},
+		})
+	}
+	return objects
+}
+
+func TestGetGraph(t *testing.T) {
+	smiAccessClient := smiaccessfake.NewSimpleClientset(&smiaccessv1alpha3.TrafficTarget{
+		ObjectMeta: metaV1.ObjectMeta{Name: "bookstore", Namespace: "bookstore"},
+		Spec: smiaccessv1alpha3.TrafficTargetSpec{
+			Destination: smiaccessv1alpha3.IdentityBindingSubject{Kind: "ServiceAccount", Name: "bookstore"},
+			Sources:     []smiaccessv1alpha3.IdentityBindingSubject{{Kind: "ServiceAccount", Name: "bookbuyer", Namespace: "bookbuyer"}},
+			Rules:       []smiaccessv1alpha3.TrafficTargetRule{{Kind: "HTTPRouteGroup", Name: "bookstore-routes"}},
+		},
+	})
+	smiSpecsClient := smispecsfake.NewSimpleClientset(&smispecsv1alpha4.HTTPRouteGroup{
+		ObjectMeta: metaV1.ObjectMeta{Name: "bookstore-routes", Namespace: "bookstore"},
+		Spec:       smispecsv1alpha4.HTTPRouteGroupSpec{Matches: []smispecsv1alpha4.HTTPMatch{{Name: "buy-a-book", PathRegex: "/buy"}}},
+	})
+
+	objects := []runtime.Object{
+		enrolledNamespace("bookbuyer"), enrolledNamespace("bookstore"), enrolledNamespace("bookthief"),
+		&apps.Deployment{ObjectMeta: metaV1.ObjectMeta{Name: "osm-controller", Namespace: "osm-system",
+			Labels: map[string]string{constants.AppLabel: constants.OSMControllerName, "meshName": "osm"}}},
+		&v1.Service{
+			ObjectMeta: metaV1.ObjectMeta{Name: "bookstore", Namespace: "bookstore"},
+			Spec:       v1.ServiceSpec{Selector: map[string]string{"app": "bookstore"}},
+		},
+	}
+	objects = append(objects, newDeployment("bookbuyer", "bookbuyer", 1, v1.PodRunning)...)
+	objects = append(objects, newDeployment("bookstore", "bookstore", 2, v1.PodRunning, v1.PodPending)...)
+	objects = append(objects, newDeployment("bookthief", "bookthief", 1, v1.PodRunning)...)
+	objects = append(objects, newDeployment("default", "unmeshed", 1, v1.PodRunning)...)
+
+	bookbuyer := NodeRef{Kind: api.ResourceKindDeployment, Namespace: "bookbuyer", Name: "bookbuyer"}
+	bookthief := NodeRef{Kind: api.ResourceKindDeployment, Namespace: "bookthief", Name: "bookthief"}
+	bookstore := NodeRef{Kind: api.ResourceKindService, Namespace: "bookstore", Name: "bookstore"}
+	traffic := &fakeTrafficSource{traffic: []ObservedTraffic{
+		{Source: bookbuyer, Destination: bookstore, Traffic: Traffic{RequestRate: 12.5, ErrorRate: 0.02, LatencyP99: 35}},
+		{Source: bookthief, Destination: bookstore, Traffic: Traffic{RequestRate: 1}},
+	}}
+
+	graph, err := GetGraph(smiAccessClient, smiSpecsClient, osmconfigfake.NewSimpleClientset(),
+		fake.NewSimpleClientset(objects...), common.NewNamespaceQuery(nil), traffic)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expectedNodes := map[string]Health{
+		"deployment/bookbuyer/bookbuyer": HealthHealthy,
+		"deployment/bookstore/bookstore": HealthDegraded,
+		"deployment/bookthief/bookthief": HealthHealthy,
+		"service/bookstore/bookstore":    HealthDegraded,
+	}
+	nodes := make(map[string]Health)
+	for _, node := range graph.Nodes {
+		nodes[node.ID] = node.Health
+		if node.Kind == api.ResourceKindService && !reflect.DeepEqual(node.Backends, []string{"deployment/bookstore/bookstore"}) {
+			t.Errorf("expected the bookstore deployment to back the bookstore service, but got %v", node.Backends)
+		}
+	}
+	if !reflect.DeepEqual(nodes, expectedNodes) {
+		t.Errorf("expected nodes %v, but got %v", expectedNodes, nodes)
+	}
+
+	if len(graph.Namespaces) != 3 || graph.Namespaces[1].Name != "bookstore" || graph.Namespaces[1].Mesh != "osm" ||
+		len(graph.Namespaces[1].Nodes) != 2 {
+		t.Errorf("expected nodes grouped by the three meshed namespaces, but got %#v", graph.Namespaces)
+	}
+
+	expectedEdges := []Edge{
+		{
+			ID:             bookbuyer.ID() + ">" + bookstore.ID(),
+			Source:         bookbuyer.ID(),
+			Destination:    bookstore.ID(),
+			Allowed:        true,
+			TrafficTargets: []string{"bookstore/bookstore"},
+			Traffic:        &Traffic{RequestRate: 12.5, ErrorRate: 0.02, LatencyP99: 35},
+		},
+		{
+			ID:             bookthief.ID() + ">" + bookstore.ID(),
+			Source:         bookthief.ID(),
+			Destination:    bookstore.ID(),
+			TrafficTargets: []string{},
+			Traffic:        &Traffic{RequestRate: 1},
+		},
+	}
+	if !reflect.DeepEqual(graph.Edges, expectedEdges) {
+		t.Errorf("expected edges %#v, but got %#v", expectedEdges, graph.Edges)
+	}
+
+	if !graph.TrafficObserved || len(graph.Version) == 0 {
+		t.Errorf("expected a versioned graph with observed traffic, but got %#v", graph)
+	}
+}
+
+func TestGetHealth(t *testing.T) {
+	two := int32(2)
+	cases := []struct {
+		pods     common.PodInfo
+		expected Health
+	}{
+		{common.PodInfo{}, HealthIdle},
+		{common.PodInfo{Current: 2, Desired: &two, Running: 2}, HealthHealthy},
+		{common.PodInfo{Current: 2, Desired: &two, Running: 1, Pending: 1}, HealthDegraded},
+		{common.PodInfo{Current: 3, Desired: &two, Running: 2, Failed: 1}, HealthDegraded},
+		{common.PodInfo{Current: 2, Desired: &two, Pending: 2}, HealthUnhealthy},
+	}
+
+	for _, c := range cases {
+		if actual := getHealth(c.pods); actual != c.expected {
+			t.Errorf("getHealth(%#v) == %s, expected %s", c.pods, actual, c.expected)
+		}
+	}
+}
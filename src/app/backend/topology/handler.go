@@ -0,0 +1,142 @@
+package topology
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	restful "github.com/emicklei/go-restful/v3"
+	"k8s.io/client-go/rest"
+
+	clientapi "github.com/kubernetes/dashboard/src/app/backend/client/api"
+	backenderrors "github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+)
+
+// TopologyHandler serves the service graph of the mesh.
+type TopologyHandler struct {
+	clientManager clientapi.ClientManager
+	cache         *graphCache
+	traffic       TrafficSource
+}
+
+// Install creates new endpoints for the mesh topology.
+func (self TopologyHandler) Install(ws *restful.WebService) {
+	ws.Route(
+		ws.GET("/topology").
+			To(self.handleGetTopology).
+			Writes(Graph{}))
+
+	ws.Route(
+		ws.GET("/topology/update").
+			To(self.handleGetTopologyUpdate).
+			Writes(GraphUpdate{}))
+}
+
+// handleGetTopology returns the graph of the namespaces in the namespace query parameter, or of all
+// namespaces. The version of the graph is its ETag.
+func (self TopologyHandler) handleGetTopology(request *restful.Request, response *restful.Response) {
+	key, build, err := self.graphBuilder(request)
+	if err != nil {
+		backenderrors.HandleInternalError(response, err)
+		return
+	}
+
+	graph, err := self.cache.get(key, build)
+	if err != nil {
+		backenderrors.HandleInternalError(response, err)
+		return
+	}
+
+	etag := `"` + graph.Version + `"`
+	response.AddHeader("ETag", etag)
+	if request.HeaderParameter("If-None-Match") == etag {
+		response.WriteHeader(http.StatusNotModified)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, graph)
+}
+
+// handleGetTopologyUpdate returns the changes of the graph since the version in the since query
+// parameter.
+func (self TopologyHandler) handleGetTopologyUpdate(request *restful.Request, response *restful.Response) {
+	key, build, err := self.graphBuilder(request)
+	if err != nil {
+		backenderrors.HandleInternalError(response, err)
+		return
+	}
+
+	update, err := self.cache.update(key, request.QueryParameter("since"), build)
+	if err != nil {
+		backenderrors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, update)
+}
+
+// graphBuilder returns the cache key of the request and a function that builds its graph with the
+// clients of the user.
+func (self TopologyHandler) graphBuilder(request *restful.Request) (string, func() (*Graph, error), error) {
+	config, err := self.clientManager.Config(request)
+	if err != nil {
+		return "", nil, err
+	}
+
+	client, err := self.clientManager.Client(request)
+	if err != nil {
+		return "", nil, err
+	}
+
+	osmConfigClient, err := self.clientManager.OsmConfigClient(request)
+	if err != nil {
+		return "", nil, err
+	}
+
+	smiAccessClient, err := self.clientManager.SmiAccessClient(request)
+	if err != nil {
+		return "", nil, err
+	}
+
+	smiSpecsClient, err := self.clientManager.SmiSpecsClient(request)
+	if err != nil {
+		return "", nil, err
+	}
+
+	namespaces := parseNamespaces(request.QueryParameter("namespace"))
+	build := func() (*Graph, error) {
+		return GetGraph(smiAccessClient, smiSpecsClient, osmConfigClient, client,
+			common.NewNamespaceQuery(namespaces), self.traffic)
+	}
+
+	return cacheKey(config, namespaces), build, nil
+}
+
+// NewTopologyHandler creates a topology handler. The traffic source may be nil, in which case edges
+// carry no observed traffic.
+func NewTopologyHandler(clientManager clientapi.ClientManager, traffic TrafficSource) TopologyHandler {
+	return TopologyHandler{clientManager: clientManager, cache: newGraphCache(graphTTL), traffic: traffic}
+}
+
+// parseNamespaces splits a comma separated list of namespaces.
+func parseNamespaces(param string) []string {
+	namespaces := make([]string, 0)
+	for _, namespace := range strings.Split(param, ",") {
+		if namespace = strings.TrimSpace(namespace); len(namespace) > 0 {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+	return namespaces
+}
+
+// cacheKey hashes the credentials of a config together with the namespaces of a graph.
+func cacheKey(config *rest.Config, namespaces []string) string {
+	hash := sha256.New()
+	for _, value := range []string{config.Host, config.BearerToken, config.BearerTokenFile, config.Username,
+		config.Password, config.Impersonate.UserName, string(config.CertData), config.CertFile,
+		strings.Join(config.Impersonate.Groups, ","), strings.Join(namespaces, ",")} {
+		hash.Write([]byte(value))
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
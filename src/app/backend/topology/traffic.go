@@ -0,0 +1,31 @@
+package topology
+
+import (
+	"time"
+)
+
+// Traffic is the traffic observed on an edge over a time window.
+type Traffic struct {
+	// RequestRate is the number of requests per second.
+	RequestRate float64 `json:"requestRate"`
+
+	// ErrorRate is the ratio of failed requests, between 0 and 1.
+	ErrorRate float64 `json:"errorRate"`
+
+	// LatencyP99 is the 99th percentile request latency in milliseconds.
+	LatencyP99 float64 `json:"latencyP99"`
+}
+
+// ObservedTraffic is the traffic a metrics source observed from a workload to a service.
+type ObservedTraffic struct {
+	Source      NodeRef
+	Destination NodeRef
+	Traffic     Traffic
+}
+
+// TrafficSource provides the traffic observed between the workloads and services of the mesh.
+type TrafficSource interface {
+	// ObservedTraffic returns the traffic from workloads to services of the given namespaces over the
+	// window ending now. All namespaces are included when none are given.
+	ObservedTraffic(namespaces []string, window time.Duration) ([]ObservedTraffic, error)
+}
@@ -17,10 +17,10 @@ package validation
 import (
 	"testing"
 
-	api "k8s.io/api/core/v1"
+	osmconfigv1alph2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	fakeosmconfigclientset "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned/fake"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/client-go/kubernetes/fake"
 )
 
 func TestValidateMesh(t *testing.T) {
@@ -40,39 +40,35 @@ func TestValidateMesh(t *testing.T) {
 		},
 		{
 			metadata,
-			[]runtime.Object{&api.ReplicationController{
+			[]runtime.Object{&osmconfigv1alph2.MeshConfig{
 				ObjectMeta: metaV1.ObjectMeta{
-					Name: "rc-1", Namespace: "ns-1",
+					Name: "mc-1", Namespace: "ns-1",
 				},
 			}},
 			true,
 		},
 		{
 			metadata,
-			[]runtime.Object{&api.Service{
+			[]runtime.Object{&osmconfigv1alph2.MeshConfig{
 				ObjectMeta: metaV1.ObjectMeta{
-					Name: "rc-1", Namespace: "ns-1",
+					Name: "foo-name-mesh-config", Namespace: "ns-1",
 				},
 			}},
 			true,
 		},
 		{
 			metadata,
-			[]runtime.Object{&api.ReplicationController{
+			[]runtime.Object{&osmconfigv1alph2.MeshConfig{
 				ObjectMeta: metaV1.ObjectMeta{
-					Name: "rc-1", Namespace: "ns-1",
-				},
-			}, &api.Service{
-				ObjectMeta: metaV1.ObjectMeta{
-					Name: "rc-1", Namespace: "ns-1",
+					Name: "foo-name-mesh-config", Namespace: "foo-namespace",
 				},
 			}},
-			true,
+			false,
 		},
 	}
 
 	for _, c := range cases {
-		testClient := fake.NewSimpleClientset(c.objects...)
+		testClient := fakeosmconfigclientset.NewSimpleClientset(c.objects...)
 		validity, _ := ValidateMeshName(c.metadata, testClient)
 		if validity.Valid != c.expected {
 			t.Errorf("Expected %#v validity to be %#v for objects %#v, but was %#v\n",